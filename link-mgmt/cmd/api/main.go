@@ -13,6 +13,7 @@ import (
 	"link-mgmt/pkg/api"
 	"link-mgmt/pkg/config"
 	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/version"
 )
 
 func main() {
@@ -24,27 +25,37 @@ func main() {
 	ctx := context.Background()
 
 	// Initialize database
-	database, err := db.New(ctx, cfg.Database.URL)
+	queryTimeout := time.Duration(cfg.Database.QueryTimeout) * time.Second
+	database, err := db.New(ctx, cfg.Database.URL, queryTimeout, db.PoolOptions{
+		MaxConns:        int32(cfg.Database.MaxConns),
+		MinConns:        int32(cfg.Database.MinConns),
+		MaxConnIdleTime: time.Duration(cfg.Database.MaxConnIdleTime) * time.Second,
+	})
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 	defer database.Close()
 
+	// Cancelled at shutdown so in-flight scrapes abort instead of holding
+	// connections open until the shutdown timeout
+	lifecycle, stopScrapes := context.WithCancel(context.Background())
+	defer stopScrapes()
+
 	// Initialize router (now passes config)
-	router := api.NewRouter(database, cfg)
+	router := api.NewRouter(lifecycle, database, cfg)
 
 	// Create server
 	srv := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.API.Host, cfg.API.Port),
 		Handler:      router,
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+		WriteTimeout: 15 * time.Second, // scraping handlers extend it to their scrape timeout
 		IdleTimeout:  60 * time.Second,
 	}
 
 	// Start server in goroutine
 	go func() {
-		log.Printf("API server starting on %s", srv.Addr)
+		log.Printf("API server %s starting on %s", version.Get(), srv.Addr)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("server failed: %v", err)
 		}
@@ -56,6 +67,7 @@ func main() {
 	<-quit
 
 	log.Println("shutting down server...")
+	stopScrapes()
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
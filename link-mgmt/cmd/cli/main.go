@@ -6,35 +6,116 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"link-mgmt/pkg/cli"
+	"link-mgmt/pkg/cli/logger"
 	"link-mgmt/pkg/config"
-	"link-mgmt/pkg/scraper"
+	"link-mgmt/pkg/models"
 	"link-mgmt/pkg/utils"
+	"link-mgmt/pkg/version"
 )
 
 func main() {
 	var (
-		register  = flag.String("register", "", "Register a new user account (provide email)")
-		scrapeURL = flag.String("scrape", "", "Scrape a URL to extract title and text content")
-		saveURL   = flag.String("save", "", "Save a link to the API (provide URL)")
+		register    = flag.String("register", "", "Register a new user account (provide email)")
+		whoami      = flag.Bool("whoami", false, "Show the account for the configured API key")
+		rotateKey   = flag.Bool("rotate-key", false, "Replace the configured API key with a new one")
+		readOnlyKey = flag.Bool("create-readonly-key", false, "Create an additional read-only API key for integrations")
+		deleteAcct  = flag.Bool("delete-account", false, "Permanently delete the configured account and all of its links")
+		keyExpiry   = flag.Int("expires-in-days", 0, "Days until the new key expires, 0 = never (with --rotate-key or --create-readonly-key; default: server setting)")
+		scrapeURL   = flag.String("scrape", "", "Scrape a URL to extract title and text content")
+		saveURL     = flag.String("save", "", "Save a link to the API (provide URL)")
+		addURL      = flag.String("add", "", "Scrape and save a link in one step (provide URL)")
+		noScrape    = flag.Bool("no-scrape", false, "Save without scraping (with --add)")
+		verify      = flag.Bool("verify", false, "Check that the URL is reachable before saving it (with --save or --add)")
+		favorites   = flag.Bool("favorites", false, "List favorite links")
+		untitled    = flag.Bool("untitled", false, "List links without a title (e.g. not yet scraped)")
+		unread      = flag.Bool("unread", false, "List links not yet marked as read")
+		since       = flag.String("since", "", "List links created on or after a date (YYYY-MM-DD)")
+		until       = flag.String("until", "", "List links created before a date (YYYY-MM-DD, exclusive)")
+		search      = flag.String("search", "", "List links whose title, URL, or description contains text (ignores case and accents)")
+		staleDays   = flag.Int("stale", 0, "List links never scraped or last scraped more than N days ago (or enrich them, with --enrich-all)")
+		fields      = flag.String("fields", "", "Comma-separated columns to list: id, url, title, site, visits, created, updated (default id,url,title,created); alone, lists all links")
+		groupBy     = flag.String("group-by", "", "Group listed links: domain (by host, ignoring www. and ports); alone, lists all links")
+		viewID      = flag.String("view", "", "Show a link's details (provide ID or short ID prefix)")
+		exportMD    = flag.String("export-md", "", "Print a link as a Markdown snippet (provide ID or short ID prefix)")
+		openID      = flag.String("open", "", "Open a link in the default browser (provide ID or short ID prefix)")
+		copyID      = flag.String("copy-url", "", "Copy a link's URL to the clipboard (provide ID or short ID prefix)")
+		deleteID    = flag.String("delete", "", "Delete a link (provide ID or short ID prefix)")
+		transfer    = flag.String("transfer", "", "Move links to another account: comma-separated IDs or short ID prefixes, or all (with --to-key)")
+		toKey       = flag.String("to-key", "", "API key of the account to move links to (with --transfer)")
+		yes         = flag.Bool("yes", false, "Skip the confirmation prompt (with --delete, --transfer, or --dedupe --prune)")
+		dedupe      = flag.Bool("dedupe", false, "List groups of duplicate links (same URL up to http/https, host case, or trailing slash)")
+		prune       = flag.Bool("prune", false, "Delete all but one link in each duplicate group (with --dedupe)")
+		keep        = flag.String("keep", "oldest", "Which duplicate to keep with --prune: oldest or metadata (most fields filled)")
+		enrichAll   = flag.Bool("enrich-all", false, "Re-scrape every link missing a title or text (with --stale N, also links not scraped in N days)")
+
+		// Update command (non-interactive edits)
+		updateID       = flag.String("update", "", "Update a link (provide ID or short ID prefix)")
+		setTitle       = flag.String("set-title", "", "New title (with --update)")
+		setDescription = flag.String("set-description", "", "New description (with --update)")
+		setText        = flag.String("set-text", "", "New text content (with --update)")
+		setNotes       = flag.String("set-notes", "", "New private notes, never touched by scraping (with --update)")
+		clearFields    = flag.String("clear", "", "Comma-separated fields to clear to null, e.g. title,description (with --update)")
 
 		// Config commands
-		configShow = flag.Bool("config-show", false, "Show current configuration")
-		configSet  = flag.String("config-set", "", "Set a config value (format: section.key=value)")
+		configShow  = flag.Bool("config-show", false, "Show current configuration, with secrets masked")
+		showSecrets = flag.Bool("show-secrets", false, "Show the API key and database password unmasked (with --config-show)")
+		configInit  = flag.Bool("config-init", false, "Write a commented default config file")
+		force       = flag.Bool("force", false, "Overwrite an existing config file (with --config-init)")
+		configSet   = flag.String("config-set", "", "Set a config value (format: section.key=value)")
+		configGet   = flag.String("config-get", "", "Print a single config value (format: section.key)")
+		configEdit  = flag.Bool("config-edit", false, "Edit config values in an interactive form")
+
+		// Database schema commands (connect to database.url directly)
+		migrate       = flag.Bool("migrate", false, "Apply pending database migrations")
+		migrateStatus = flag.Bool("migrate-status", false, "List database migrations and whether each is applied")
+
+		quiet       = flag.Bool("quiet", false, "Print only results, without progress and status lines (with --scrape, --add, or --register)")
+		showVersion = flag.Bool("version", false, "Print version and build information")
+		doctor      = flag.Bool("doctor", false, "Check config, API, authentication, and scraper connectivity")
 	)
 	flag.Parse()
 
+	if *showVersion {
+		fmt.Printf("link-mgmt %s\n", version.Get())
+		return
+	}
+
+	// Handle config init before loading, since Load creates a plain default file
+	if *configInit {
+		app := cli.NewApp(config.DefaultConfig())
+		if err := app.InitConfig(*force); err != nil {
+			log.Fatalf("failed to initialize config: %v", err)
+		}
+		return
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
+	if err := logger.Configure(cfg.CLI.LogSinks, cfg.CLI.LogLevel); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: invalid logging config, using defaults: %v\n", err)
+	}
+	defer logger.CloseLog()
+
 	app := cli.NewApp(cfg)
+	app.SetQuiet(*quiet)
 
 	// Handle config commands first (don't need API connection)
 	if *configShow {
-		app.ShowConfig()
+		app.ShowConfig(*showSecrets)
+		return
+	}
+	if *configGet != "" {
+		value, err := app.GetConfig(*configGet)
+		if err != nil {
+			log.Fatalf("failed to get config: %v", err)
+		}
+		fmt.Println(value)
 		return
 	}
 	if *configSet != "" {
@@ -44,6 +125,35 @@ func main() {
 		fmt.Println("Configuration updated successfully")
 		return
 	}
+	if *configEdit {
+		if err := app.EditConfig(); err != nil {
+			log.Fatalf("failed to edit config: %v", err)
+		}
+		return
+	}
+
+	// Handle migrations (need database.url but not the API)
+	if *migrate {
+		if err := app.Migrate(); err != nil {
+			log.Fatalf("failed to migrate database: %v", err)
+		}
+		return
+	}
+	if *migrateStatus {
+		if err := app.MigrateStatus(); err != nil {
+			log.Fatalf("failed to get migration status: %v", err)
+		}
+		return
+	}
+
+	// Handle doctor command (reports missing config itself rather than failing)
+	if *doctor {
+		if err := app.Doctor(); err != nil {
+			fmt.Fprintf(os.Stderr, "\n%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Handle registration (needs API URL but not API key)
 	if *register != "" {
@@ -56,78 +166,93 @@ func main() {
 		return
 	}
 
-	// Handle scrape command (needs base URL but not API key)
-	if *scrapeURL != "" {
+	// Handle whoami (needs base URL and API key)
+	if *whoami {
 		if cfg.CLI.BaseURL == "" {
 			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
 		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
 
-		// Validate URL format
-		urlStr, err := utils.ValidateURL(*scrapeURL)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: invalid URL: %v\n", err)
-			os.Exit(1)
+		if err := app.WhoAmI(); err != nil {
+			log.Fatalf("whoami failed: %v", err)
+		}
+		return
+	}
+
+	// Handle API key rotation (needs base URL and API key)
+	if *rotateKey {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
 		}
 
-		// Get scraper service
-		scraperService := scraper.NewScraperService(cfg.CLI.BaseURL)
-
-		// Check health first
-		fmt.Print("⏳ Checking scraper service... ")
-		if err := scraperService.CheckHealth(); err != nil {
-			fmt.Println("✗")
-
-			// Provide helpful guidance for connection errors
-			errStr := err.Error()
-			if strings.Contains(errStr, "connection refused") || strings.Contains(errStr, "dial tcp") {
-				log.Fatalf("scraper service unavailable: %v\n\n"+
-					"💡 The services are not running. To start them:\n"+
-					"   From project root: make dev-upd\n"+
-					"   Or: docker compose --profile dev up -d --build\n\n"+
-					"This will start:\n"+
-					"  - Nginx reverse proxy (port 80)\n"+
-					"  - API service (api-dev)\n"+
-					"  - Scraper service (scraper-dev)\n"+
-					"  - PostgreSQL database", err)
+		// Only send an expiry if it was explicitly passed, otherwise the server default applies
+		var expiresInDays *int
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "expires-in-days" {
+				expiresInDays = keyExpiry
 			}
+		})
 
-			log.Fatalf("scraper service unavailable: %v\n\nPlease check if the service is running", err)
+		if err := app.RotateAPIKey(expiresInDays); err != nil {
+			log.Fatalf("failed to rotate API key: %v", err)
 		}
-		fmt.Println("✓")
+		return
+	}
 
-		// Scrape the URL
-		fmt.Printf("⏳ Scraping URL... (this may take a few seconds)\n")
-		timeout := cfg.CLI.ScrapeTimeout
-		if timeout <= 0 {
-			timeout = 30
+	// Handle read-only key creation (needs base URL and API key)
+	if *readOnlyKey {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
 		}
-		result, err := scraperService.Scrape(urlStr, timeout*1000) // timeout in ms
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: scraping failed: %v\n", err)
-			os.Exit(1)
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
 		}
 
-		if !result.Success {
-			fmt.Fprintf(os.Stderr, "Error: scraping failed: %s\n", result.Error)
-			os.Exit(1)
+		// Only send an expiry if it was explicitly passed, otherwise the server default applies
+		var expiresInDays *int
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "expires-in-days" {
+				expiresInDays = keyExpiry
+			}
+		})
+
+		if err := app.CreateReadOnlyAPIKey(expiresInDays); err != nil {
+			log.Fatalf("failed to create read-only API key: %v", err)
+		}
+		return
+	}
+
+	// Handle account deletion (needs base URL and API key)
+	if *deleteAcct {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
 		}
 
-		// Display results
-		fmt.Println("\n✓ Scraping successful!")
-		fmt.Printf("\nURL: %s\n", result.URL)
-		if result.Title != "" {
-			fmt.Printf("Title: %s\n", result.Title)
-		} else {
-			fmt.Println("Title: (no title)")
+		if err := app.DeleteAccount(); err != nil {
+			log.Fatalf("failed to delete account: %v", err)
 		}
-		if result.Text != "" {
-			truncated := truncateText(result.Text, 500)
-			fmt.Printf("Text: %s\n", truncated)
-			if len(result.Text) > 500 {
-				fmt.Printf("\n(Text truncated, full length: %d characters)\n", len(result.Text))
-			}
-		} else {
-			fmt.Println("Text: (no text content)")
+		return
+	}
+
+	// Handle scrape command (needs a scraper URL but not API key)
+	if *scrapeURL != "" {
+		// Validate URL format
+		urlStr, err := utils.ValidateURL(*scrapeURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid URL: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := app.ScrapeURL(urlStr); err != nil {
+			log.Fatalf("failed to scrape URL: %v", err)
 		}
 		return
 	}
@@ -148,23 +273,236 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err := app.SaveLink(urlStr); err != nil {
+		if err := app.SaveLink(urlStr, *verify); err != nil {
 			log.Fatalf("failed to save link: %v", err)
 		}
 		return
 	}
 
+	// Handle add command (needs base URL and API key)
+	if *addURL != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		// Validate URL format
+		urlStr, err := utils.ValidateURL(*addURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid URL: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := app.AddLink(urlStr, !*noScrape, *verify); err != nil {
+			log.Fatalf("failed to add link: %v", err)
+		}
+		return
+	}
+
+	// Handle update command (needs base URL and API key)
+	if *updateID != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		// Only send fields that were explicitly passed, so "--set-title=" clears a title
+		var update models.LinkUpdate
+		flag.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "set-title":
+				update.Title = setTitle
+			case "set-description":
+				update.Description = setDescription
+			case "set-text":
+				update.Text = setText
+			case "set-notes":
+				update.Notes = setNotes
+			}
+		})
+		for _, field := range strings.Split(*clearFields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				update.ClearFields = append(update.ClearFields, field)
+			}
+		}
+
+		if err := app.UpdateLink(*updateID, update); err != nil {
+			log.Fatalf("failed to update link: %v", err)
+		}
+		return
+	}
+
+	// Handle view command (needs base URL and API key)
+	if *viewID != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		if err := app.ViewLink(*viewID); err != nil {
+			log.Fatalf("failed to view link: %v", err)
+		}
+		return
+	}
+
+	// Handle Markdown export (needs base URL and API key)
+	if *exportMD != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		if err := app.ExportMarkdown(*exportMD); err != nil {
+			log.Fatalf("failed to export link: %v", err)
+		}
+		return
+	}
+
+	// Handle open command (needs base URL and API key)
+	if *openID != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		if err := app.OpenLink(*openID); err != nil {
+			log.Fatalf("failed to open link: %v", err)
+		}
+		return
+	}
+
+	// Handle copy command (needs base URL and API key)
+	if *copyID != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		if err := app.CopyURL(*copyID); err != nil {
+			log.Fatalf("failed to copy URL: %v", err)
+		}
+		return
+	}
+
+	// Handle delete command (needs base URL and API key)
+	if *deleteID != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		if err := app.DeleteLink(*deleteID, *yes); err != nil {
+			log.Fatalf("failed to delete link: %v", err)
+		}
+		return
+	}
+
+	// Handle transfer command (needs base URL and API key)
+	if *transfer != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		if err := app.TransferLinks(*transfer, *toKey, *yes); err != nil {
+			log.Fatalf("failed to transfer links: %v", err)
+		}
+		return
+	}
+
+	// Handle dedupe command (needs base URL and API key)
+	if *dedupe {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		if err := app.Dedupe(*prune, *keep, *yes); err != nil {
+			log.Fatalf("failed to dedupe links: %v", err)
+		}
+		return
+	}
+
+	// --stale 0 is meaningful (anything scraped before now), so detect it explicitly
+	var stale *int
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "stale" {
+			stale = staleDays
+		}
+	})
+
+	if stale != nil && *stale < 0 {
+		log.Fatalf("invalid --stale value %d (expected days >= 0)", *stale)
+	}
+
+	// Handle bulk enrichment (needs base URL and API key)
+	if *enrichAll {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		if err := app.EnrichAll(stale); err != nil {
+			log.Fatalf("failed to enrich links: %v", err)
+		}
+		return
+	}
+
+	// Handle filtered listing (needs base URL and API key)
+	if *favorites || *untitled || *unread || *since != "" || *until != "" || stale != nil || *search != "" || *fields != "" || *groupBy != "" {
+		if cfg.CLI.BaseURL == "" {
+			log.Fatalf("Base URL not configured. Set it with: --config-set cli.base_url=<url>")
+		}
+		if cfg.CLI.APIKey == "" {
+			log.Fatalf("API key not configured. Register a user with --register <email> or set it with: --config-set cli.api_key=<key>")
+		}
+
+		filter := models.LinkFilter{FavoritesOnly: *favorites, UntitledOnly: *untitled, UnreadOnly: *unread, Search: *search}
+		if *since != "" {
+			t, err := time.Parse(models.LinkFilterDateLayout, *since)
+			if err != nil {
+				log.Fatalf("invalid --since date %q (expected YYYY-MM-DD)", *since)
+			}
+			filter.CreatedAfter = &t
+		}
+		if *until != "" {
+			t, err := time.Parse(models.LinkFilterDateLayout, *until)
+			if err != nil {
+				log.Fatalf("invalid --until date %q (expected YYYY-MM-DD)", *until)
+			}
+			filter.CreatedBefore = &t
+		}
+		filter.StaleDays = stale
+
+		if err := app.ListLinks(filter, *fields, *groupBy); err != nil {
+			log.Fatalf("failed to list links: %v", err)
+		}
+		return
+	}
+
 	// Interactive TUI mode
 	if err := app.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 }
-
-// truncateText truncates text to a maximum length, adding ellipsis if truncated
-func truncateText(text string, maxLen int) string {
-	if len(text) <= maxLen {
-		return text
-	}
-	return text[:maxLen] + "..."
-}
@@ -1,4 +1,4 @@
-CREATE TABLE links (
+CREATE TABLE IF NOT EXISTS links (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     url TEXT NOT NULL,
@@ -10,5 +10,5 @@ CREATE TABLE links (
     UNIQUE(user_id, url)
 );
 
-CREATE INDEX idx_links_user_id ON links(user_id);
-CREATE INDEX idx_links_created_at ON links(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_links_user_id ON links(user_id);
+CREATE INDEX IF NOT EXISTS idx_links_created_at ON links(created_at DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE links ADD COLUMN IF NOT EXISTS is_favorite BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_links_user_favorite ON links(user_id) WHERE is_favorite;
@@ -0,0 +1,2 @@
+ALTER TABLE links ADD COLUMN IF NOT EXISTS favicon TEXT;
+ALTER TABLE links ADD COLUMN IF NOT EXISTS site_name TEXT;
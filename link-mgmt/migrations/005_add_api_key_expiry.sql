@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP;
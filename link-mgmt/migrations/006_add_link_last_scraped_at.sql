@@ -0,0 +1 @@
+ALTER TABLE links ADD COLUMN IF NOT EXISTS last_scraped_at TIMESTAMP;
@@ -0,0 +1,4 @@
+-- Manual ordering set by PUT /api/v1/links/reorder; NULL until a user reorders
+ALTER TABLE links ADD COLUMN IF NOT EXISTS position INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_links_user_position ON links(user_id, position);
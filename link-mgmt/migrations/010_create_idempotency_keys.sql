@@ -0,0 +1,10 @@
+-- Idempotency-Key values sent with POST /api/v1/links, so a retried create
+-- returns the original link instead of creating another. link_id is NULL only
+-- inside the transaction that records the key.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    link_id UUID REFERENCES links(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, key)
+);
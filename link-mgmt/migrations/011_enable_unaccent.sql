@@ -0,0 +1,9 @@
+-- Lets ?q= search ignore accents ("cafe" matches "Café"). Skipped with a notice
+-- where the extension isn't installed; search then only ignores case.
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS unaccent;
+EXCEPTION WHEN OTHERS THEN
+    RAISE NOTICE 'unaccent extension unavailable (%); search will not ignore accents', SQLERRM;
+END
+$$;
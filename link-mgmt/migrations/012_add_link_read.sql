@@ -0,0 +1,3 @@
+ALTER TABLE links ADD COLUMN IF NOT EXISTS is_read BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_links_user_unread ON links(user_id) WHERE NOT is_read;
@@ -0,0 +1 @@
+ALTER TABLE links ADD COLUMN IF NOT EXISTS visit_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+-- A replayed Idempotency-Key must not create the link again once it has been
+-- deleted, so keep the key (with link_id NULL) instead of cascading. The
+-- request hash lets a key reused with a different body be rejected.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS request_hash CHAR(64);
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_link_id_fkey;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_link_id_fkey
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL migrations so binaries can apply them
+// without a checkout of this directory.
+package migrations
+
+import "embed"
+
+// FS holds the migration files, applied in filename order
+//
+//go:embed *.sql
+var FS embed.FS
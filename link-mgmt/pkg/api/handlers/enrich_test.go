@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/scraper"
+	"link-mgmt/pkg/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sseEvent is one event read from a Server-Sent Events stream
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvents reads every event from an SSE stream until it ends
+func readEvents(t *testing.T, body io.Reader) []sseEvent {
+	t.Helper()
+
+	var events []sseEvent
+	var event sseEvent
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if event.name != "" {
+				events = append(events, event)
+			}
+			event = sseEvent{}
+		case strings.HasPrefix(line, "event:"):
+			event.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			event.data += strings.TrimPrefix(line, "data:")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("reading event stream: %v", err)
+	}
+	if event.name != "" {
+		events = append(events, event)
+	}
+	return events
+}
+
+// streamEnrich serves EnrichLinkStream for userID on a real HTTP server (the
+// stream needs a connection to write to) with the given write timeout, and
+// returns the events read for linkID
+func streamEnrich(t *testing.T, service *services.LinkService, userID, linkID uuid.UUID, writeTimeout time.Duration, query string) []sseEvent {
+	t.Helper()
+
+	router := gin.New()
+	router.GET("/links/:id/enrich/stream", func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	}, EnrichLinkStream(service))
+
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = writeTimeout
+	server.Start()
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/links/" + linkID.String() + "/enrich/stream?" + query)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/event-stream") {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+	return readEvents(t, resp.Body)
+}
+
+// scraperStub returns a scraper service whose requests are answered with a
+// successful scrape titled title after delay
+func scraperStub(t *testing.T, title string, delay time.Duration) *scraper.ScraperService {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "url": "https://example.com", "title": title})
+	}))
+	t.Cleanup(server.Close)
+	return scraper.NewScraperService(server.URL)
+}
+
+// eventNames returns the names of events, with each progress event's stage
+// appended after a colon (e.g. "progress:fetching")
+func eventNames(t *testing.T, events []sseEvent) []string {
+	t.Helper()
+
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.name
+		if event.name == "progress" {
+			var progress enrichProgressEvent
+			if err := json.Unmarshal([]byte(event.data), &progress); err != nil {
+				t.Fatalf("progress event %q: %v", event.data, err)
+			}
+			names[i] += ":" + string(progress.Stage)
+		}
+	}
+	return names
+}
+
+func TestEnrichLinkStreamStages(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/stream", nil)
+	service := services.NewLinkService(database, scraperStub(t, "Streamed Title", 0))
+
+	events := streamEnrich(t, service, user.ID, link.ID, 0, "")
+
+	want := []string{"progress:health_check", "progress:fetching", "progress:extracting", "progress:complete", "link"}
+	if got := eventNames(t, events); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	var enriched struct {
+		ID    uuid.UUID `json:"id"`
+		Title *string   `json:"title"`
+	}
+	if err := json.Unmarshal([]byte(events[len(events)-1].data), &enriched); err != nil {
+		t.Fatalf("link event: %v", err)
+	}
+	if enriched.ID != link.ID || enriched.Title == nil || *enriched.Title != "Streamed Title" {
+		t.Errorf("link event = %s, want the link titled from the scrape", events[len(events)-1].data)
+	}
+
+	// Scraping identical content again reports it unchanged
+	events = streamEnrich(t, service, user.ID, link.ID, 0, "")
+	names := eventNames(t, events)
+	if len(names) < 2 || names[len(names)-2] != "unchanged" || names[len(names)-1] != "link" {
+		t.Errorf("second scrape events = %v, want them to end with unchanged, link", names)
+	}
+}
+
+func TestEnrichLinkStreamOutlastsWriteTimeout(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/slow", nil)
+	service := services.NewLinkService(database, scraperStub(t, "Slow Page", time.Second))
+
+	// The scrape takes longer than the server's write timeout
+	events := streamEnrich(t, service, user.ID, link.ID, 300*time.Millisecond, "timeout=2")
+
+	names := eventNames(t, events)
+	if len(names) == 0 || names[len(names)-1] != "link" {
+		t.Errorf("events = %v, want the stream to end with the link", names)
+	}
+}
+
+func TestEnrichLinkStreamError(t *testing.T) {
+	// pgxpool connects lazily; the first query fails to connect
+	pool, err := pgxpool.New(context.Background(), "postgres://test@127.0.0.1:1/test?connect_timeout=1")
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	service := services.NewLinkService(&db.DB{Pool: pool, QueryTimeout: time.Second}, nil)
+
+	events := streamEnrich(t, service, uuid.New(), uuid.New(), 0, "")
+
+	if names := eventNames(t, events); strings.Join(names, " ") != "error" {
+		t.Fatalf("events = %v, want a single error event", names)
+	}
+	var payload struct {
+		Error  string `json:"error"`
+		Status int    `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(events[0].data), &payload); err != nil {
+		t.Fatalf("error event: %v", err)
+	}
+	if payload.Error == "" || payload.Status != http.StatusInternalServerError {
+		t.Errorf("error event = %s, want a message and status 500", events[0].data)
+	}
+}
+
+func TestEnrichLinkStreamInvalidID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/links/not-a-uuid/enrich/stream", nil)
+	w := serve(EnrichLinkStream(newLinkService(nil)), uuid.New(), http.MethodGet, "/links/:id/enrich/stream", req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
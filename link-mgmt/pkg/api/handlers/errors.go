@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"link-mgmt/pkg/api/middleware"
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// scrapeBusyRetrySeconds is the Retry-After sent with ErrScrapeBusy
+const scrapeBusyRetrySeconds = 5
+
+// writeError responds with the status code matching a service/db error. The
+// body carries the request ID, as for a recovered panic, so a reported error
+// can be found in the logs.
+func writeError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrScrapeBusy) {
+		c.Header("Retry-After", strconv.Itoa(scrapeBusyRetrySeconds))
+	}
+	body := gin.H{"error": err.Error(), "request_id": middleware.GetRequestID(c)}
+	var unreachable *services.UnreachableError
+	if errors.As(err, &unreachable) && unreachable.StatusCode != 0 {
+		body["status"] = unreachable.StatusCode
+	}
+	c.JSON(errorStatus(err), body)
+}
+
+// errorStatus returns the HTTP status code for a service/db error
+func errorStatus(err error) int {
+	status := http.StatusInternalServerError
+	var validationErr *services.ValidationError
+	var unreachable *services.UnreachableError
+	switch {
+	case errors.As(err, &validationErr):
+		status = http.StatusBadRequest
+	case errors.As(err, &unreachable):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, db.ErrLinkNotFound), errors.Is(err, db.ErrUserNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, db.ErrDuplicateLink), errors.Is(err, db.ErrDuplicateEmail), errors.Is(err, db.ErrIdempotencyKeyReused):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrTransferTarget):
+		status = http.StatusForbidden
+	case errors.Is(err, db.ErrAPIKeyExpired):
+		status = http.StatusUnauthorized
+	case errors.Is(err, db.ErrQueryTimeout):
+		status = http.StatusGatewayTimeout
+	case errors.Is(err, services.ErrScrapeBusy):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, services.ErrShuttingDown):
+		status = http.StatusServiceUnavailable
+	}
+	return status
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"link-mgmt/pkg/api/middleware"
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("failed to query links: %w", db.ErrQueryTimeout), http.StatusGatewayTimeout},
+		{db.ErrLinkNotFound, http.StatusNotFound},
+		{db.ErrUserNotFound, http.StatusNotFound},
+		{db.ErrDuplicateLink, http.StatusConflict},
+		{fmt.Errorf("failed to create link: %w", db.ErrDuplicateLink), http.StatusConflict},
+		{db.ErrDuplicateEmail, http.StatusConflict},
+		{db.ErrIdempotencyKeyReused, http.StatusConflict},
+		{db.ErrAPIKeyExpired, http.StatusUnauthorized},
+		{&services.ValidationError{Field: "title", Message: "too long"}, http.StatusBadRequest},
+		{&services.UnreachableError{StatusCode: http.StatusNotFound}, http.StatusUnprocessableEntity},
+		{services.ErrTransferTarget, http.StatusForbidden},
+		{services.ErrScrapeBusy, http.StatusTooManyRequests},
+		{services.ErrShuttingDown, http.StatusServiceUnavailable},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := errorStatus(tt.err); got != tt.want {
+			t.Errorf("errorStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWriteErrorRetryAfter(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{services.ErrScrapeBusy, "5"},
+		{fmt.Errorf("enrich: %w", services.ErrScrapeBusy), "5"},
+		{db.ErrLinkNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		writeError(c, tt.err)
+		if got := w.Header().Get("Retry-After"); got != tt.want {
+			t.Errorf("writeError(%v): Retry-After = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWriteErrorIncludesRequestID(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+	}{
+		{&services.ValidationError{Field: "url", Message: "is required"}, http.StatusBadRequest},
+		{db.ErrLinkNotFound, http.StatusNotFound},
+		{db.ErrDuplicateLink, http.StatusConflict},
+		{&services.UnreachableError{StatusCode: http.StatusNotFound}, http.StatusUnprocessableEntity},
+		{services.ErrScrapeBusy, http.StatusTooManyRequests},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		router := gin.New()
+		router.Use(middleware.RequestID())
+		router.GET("/fail", func(c *gin.Context) { writeError(c, tt.err) })
+
+		req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+		req.Header.Set(middleware.RequestIDHeader, "req-handled")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("writeError(%v): body is not JSON: %v\n%s", tt.err, err, w.Body.String())
+		}
+		if w.Code != tt.wantStatus || body["request_id"] != "req-handled" {
+			t.Errorf("writeError(%v) = %d with request_id %v, want %d with %q", tt.err, w.Code, body["request_id"], tt.wantStatus, "req-handled")
+		}
+		var unreachable *services.UnreachableError
+		if errors.As(tt.err, &unreachable) && body["status"] != float64(unreachable.StatusCode) {
+			t.Errorf("writeError(%v): status = %v, want the site's %d", tt.err, body["status"], unreachable.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeJSONWithETag responds 200 with payload as JSON and an ETag hashed from
+// the body. Headers describing the response outside the body (such as
+// X-Total-Count) should be passed as extra so a change to them changes the
+// ETag too. A request whose If-None-Match names the ETag gets 304 Not
+// Modified with no body instead.
+func writeJSONWithETag(c *gin.Context, payload interface{}, extra ...string) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	hash := sha256.New()
+	hash.Write(body)
+	for _, value := range extra {
+		hash.Write([]byte{0})
+		hash.Write([]byte(value))
+	}
+	etag := `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	c.Header("ETag", etag)
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// etagMatches reports whether an If-None-Match header names etag, comparing
+// weakly (a W/ prefix is ignored) as RFC 9110 asks for If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// getWithETag serves GET / from router, sending ifNoneMatch when set
+func getWithETag(router http.Handler, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestWriteJSONWithETag(t *testing.T) {
+	payload := map[string]string{"title": "First"}
+	total := "1"
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) { writeJSONWithETag(c, payload, total) })
+
+	first := getWithETag(router, "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.String() != `{"title":"First"}` {
+		t.Fatalf("first request: status %d, ETag %q, body %s; want 200 with an ETag and the payload", first.Code, etag, first.Body)
+	}
+
+	for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		w := getWithETag(router, ifNoneMatch)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: status %d with %d bytes, want 304 with no body", ifNoneMatch, w.Code, w.Body.Len())
+		}
+		if w.Header().Get("ETag") != etag {
+			t.Errorf("If-None-Match %s: ETag %q, want %q", ifNoneMatch, w.Header().Get("ETag"), etag)
+		}
+	}
+
+	// A changed body, or a changed total, changes the ETag
+	payload["title"] = "Second"
+	changed := getWithETag(router, etag)
+	if changed.Code != http.StatusOK || changed.Body.String() != `{"title":"Second"}` {
+		t.Errorf("changed payload: status %d, body %s; want 200 with the new payload", changed.Code, changed.Body)
+	}
+	etag = changed.Header().Get("ETag")
+	total = "2"
+	if w := getWithETag(router, etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("changed total: status %d, ETag %q; want 200 with a new ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `"abc"`
+	tests := []struct {
+		ifNoneMatch string
+		want        bool
+	}{
+		{`"abc"`, true},
+		{`W/"abc"`, true},
+		{` "x" , "abc" `, true},
+		{"*", true},
+		{"", false},
+		{`"abd"`, false},
+		{`abc`, false},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.ifNoneMatch, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.ifNoneMatch, got, tt.want)
+		}
+	}
+}
+
+func TestGetLinkETag(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/article", nil)
+	handler := GetLink(newLinkService(database))
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/links/"+link.ID.String(), nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		return serve(handler, user.ID, http.MethodGet, "/links/:id", req)
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("status %d, ETag %q; want 200 with an ETag", first.Code, etag)
+	}
+	if w := get(etag); w.Code != http.StatusNotModified {
+		t.Errorf("unchanged link: status %d, want 304", w.Code)
+	}
+
+	title := "Now titled"
+	if _, err := database.UpdateLink(context.Background(), link.ID, user.ID, models.LinkUpdate{Title: &title}); err != nil {
+		t.Fatalf("UpdateLink: %v", err)
+	}
+	w := get(etag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("changed link: status %d, want 200", w.Code)
+	}
+	var got models.Link
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding link: %v", err)
+	}
+	if got.Title == nil || *got.Title != title {
+		t.Errorf("changed link: title %v, want %q", got.Title, title)
+	}
+
+	// Another user's link is still 404, whatever the ETag
+	req := httptest.NewRequest(http.MethodGet, "/links/"+link.ID.String(), nil)
+	req.Header.Set("If-None-Match", "*")
+	if w := serve(handler, uuid.New(), http.MethodGet, "/links/:id", req); w.Code != http.StatusNotFound {
+		t.Errorf("another user's link: status %d, want 404", w.Code)
+	}
+}
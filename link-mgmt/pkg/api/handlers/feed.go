@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// feedLimit is the number of most recent links included in a feed
+const feedLimit = 50
+
+// atomFeed and atomEntry are the subset of Atom (RFC 4287) used by LinksFeed
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Summary   string   `xml:"summary,omitempty"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+}
+
+// LinksFeed returns the user's most recent links as an Atom feed. Feed readers
+// usually can't send headers, so the route authenticates with ?key=<api key>.
+func LinksFeed(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+		user := c.MustGet("user").(*models.User)
+
+		links, err := service.ListLinks(c.Request.Context(), userID, models.LinkFilter{}, models.ListOptions{Limit: feedLimit})
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		data, err := xml.MarshalIndent(buildAtomFeed(user, links), "", "  ")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to render feed"})
+			return
+		}
+
+		c.Data(http.StatusOK, "application/atom+xml; charset=utf-8", append([]byte(xml.Header), data...))
+	}
+}
+
+// buildAtomFeed converts links (newest first) into an Atom feed
+func buildAtomFeed(user *models.User, links []models.Link) atomFeed {
+	feed := atomFeed{
+		ID:      fmt.Sprintf("urn:uuid:%s", user.ID),
+		Title:   "Saved links",
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: user.Email},
+		Entries: make([]atomEntry, 0, len(links)),
+	}
+
+	var latest time.Time
+	for _, link := range links {
+		title := link.URL
+		if link.Title != nil && *link.Title != "" {
+			title = *link.Title
+		}
+		entry := atomEntry{
+			ID:        fmt.Sprintf("urn:uuid:%s", link.ID),
+			Title:     title,
+			Link:      atomLink{Href: link.URL, Rel: "alternate"},
+			Published: link.CreatedAt.UTC().Format(time.RFC3339),
+			Updated:   link.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if link.Description != nil {
+			entry.Summary = *link.Description
+		}
+		feed.Entries = append(feed.Entries, entry)
+
+		if link.UpdatedAt.After(latest) {
+			latest = link.UpdatedAt
+		}
+	}
+	// The feed is as new as its newest entry
+	if !latest.IsZero() {
+		feed.Updated = latest.UTC().Format(time.RFC3339)
+	}
+
+	return feed
+}
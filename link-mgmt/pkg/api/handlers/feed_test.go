@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+// parsedFeed is what a feed reader sees of an Atom feed
+type parsedFeed struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	Author  string   `xml:"author>name"`
+	Entries []struct {
+		ID    string `xml:"id"`
+		Title string `xml:"title"`
+		Link  struct {
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+		Summary   string `xml:"summary"`
+		Published string `xml:"published"`
+	} `xml:"entry"`
+}
+
+func TestBuildAtomFeed(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "reader@example.com"}
+	title, description := "A Titled Link", "What it's about & more"
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	links := []models.Link{
+		{ID: uuid.New(), URL: "https://example.com/titled?a=1&b=2", Title: &title, Description: &description,
+			CreatedAt: created.Add(time.Hour), UpdatedAt: created.Add(2 * time.Hour)},
+		{ID: uuid.New(), URL: "https://example.com/untitled", CreatedAt: created, UpdatedAt: created},
+	}
+
+	data, err := xml.Marshal(buildAtomFeed(user, links))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var feed parsedFeed
+	if err := xml.Unmarshal(append([]byte(xml.Header), data...), &feed); err != nil {
+		t.Fatalf("feed doesn't parse: %v\n%s", err, data)
+	}
+
+	if feed.ID != "urn:uuid:"+user.ID.String() || feed.Author != user.Email {
+		t.Errorf("feed id %q, author %q; want the user's", feed.ID, feed.Author)
+	}
+	if want := "2024-03-01T14:00:00Z"; feed.Updated != want {
+		t.Errorf("feed updated = %q, want the newest entry's %q", feed.Updated, want)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(feed.Entries))
+	}
+
+	first := feed.Entries[0]
+	if first.ID != "urn:uuid:"+links[0].ID.String() || first.Title != title || first.Link.Href != links[0].URL ||
+		first.Summary != description || first.Published != "2024-03-01T13:00:00Z" {
+		t.Errorf("first entry = %+v, want the titled link", first)
+	}
+	// Without a title, the URL stands in
+	second := feed.Entries[1]
+	if second.Title != links[1].URL || second.Summary != "" || second.Published != "2024-03-01T12:00:00Z" {
+		t.Errorf("second entry = %+v, want the untitled link titled by its URL", second)
+	}
+}
+
+func TestBuildAtomFeedEmpty(t *testing.T) {
+	data, err := xml.Marshal(buildAtomFeed(&models.User{ID: uuid.New()}, nil))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var feed parsedFeed
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		t.Fatalf("feed doesn't parse: %v\n%s", err, data)
+	}
+	if len(feed.Entries) != 0 || feed.Updated == "" {
+		t.Errorf("feed = %+v, want no entries and an updated time", feed)
+	}
+}
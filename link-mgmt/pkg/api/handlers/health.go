@@ -3,6 +3,8 @@ package handlers
 import (
 	"net/http"
 
+	"link-mgmt/pkg/db"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,3 +13,20 @@ func HealthCheck(c *gin.Context) {
 		"status": "ok",
 	})
 }
+
+// ReadinessCheck reports whether the server can serve requests, i.e. the
+// database is reachable; 503 otherwise
+func ReadinessCheck(db *db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := db.Ping(c.Request.Context()); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status": "unavailable",
+				"error":  err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+		})
+	}
+}
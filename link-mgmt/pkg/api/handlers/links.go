@@ -1,9 +1,18 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/scraper"
 	"link-mgmt/pkg/services"
 
 	"github.com/gin-gonic/gin"
@@ -11,13 +20,88 @@ import (
 )
 
 // ListLinks lists all links for the authenticated user
+// Optional query parameters: favorites=true, unread=true, untitled=true,
+// created_after/created_before=YYYY-MM-DD, stale_days=N, q=text,
+// sort=created_at|updated_at|title|url|position|visit_count, order=asc|desc, limit=N, offset=N.
+// X-Total-Count carries the number of matching links; a paged request also gets
+// a Link header with next/prev page URLs. The response has an ETag, and a
+// matching If-None-Match gets 304 Not Modified.
 func ListLinks(service *services.LinkService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.MustGet("userID").(uuid.UUID)
 
-		links, err := service.ListLinks(c.Request.Context(), userID)
+		var filter models.LinkFilter
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "created_after must be before created_before"})
+			return
+		}
+		if filter.StaleDays != nil && *filter.StaleDays < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "stale_days must not be negative"})
+			return
+		}
+
+		var opts models.ListOptions
+		if err := c.ShouldBindQuery(&opts); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := opts.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		links, err := service.ListLinks(c.Request.Context(), userID, filter, opts)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeError(c, err)
+			return
+		}
+
+		total := int64(len(links))
+		if opts.Paginated() {
+			if total, err = service.CountLinks(c.Request.Context(), userID, filter); err != nil {
+				writeError(c, err)
+				return
+			}
+			if link := paginationLinkHeader(c.Request.URL, opts, total); link != "" {
+				c.Header("Link", link)
+			}
+		}
+		c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+
+		writeJSONWithETag(c, links, strconv.FormatInt(total, 10))
+	}
+}
+
+// defaultRecentDays is the window RecentLinks uses when ?days= is omitted
+const defaultRecentDays = 7
+
+// maxRecentDays bounds ?days= so the window start stays a valid timestamp
+const maxRecentDays = 36500
+
+// RecentLinks lists the links created in the last ?days= days (7 by
+// default), newest first
+func RecentLinks(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		days := defaultRecentDays
+		if raw := c.Query("days"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n <= 0 || n > maxRecentDays {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("days must be a whole number from 1 to %d", maxRecentDays)})
+				return
+			}
+			days = n
+		}
+
+		// The window is computed by the database, whose clock set created_at
+		links, err := service.ListLinks(c.Request.Context(), userID, models.LinkFilter{CreatedWithinDays: &days}, models.ListOptions{})
+		if err != nil {
+			writeError(c, err)
 			return
 		}
 
@@ -25,7 +109,41 @@ func ListLinks(service *services.LinkService) gin.HandlerFunc {
 	}
 }
 
-// CreateLink creates a new link
+// paginationLinkHeader builds an RFC 5988 Link header with next and prev
+// pages for a paged list request, keeping the request's other query
+// parameters. Returns "" when there is neither.
+func paginationLinkHeader(requestURL *url.URL, opts models.ListOptions, total int64) string {
+	pageURL := func(offset int) string {
+		u := *requestURL
+		query := u.Query()
+		query.Set("offset", strconv.Itoa(offset))
+		if opts.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		u.RawQuery = query.Encode()
+		return u.RequestURI()
+	}
+
+	var links []string
+	// Without a limit the first page already runs to the end
+	if opts.Limit > 0 && int64(opts.Offset+opts.Limit) < total {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(opts.Offset+opts.Limit)))
+	}
+	if opts.Offset > 0 {
+		prev := 0
+		if opts.Limit > 0 && opts.Offset > opts.Limit {
+			prev = opts.Offset - opts.Limit
+		}
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(prev)))
+	}
+	return strings.Join(links, ", ")
+}
+
+// CreateLink creates a new link. With an Idempotency-Key header, a repeated
+// request with the same key returns the link the first one created (marked
+// with Idempotent-Replayed: true) instead of creating another; 409 if the key
+// came with a different body, 404 if that link was deleted since. With
+// ?verify=true the URL must pass a reachability check first (422 if not).
 func CreateLink(service *services.LinkService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.MustGet("userID").(uuid.UUID)
@@ -35,18 +153,104 @@ func CreateLink(service *services.LinkService) gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if !verifyIfRequested(c, service, linkCreate.URL) {
+			return
+		}
+
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			link, err := service.CreateLink(c.Request.Context(), userID, linkCreate)
+			if err != nil {
+				writeError(c, err)
+				return
+			}
+			c.JSON(http.StatusCreated, link)
+			return
+		}
+		if len(key) > models.MaxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Idempotency-Key must be at most %d characters", models.MaxIdempotencyKeyLength)})
+			return
+		}
 
-		link, err := service.CreateLink(c.Request.Context(), userID, linkCreate)
+		link, replayed, err := service.CreateLinkIdempotent(c.Request.Context(), userID, key, linkCreate)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeError(c, err)
 			return
 		}
+		if replayed {
+			c.Header("Idempotent-Replayed", "true")
+		}
 
 		c.JSON(http.StatusCreated, link)
 	}
 }
 
-// CreateLinkWithScraping creates a link and enriches it with scraped content
+// verifyIfRequested runs the reachability check on url when the request has
+// ?verify=true, writing the error response if the value is invalid or the
+// check fails. It reports whether the create may go ahead.
+func verifyIfRequested(c *gin.Context, service *services.LinkService, url string) bool {
+	raw := c.Query("verify")
+	if raw == "" {
+		return true
+	}
+	verify, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "verify must be true or false"})
+		return false
+	}
+	if !verify {
+		return true
+	}
+	if err := service.VerifyURL(c.Request.Context(), url); err != nil {
+		writeError(c, err)
+		return false
+	}
+	return true
+}
+
+// CreateLinks creates many links in one request. Per-item failures (such as
+// duplicate URLs) are reported in the results instead of failing the batch.
+func CreateLinks(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		var linkCreates []models.LinkCreate
+		if err := c.ShouldBindJSON(&linkCreates); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(linkCreates) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "at least one link is required"})
+			return
+		}
+		if len(linkCreates) > models.MaxLinkBatchSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("at most %d links may be created per request", models.MaxLinkBatchSize)})
+			return
+		}
+
+		results, err := service.CreateLinks(c.Request.Context(), userID, linkCreates)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		created := 0
+		for _, result := range results {
+			if result.Link != nil {
+				created++
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"results": results,
+			"created": created,
+			"failed":  len(results) - created,
+		})
+	}
+}
+
+// CreateLinkWithScraping creates a link and enriches it with scraped content.
+// It takes ?verify=true like CreateLink.
 func CreateLinkWithScraping(service *services.LinkService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.MustGet("userID").(uuid.UUID)
@@ -80,6 +284,12 @@ func CreateLinkWithScraping(service *services.LinkService) gin.HandlerFunc {
 			}
 			scrapeOpts.OnlyFillEmpty = req.Scrape.OnlyFillEmpty
 		}
+		if !checkScrapeTimeout(c, scrapeOpts.TimeoutSeconds) || !verifyIfRequested(c, service, req.URL) {
+			return
+		}
+		if scrapeOpts.Enabled {
+			extendWriteDeadline(c, scrapeOpts.TimeoutSeconds)
+		}
 
 		link, err := service.CreateLinkWithScraping(
 			c.Request.Context(),
@@ -88,7 +298,7 @@ func CreateLinkWithScraping(service *services.LinkService) gin.HandlerFunc {
 			scrapeOpts,
 		)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeError(c, err)
 			return
 		}
 
@@ -96,7 +306,8 @@ func CreateLinkWithScraping(service *services.LinkService) gin.HandlerFunc {
 	}
 }
 
-// GetLink retrieves a single link
+// GetLink retrieves a single link. Like ListLinks, it sets an ETag and
+// answers a matching If-None-Match with 304 Not Modified.
 func GetLink(service *services.LinkService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.MustGet("userID").(uuid.UUID)
@@ -109,15 +320,165 @@ func GetLink(service *services.LinkService) gin.HandlerFunc {
 
 		link, err := service.GetLink(c.Request.Context(), linkID, userID)
 		if err != nil {
-			if err.Error() == "link not found" {
-				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			writeError(c, err)
+			return
+		}
+
+		writeJSONWithETag(c, link)
+	}
+}
+
+// maxScrapeTimeoutSeconds is the longest scrape timeout a request may ask
+// for, which bounds how long a scraping request holds its connection open
+const maxScrapeTimeoutSeconds = 120
+
+// checkScrapeTimeout answers 400 and returns false if a request asked for a
+// scrape timeout over maxScrapeTimeoutSeconds
+func checkScrapeTimeout(c *gin.Context, timeoutSeconds int) bool {
+	if timeoutSeconds > maxScrapeTimeoutSeconds {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("timeout must be at most %d seconds", maxScrapeTimeoutSeconds)})
+		return false
+	}
+	return true
+}
+
+// scrapeWriteSlack is how long past the scrape itself a scraping request may
+// still write its response, for the database work around the scrape
+const scrapeWriteSlack = 15 * time.Second
+
+// extendWriteDeadline moves the response write deadline to the longest the
+// scrape may take (see scraper.MaxScrapeDuration) plus scrapeWriteSlack from
+// now. Without it the server's WriteTimeout (15s) would cut off a response to
+// a scrape allowed to run longer, such as the default 30s, or one retried
+// while the scraper is throttling. Writers without deadline support (as in
+// tests) are left alone.
+func extendWriteDeadline(c *gin.Context, timeoutSeconds int) {
+	deadline := time.Now().Add(scraper.MaxScrapeDuration(timeoutSeconds) + scrapeWriteSlack)
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(deadline)
+}
+
+// enrichProgressEvent is the payload of a "progress" event on the enrich stream
+type enrichProgressEvent struct {
+	Stage   scraper.ScrapeStage `json:"stage"`
+	Message string              `json:"message"`
+}
+
+// EnrichLinkStream enriches a link like EnrichLink, streaming progress as
+// Server-Sent Events: "progress" events carry {stage, message}, and the stream
+// ends with a "link" event (the enriched link) or an "error" event.
+// Optional query parameters: timeout (seconds), only_fill_empty (default true)
+func EnrichLinkStream(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		linkID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+			return
+		}
+
+		var query struct {
+			Timeout       int   `form:"timeout"` // seconds
+			OnlyFillEmpty *bool `form:"only_fill_empty"`
+		}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		scrapeOpts := services.ScrapeOptions{
+			Enabled:        true,
+			TimeoutSeconds: 30,
+			OnlyFillEmpty:  true,
+		}
+		if query.Timeout > 0 {
+			scrapeOpts.TimeoutSeconds = query.Timeout
+		}
+		if query.OnlyFillEmpty != nil {
+			scrapeOpts.OnlyFillEmpty = *query.OnlyFillEmpty
+		}
+		if !checkScrapeTimeout(c, scrapeOpts.TimeoutSeconds) {
+			return
+		}
+		extendWriteDeadline(c, scrapeOpts.TimeoutSeconds)
+
+		ctx := c.Request.Context()
+		progress := make(chan enrichProgressEvent, 8)
+		var link *models.Link
+		var changed bool
+		var enrichErr error
+
+		go func() {
+			defer close(progress)
+			link, changed, enrichErr = service.EnrichLinkWithProgress(ctx, linkID, userID, scrapeOpts,
+				func(stage scraper.ScrapeStage, message string) {
+					select {
+					case progress <- enrichProgressEvent{Stage: stage, Message: message}:
+					case <-ctx.Done():
+						// Client went away; stop reporting
+					}
+				})
+		}()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no") // disable nginx response buffering
+
+		c.Stream(func(w io.Writer) bool {
+			if event, ok := <-progress; ok {
+				c.SSEvent("progress", event)
+				return true
+			}
+			// progress is closed, so link and enrichErr are set
+			if enrichErr != nil {
+				c.SSEvent("error", gin.H{"error": enrichErr.Error(), "status": errorStatus(enrichErr)})
+				return false
+			}
+			if !changed {
+				c.SSEvent("unchanged", gin.H{"message": "no changes since last scrape"})
+			}
+			c.SSEvent("link", link)
+			return false
+		})
+	}
+}
+
+// defaultSimilarLimit is how many links SimilarLinks returns when ?limit= is omitted
+const defaultSimilarLimit = 10
+
+// maxSimilarLimit bounds ?limit= for SimilarLinks
+const maxSimilarLimit = 50
+
+// SimilarLinks lists the user's other links sharing terms with a link's
+// title, description, or text, most similar first; ?limit= caps the count
+func SimilarLinks(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		linkID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+			return
+		}
+
+		limit := defaultSimilarLimit
+		if raw := c.Query("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n <= 0 || n > maxSimilarLimit {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be a whole number from 1 to %d", maxSimilarLimit)})
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			limit = n
+		}
+
+		links, err := service.GetSimilarLinks(c.Request.Context(), linkID, userID, limit)
+		if err != nil {
+			writeError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, link)
+		c.JSON(http.StatusOK, links)
 	}
 }
 
@@ -137,14 +498,14 @@ func UpdateLink(service *services.LinkService) gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err := linkUpdate.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
 		link, err := service.UpdateLink(c.Request.Context(), linkID, userID, linkUpdate)
 		if err != nil {
-			if err.Error() == "link not found" {
-				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeError(c, err)
 			return
 		}
 
@@ -164,15 +525,202 @@ func DeleteLink(service *services.LinkService) gin.HandlerFunc {
 		}
 
 		if err := service.DeleteLink(c.Request.Context(), linkID, userID); err != nil {
-			if err.Error() == "link not found" {
-				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "link deleted"})
+	}
+}
+
+// ToggleFavorite flips the favorite flag on a link
+func ToggleFavorite(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		linkID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+			return
+		}
+
+		link, err := service.ToggleFavorite(c.Request.Context(), linkID, userID)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, link)
+	}
+}
+
+// RecordVisit counts an opening of a link, responding with the new count
+func RecordVisit(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		linkID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+			return
+		}
+
+		count, err := service.RecordVisit(c.Request.Context(), linkID, userID)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"visit_count": count})
+	}
+}
+
+// SetLinkRead marks a link read or unread from an optional body
+// {"read": bool}; without one, the read status is toggled
+func SetLinkRead(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		linkID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid link ID"})
+			return
+		}
+
+		var req struct {
+			Read *bool `json:"read"`
+		}
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+
+		link, err := service.SetLinkRead(c.Request.Context(), linkID, userID, req.Read)
+		if err != nil {
+			writeError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "link deleted"})
+		c.JSON(http.StatusOK, link)
+	}
+}
+
+// linkIDList is a request body naming links: a JSON array of IDs, or an
+// object holding that array under "ids"
+type linkIDList []uuid.UUID
+
+func (l *linkIDList) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var obj struct {
+			IDs []uuid.UUID `json:"ids"`
+		}
+		if err := json.Unmarshal(trimmed, &obj); err != nil {
+			return err
+		}
+		*l = obj.IDs
+		return nil
+	}
+	return json.Unmarshal(data, (*[]uuid.UUID)(l))
+}
+
+// DeleteLinks deletes multiple links in one request. The body is a JSON
+// array of link IDs; {"ids": [...]} is accepted too. IDs of other users'
+// links are ignored, and the response reports how many were deleted.
+func DeleteLinks(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		var ids linkIDList
+		if err := c.ShouldBindJSON(&ids); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(ids) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "at least one link ID is required"})
+			return
+		}
+
+		deleted, err := service.DeleteLinks(c.Request.Context(), ids, userID)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "links deleted", "deleted": deleted})
+	}
+}
+
+// ReorderLinks sets the manual link ordering from an ordered list of IDs.
+// Links not listed keep their relative order after the listed ones.
+func ReorderLinks(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		var req struct {
+			IDs []uuid.UUID `json:"ids" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(req.IDs) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "at least one link ID is required"})
+			return
+		}
+		seen := make(map[uuid.UUID]bool, len(req.IDs))
+		for _, id := range req.IDs {
+			if seen[id] {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "duplicate link ID: " + id.String()})
+				return
+			}
+			seen[id] = true
+		}
+
+		if err := service.ReorderLinks(c.Request.Context(), req.IDs, userID); err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "links reordered"})
+	}
+}
+
+// TransferLinks moves links to another account. The body names the links
+// and carries an API key of the target account, proving the caller owns it.
+func TransferLinks(service *services.LinkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		var req struct {
+			IDs          []uuid.UUID `json:"ids" binding:"required"`
+			TargetAPIKey string      `json:"target_api_key" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(req.IDs) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "at least one link ID is required"})
+			return
+		}
+		seen := make(map[uuid.UUID]bool, len(req.IDs))
+		for _, id := range req.IDs {
+			if seen[id] {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "duplicate link ID: " + id.String()})
+				return
+			}
+			seen[id] = true
+		}
+
+		transferred, err := service.TransferLinks(c.Request.Context(), req.IDs, userID, req.TargetAPIKey)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "links transferred", "transferred": transferred})
 	}
 }
 
@@ -212,13 +760,20 @@ func EnrichLink(service *services.LinkService) gin.HandlerFunc {
 		if c.Request.ContentLength > 0 {
 			scrapeOpts.OnlyFillEmpty = req.OnlyFillEmpty
 		}
+		if !checkScrapeTimeout(c, scrapeOpts.TimeoutSeconds) {
+			return
+		}
+		extendWriteDeadline(c, scrapeOpts.TimeoutSeconds)
 
-		link, err := service.EnrichLink(c.Request.Context(), linkID, userID, scrapeOpts)
+		link, changed, err := service.EnrichLink(c.Request.Context(), linkID, userID, scrapeOpts)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeError(c, err)
 			return
 		}
 
+		// Tell clients whether the re-scrape changed anything
+		c.Header("X-Link-Changed", strconv.FormatBool(changed))
+
 		c.JSON(http.StatusOK, link)
 	}
 }
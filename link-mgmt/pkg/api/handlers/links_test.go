@@ -0,0 +1,562 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// serve sends a request to handler mounted at method and route, with userID
+// set as the authenticated user, and records the response
+func serve(handler gin.HandlerFunc, userID uuid.UUID, method, route string, req *http.Request) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	}, handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// newLinkService returns a link service over database without a scraper
+func newLinkService(database *db.DB) *services.LinkService {
+	return services.NewLinkService(database, nil)
+}
+
+func TestLinkIDListUnmarshal(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	tests := []struct {
+		name    string
+		body    string
+		want    []uuid.UUID
+		wantErr bool
+	}{
+		{"bare array", `["` + a.String() + `","` + b.String() + `"]`, []uuid.UUID{a, b}, false},
+		{"object", ` {"ids": ["` + a.String() + `"]}`, []uuid.UUID{a}, false},
+		{"empty array", `[]`, []uuid.UUID{}, false},
+		{"invalid ID", `["not-a-uuid"]`, nil, true},
+		{"string", `"` + a.String() + `"`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got linkIDList
+			err := json.Unmarshal([]byte(tt.body), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteLinksBareArray(t *testing.T) {
+	database := dbtest.New(t)
+	owner := dbtest.CreateUser(t, database)
+	other := dbtest.CreateUser(t, database)
+	mine := dbtest.CreateLink(t, database, owner.ID, "https://example.com/mine", nil)
+	theirs := dbtest.CreateLink(t, database, other.ID, "https://example.com/theirs", nil)
+
+	body := `["` + mine.ID.String() + `","` + theirs.ID.String() + `"]`
+	req := httptest.NewRequest(http.MethodDelete, "/links", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := serve(DeleteLinks(newLinkService(database)), owner.ID, http.MethodDelete, "/links", req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", w.Code, w.Body)
+	}
+	var resp struct {
+		Deleted int64 `json:"deleted"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Deleted != 1 {
+		t.Errorf("deleted = %d, want 1", resp.Deleted)
+	}
+}
+
+func TestDeleteLinksRejectsEmptyList(t *testing.T) {
+	for _, body := range []string{`[]`, `{"ids": []}`, `{}`} {
+		req := httptest.NewRequest(http.MethodDelete, "/links", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := serve(DeleteLinks(newLinkService(nil)), uuid.New(), http.MethodDelete, "/links", req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("body %s: status = %d, want 400", body, w.Code)
+		}
+	}
+}
+
+func TestListLinksRejectsBadDates(t *testing.T) {
+	// Validation fails before the database is queried
+	service := newLinkService(nil)
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"malformed created_after", "created_after=03/01/2024"},
+		{"malformed created_before", "created_before=2024-13-01"},
+		{"empty range", "created_after=2024-03-05&created_before=2024-03-01"},
+		{"same day range", "created_after=2024-03-01&created_before=2024-03-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/links?"+tt.query, nil)
+			w := serve(ListLinks(service), uuid.New(), http.MethodGet, "/links", req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, http.StatusBadRequest, w.Body)
+			}
+		})
+	}
+}
+
+func TestListLinksRejectsBadSort(t *testing.T) {
+	service := newLinkService(nil)
+	for _, query := range []string{"sort=email", "sort=title%3B%20DROP%20TABLE%20links", "sort=title&order=sideways", "limit=-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/links?"+query, nil)
+		w := serve(ListLinks(service), uuid.New(), http.MethodGet, "/links", req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d (body %s)", query, w.Code, http.StatusBadRequest, w.Body)
+		}
+	}
+}
+
+func TestPaginationLinkHeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		limit, offset int
+		total         int64
+		want          string
+	}{
+		{"first page", "limit=2", 2, 0, 5, `</links?limit=2&offset=2>; rel="next"`},
+		{"middle page", "limit=2&offset=2", 2, 2, 5, `</links?limit=2&offset=4>; rel="next", </links?limit=2&offset=0>; rel="prev"`},
+		{"last page", "limit=2&offset=4", 2, 4, 5, `</links?limit=2&offset=2>; rel="prev"`},
+		{"exactly full", "limit=5", 5, 0, 5, ""},
+		{"offset inside first page", "limit=10&offset=3", 10, 3, 50, `</links?limit=10&offset=13>; rel="next", </links?limit=10&offset=0>; rel="prev"`},
+		{"offset without limit", "offset=3", 0, 3, 5, `</links?offset=0>; rel="prev"`},
+		{"other parameters kept", "favorites=true&limit=2", 2, 0, 3, `</links?favorites=true&limit=2&offset=2>; rel="next"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &url.URL{Path: "/links", RawQuery: tt.query}
+			got := paginationLinkHeader(u, models.ListOptions{Limit: tt.limit, Offset: tt.offset}, tt.total)
+			if got != tt.want {
+				t.Errorf("paginationLinkHeader = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListLinksPaginationHeaders(t *testing.T) {
+	database := dbtest.New(t)
+	service := newLinkService(database)
+	user := dbtest.CreateUser(t, database)
+	for i := range 5 {
+		dbtest.CreateLink(t, database, user.ID, fmt.Sprintf("https://example.com/%d", i), nil)
+	}
+
+	tests := []struct {
+		query     string
+		wantCount int
+		wantLink  string
+	}{
+		{"", 5, ""},
+		{"limit=2", 2, `</links?limit=2&offset=2>; rel="next"`},
+		{"limit=2&offset=2", 2, `</links?limit=2&offset=4>; rel="next", </links?limit=2&offset=0>; rel="prev"`},
+		{"limit=2&offset=4", 1, `</links?limit=2&offset=2>; rel="prev"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/links?"+tt.query, nil)
+			w := serve(ListLinks(service), user.ID, http.MethodGet, "/links", req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+			}
+
+			var links []models.Link
+			if err := json.Unmarshal(w.Body.Bytes(), &links); err != nil {
+				t.Fatalf("body: %v", err)
+			}
+			if len(links) != tt.wantCount {
+				t.Errorf("got %d links, want %d", len(links), tt.wantCount)
+			}
+			if got := w.Header().Get("X-Total-Count"); got != "5" {
+				t.Errorf("X-Total-Count = %q, want 5", got)
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Link = %q, want %q", got, tt.wantLink)
+			}
+		})
+	}
+}
+
+func TestCreateLinkIdempotencyKey(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	handler := CreateLink(newLinkService(database))
+
+	create := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/links", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		return serve(handler, user.ID, http.MethodPost, "/api/v1/links", req)
+	}
+	body := `{"url": "https://example.com/once"}`
+
+	first := create("abc", body)
+	if first.Code != http.StatusCreated || first.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("first: status = %d, replayed = %q; want 201, not replayed", first.Code, first.Header().Get("Idempotent-Replayed"))
+	}
+	var created models.Link
+	if err := json.Unmarshal(first.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decoding link: %v", err)
+	}
+
+	repeat := create("abc", body)
+	if repeat.Code != http.StatusCreated || repeat.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("repeat: status = %d, replayed = %q; want 201, replayed", repeat.Code, repeat.Header().Get("Idempotent-Replayed"))
+	}
+	var replayed models.Link
+	if err := json.Unmarshal(repeat.Body.Bytes(), &replayed); err != nil {
+		t.Fatalf("decoding link: %v", err)
+	}
+	if replayed.ID != created.ID {
+		t.Errorf("repeat returned link %s, want %s", replayed.ID, created.ID)
+	}
+
+	if w := create("abc", `{"url": "https://example.com/other"}`); w.Code != http.StatusConflict {
+		t.Errorf("different body: status = %d, want %d", w.Code, http.StatusConflict)
+	}
+
+	dbtest.Exec(t, database, `DELETE FROM links WHERE id = $1`, created.ID)
+	if w := create("abc", body); w.Code != http.StatusNotFound {
+		t.Errorf("replay after delete: status = %d, want %d: %s", w.Code, http.StatusNotFound, w.Body.String())
+	}
+}
+
+func TestLinkLengthLimits(t *testing.T) {
+	// Rejected before the database is reached, so none is needed
+	service := newLinkService(nil)
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+		method  string
+		route   string
+		path    string
+		body    string
+		want    string
+	}{
+		{"create with a long URL", CreateLink(service), http.MethodPost, "/api/v1/links", "/api/v1/links",
+			`{"url": "https://example.com/` + strings.Repeat("a", models.MaxURLLength) + `"}`, "url must be at most 2048 characters"},
+		{"create with a long description", CreateLink(service), http.MethodPost, "/api/v1/links", "/api/v1/links",
+			`{"url": "https://example.com", "description": "` + strings.Repeat("d", models.MaxDescriptionLength+1) + `"}`, "description must be at most 1000 characters (got 1001)"},
+		{"update with a long title", UpdateLink(service), http.MethodPut, "/api/v1/links/:id", "/api/v1/links/" + uuid.NewString(),
+			`{"title": "` + strings.Repeat("t", models.MaxTitleLength+1) + `"}`, "title must be at most 255 characters (got 256)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := serve(tt.handler, userID, tt.method, tt.route, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body.String())
+			}
+			var body struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || !strings.Contains(body.Error, tt.want) {
+				t.Errorf("error = %q (%v), want it to contain %q", body.Error, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecentLinksRejectsInvalidDays(t *testing.T) {
+	handler := RecentLinks(newLinkService(nil))
+	for _, days := range []string{"0", "-3", "week", "1.5", "36501"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/links/recent?days="+url.QueryEscape(days), nil)
+		w := serve(handler, uuid.New(), http.MethodGet, "/api/v1/links/recent", req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("days=%s: status = %d, want %d", days, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestRecentLinks(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	handler := RecentLinks(newLinkService(database))
+
+	recent := dbtest.CreateLink(t, database, user.ID, "https://example.com/recent", nil)
+	older := dbtest.CreateLink(t, database, user.ID, "https://example.com/older", nil)
+	dbtest.Exec(t, database, `UPDATE links SET created_at = NOW() - interval '10 days' WHERE id = $1`, older.ID)
+
+	tests := []struct {
+		query string
+		want  []uuid.UUID
+	}{
+		{"", []uuid.UUID{recent.ID}},
+		{"?days=7", []uuid.UUID{recent.ID}},
+		{"?days=11", []uuid.UUID{recent.ID, older.ID}},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/links/recent"+tt.query, nil)
+		w := serve(handler, user.ID, http.MethodGet, "/api/v1/links/recent", req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d: %s", tt.query, w.Code, w.Body.String())
+		}
+		var links []models.Link
+		if err := json.Unmarshal(w.Body.Bytes(), &links); err != nil {
+			t.Fatalf("decoding links: %v", err)
+		}
+		if len(links) != len(tt.want) {
+			t.Fatalf("%s: got %d links, want %d", tt.query, len(links), len(tt.want))
+		}
+		for i := range links {
+			if links[i].ID != tt.want[i] {
+				t.Errorf("%s: link %d = %s, want %s", tt.query, i, links[i].ID, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestTransferLinksRejectsBadRequest(t *testing.T) {
+	handler := TransferLinks(newLinkService(nil))
+	id := uuid.New()
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing target key", fmt.Sprintf(`{"ids":["%s"]}`, id)},
+		{"missing ids", `{"target_api_key":"key"}`},
+		{"empty ids", `{"ids":[],"target_api_key":"key"}`},
+		{"duplicate id", fmt.Sprintf(`{"ids":["%s","%s"],"target_api_key":"key"}`, id, id)},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/links/transfer", strings.NewReader(tt.body))
+		w := serve(handler, uuid.New(), http.MethodPost, "/api/v1/links/transfer", req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestTransferLinksStatus(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	target := dbtest.CreateUser(t, database)
+	readOnlyKey := dbtest.CreateReadOnlyKey(t, database, target.ID)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/moving", nil)
+
+	transfer := func(database *db.DB, key string) int {
+		body := fmt.Sprintf(`{"ids":["%s"],"target_api_key":"%s"}`, link.ID, key)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/links/transfer", strings.NewReader(body))
+		return serve(TransferLinks(newLinkService(database)), user.ID, http.MethodPost, "/api/v1/links/transfer", req).Code
+	}
+
+	if got := transfer(database, dbtest.NewAPIKey(t)); got != http.StatusForbidden {
+		t.Errorf("unknown target key: status = %d, want %d", got, http.StatusForbidden)
+	}
+	if got := transfer(database, readOnlyKey); got != http.StatusForbidden {
+		t.Errorf("read-only target key: status = %d, want %d", got, http.StatusForbidden)
+	}
+	if got := transfer(database, user.APIKey); got != http.StatusBadRequest {
+		t.Errorf("own key: status = %d, want %d", got, http.StatusBadRequest)
+	}
+	if got := transfer(database, target.APIKey); got != http.StatusOK {
+		t.Errorf("target key: status = %d, want %d", got, http.StatusOK)
+	}
+
+	// A failing database is a server error, not a rejected key
+	closed := dbtest.New(t)
+	closed.Close()
+	if got := transfer(closed, target.APIKey); got != http.StatusInternalServerError {
+		t.Errorf("database closed: status = %d, want %d", got, http.StatusInternalServerError)
+	}
+}
+
+func TestSetLinkReadRejectsBadRequest(t *testing.T) {
+	handler := SetLinkRead(newLinkService(nil))
+	tests := []struct {
+		name, id, body string
+	}{
+		{"invalid id", "not-a-uuid", ""},
+		{"malformed body", uuid.NewString(), `{"read":`},
+		{"non-boolean read", uuid.NewString(), `{"read":"yes"}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/links/"+tt.id+"/read", strings.NewReader(tt.body))
+		w := serve(handler, uuid.New(), http.MethodPost, "/links/:id/read", req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestSetLinkRead(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/article", nil)
+	handler := SetLinkRead(newLinkService(database))
+
+	tests := []struct {
+		body string
+		want bool
+	}{
+		{"", true},  // no body toggles
+		{"", false}, // and toggles back
+		{`{"read":true}`, true},
+		{`{"read":true}`, true},
+		{`{"read":false}`, false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/links/"+link.ID.String()+"/read", strings.NewReader(tt.body))
+		w := serve(handler, user.ID, http.MethodPost, "/links/:id/read", req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("body %q: status = %d: %s", tt.body, w.Code, w.Body)
+		}
+		var got models.Link
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("decoding link: %v", err)
+		}
+		if got.IsRead != tt.want {
+			t.Errorf("body %q: is_read = %v, want %v", tt.body, got.IsRead, tt.want)
+		}
+	}
+
+	// Only unread links are listed with ?unread=true
+	unread := dbtest.CreateLink(t, database, user.ID, "https://example.com/unread", nil)
+	req := httptest.NewRequest(http.MethodPost, "/links/"+link.ID.String()+"/read", strings.NewReader(`{"read":true}`))
+	serve(handler, user.ID, http.MethodPost, "/links/:id/read", req)
+	req = httptest.NewRequest(http.MethodGet, "/links?unread=true", nil)
+	w := serve(ListLinks(newLinkService(database)), user.ID, http.MethodGet, "/links", req)
+	var links []models.Link
+	if err := json.Unmarshal(w.Body.Bytes(), &links); err != nil {
+		t.Fatalf("decoding links: %v", err)
+	}
+	if len(links) != 1 || links[0].ID != unread.ID {
+		t.Errorf("?unread=true listed %d links, want only %s", len(links), unread.URL)
+	}
+
+	other := dbtest.CreateUser(t, database)
+	req = httptest.NewRequest(http.MethodPost, "/links/"+link.ID.String()+"/read", nil)
+	if w := serve(handler, other.ID, http.MethodPost, "/links/:id/read", req); w.Code != http.StatusNotFound {
+		t.Errorf("another user's link: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestRecordVisit(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/links/not-a-uuid/visit", nil)
+	if w := serve(RecordVisit(newLinkService(nil)), uuid.New(), http.MethodPost, "/links/:id/visit", req); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid ID: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/article", nil)
+	handler := RecordVisit(newLinkService(database))
+
+	for want := 1; want <= 2; want++ {
+		req := httptest.NewRequest(http.MethodPost, "/links/"+link.ID.String()+"/visit", nil)
+		w := serve(handler, user.ID, http.MethodPost, "/links/:id/visit", req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d: %s", w.Code, w.Body)
+		}
+		var got struct {
+			VisitCount int `json:"visit_count"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+		if got.VisitCount != want {
+			t.Errorf("visit_count = %d, want %d", got.VisitCount, want)
+		}
+	}
+
+	other := dbtest.CreateUser(t, database)
+	req = httptest.NewRequest(http.MethodPost, "/links/"+link.ID.String()+"/visit", nil)
+	if w := serve(handler, other.ID, http.MethodPost, "/links/:id/visit", req); w.Code != http.StatusNotFound {
+		t.Errorf("another user's link: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestSimilarLinksRejectsBadRequest(t *testing.T) {
+	handler := SimilarLinks(newLinkService(nil))
+	id := uuid.NewString()
+	for _, path := range []string{
+		"/links/not-a-uuid/similar",
+		"/links/" + id + "/similar?limit=0",
+		"/links/" + id + "/similar?limit=-1",
+		"/links/" + id + "/similar?limit=ten",
+		fmt.Sprintf("/links/%s/similar?limit=%d", id, maxSimilarLimit+1),
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := serve(handler, uuid.New(), http.MethodGet, "/links/:id/similar", req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status = %d, want %d", path, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestScrapeTimeoutLimit(t *testing.T) {
+	service := newLinkService(nil)
+	id := uuid.New().String()
+	tooLong := fmt.Sprint(maxScrapeTimeoutSeconds + 1)
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+		method  string
+		route   string
+		target  string
+		body    string
+	}{
+		{"create with scraping", CreateLinkWithScraping(service), http.MethodPost, "/links/scrape", "/links/scrape?verify=true",
+			`{"url": "https://example.com", "scrape": {"enabled": true, "timeout": ` + tooLong + `}}`},
+		{"enrich", EnrichLink(service), http.MethodPost, "/links/:id/enrich", "/links/" + id + "/enrich",
+			`{"timeout": ` + tooLong + `}`},
+		{"enrich stream", EnrichLinkStream(service), http.MethodGet, "/links/:id/enrich/stream", "/links/" + id + "/enrich/stream?timeout=" + tooLong, ""},
+		{"overflowing", EnrichLinkStream(service), http.MethodGet, "/links/:id/enrich/stream", "/links/" + id + "/enrich/stream?timeout=9223372036854775807", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			w := serve(tt.handler, uuid.New(), tt.method, tt.route, req)
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "at most 120 seconds") {
+				t.Errorf("got %d %s, want 400 naming the limit", w.Code, w.Body)
+			}
+		})
+	}
+}
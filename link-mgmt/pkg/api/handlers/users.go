@@ -3,14 +3,17 @@ package handlers
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"net/http"
 
 	"link-mgmt/pkg/db"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-func CreateUser(db *db.DB) gin.HandlerFunc {
+// CreateUser registers a user; keyExpiryDays > 0 makes the new API key expire
+func CreateUser(database *db.DB, keyExpiryDays int) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
 			Email string `json:"email" binding:"required,email"`
@@ -21,6 +24,16 @@ func CreateUser(db *db.DB) gin.HandlerFunc {
 			return
 		}
 
+		// Catch a registered email before issuing a key; the unique constraint
+		// still covers two registrations racing each other
+		if _, err := database.GetUserByEmail(c.Request.Context(), req.Email); err == nil {
+			writeError(c, db.ErrDuplicateEmail)
+			return
+		} else if !errors.Is(err, db.ErrUserNotFound) {
+			writeError(c, err)
+			return
+		}
+
 		// Generate API key
 		apiKey, err := generateAPIKey()
 		if err != nil {
@@ -28,9 +41,9 @@ func CreateUser(db *db.DB) gin.HandlerFunc {
 			return
 		}
 
-		user, err := db.CreateUser(c.Request.Context(), req.Email, apiKey)
+		user, err := database.CreateUser(c.Request.Context(), req.Email, apiKey, keyExpiryDays)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeError(c, err)
 			return
 		}
 
@@ -49,6 +62,105 @@ func GetCurrentUser(db *db.DB) gin.HandlerFunc {
 	}
 }
 
+// RotateAPIKey replaces the authenticated user's API key. The optional
+// expires_in_days body field overrides the configured default expiry
+// (0 = never expires).
+func RotateAPIKey(db *db.DB, keyExpiryDays int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		var req struct {
+			ExpiresInDays *int `json:"expires_in_days"`
+		}
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		expiryDays := keyExpiryDays
+		if req.ExpiresInDays != nil {
+			if *req.ExpiresInDays < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in_days must not be negative"})
+				return
+			}
+			expiryDays = *req.ExpiresInDays
+		}
+
+		apiKey, err := generateAPIKey()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate API key"})
+			return
+		}
+
+		user, err := db.RotateAPIKey(c.Request.Context(), userID, apiKey, expiryDays)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, user)
+	}
+}
+
+// CreateReadOnlyAPIKey creates a read-only API key for the authenticated user,
+// alongside the primary key, for integrations that only need to read links.
+// The optional expires_in_days body field works as for RotateAPIKey.
+func CreateReadOnlyAPIKey(db *db.DB, keyExpiryDays int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		var req struct {
+			ExpiresInDays *int `json:"expires_in_days"`
+		}
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		expiryDays := keyExpiryDays
+		if req.ExpiresInDays != nil {
+			if *req.ExpiresInDays < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in_days must not be negative"})
+				return
+			}
+			expiryDays = *req.ExpiresInDays
+		}
+
+		apiKey, err := generateAPIKey()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate API key"})
+			return
+		}
+
+		key, err := db.CreateReadOnlyAPIKey(c.Request.Context(), userID, apiKey, expiryDays)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, key)
+	}
+}
+
+// DeleteCurrentUser deletes the authenticated user's account along with all
+// of their links and API keys
+func DeleteCurrentUser(db *db.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(uuid.UUID)
+
+		if err := db.DeleteUser(c.Request.Context(), userID); err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "account deleted"})
+	}
+}
+
 // generateAPIKey generates a random 32-byte hex string
 func generateAPIKey() (string, error) {
 	bytes := make([]byte, 32)
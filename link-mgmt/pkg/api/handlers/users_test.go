@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"link-mgmt/pkg/db/dbtest"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateUserDuplicateEmail(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+
+	tests := []struct {
+		name  string
+		email string
+		want  int
+	}{
+		{"new email", uuid.NewString() + "@example.com", http.StatusCreated},
+		{"registered email", user.Email, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"email":"`+tt.email+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := serve(CreateUser(database, 0), uuid.Nil, http.MethodPost, "/users", req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if tt.want != http.StatusConflict {
+				return
+			}
+			var body struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decoding error body: %v", err)
+			}
+			if body.Error != "email already registered" {
+				t.Errorf("error = %q, want %q", body.Error, "email already registered")
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"net/http"
+
+	"link-mgmt/pkg/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version returns the build metadata of the running API server
+func Version(c *gin.Context) {
+	c.JSON(http.StatusOK, version.Get())
+}
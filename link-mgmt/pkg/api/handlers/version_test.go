@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"link-mgmt/pkg/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setVersion injects build metadata as -ldflags would, restoring it after the test
+func setVersion(t *testing.T, v, commit, buildDate string) {
+	t.Helper()
+	previous := []string{version.Version, version.Commit, version.BuildDate}
+	version.Version, version.Commit, version.BuildDate = v, commit, buildDate
+	t.Cleanup(func() {
+		version.Version, version.Commit, version.BuildDate = previous[0], previous[1], previous[2]
+	})
+}
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		name                     string
+		version, commit, builtAt string
+		want                     version.Info
+	}{
+		{"injected", "v1.2.0", "abc1234", "2024-03-01T12:00:00Z", version.Info{Version: "v1.2.0", Commit: "abc1234", BuildDate: "2024-03-01T12:00:00Z"}},
+		{"unset", "", "", "", version.Info{Version: "dev", Commit: "dev", BuildDate: "dev"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVersion(t, tt.version, tt.commit, tt.builtAt)
+
+			router := gin.New()
+			router.GET("/api/v1/version", Version)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/version", nil))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			var got version.Info
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("body is not JSON: %v\n%s", err, w.Body.String())
+			}
+			if got != tt.want {
+				t.Errorf("version = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,17 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/models"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RequireAuth(db *db.DB) gin.HandlerFunc {
+func RequireAuth(database *db.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -22,7 +24,17 @@ func RequireAuth(db *db.DB) gin.HandlerFunc {
 		apiKey := strings.TrimPrefix(authHeader, "Bearer ")
 		apiKey = strings.TrimSpace(apiKey)
 
-		user, err := db.GetUserByAPIKey(c.Request.Context(), apiKey)
+		user, err := database.GetUserByAPIKey(c.Request.Context(), apiKey)
+		if errors.Is(err, db.ErrQueryTimeout) {
+			c.JSON(http.StatusGatewayTimeout, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if errors.Is(err, db.ErrAPIKeyExpired) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "API key expired"})
+			c.Abort()
+			return
+		}
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
 			c.Abort()
@@ -31,6 +43,55 @@ func RequireAuth(db *db.DB) gin.HandlerFunc {
 
 		c.Set("userID", user.ID)
 		c.Set("user", user)
+
+		// Read-only keys may only read
+		if user.IsReadOnly && !isReadMethod(c.Request.Method) {
+			abortReadOnly(c)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireWriteAccess rejects read-only API keys with 403. RequireAuth already
+// does this for every non-GET request; add it to GET routes that modify data.
+// Must run after RequireAuth.
+func RequireWriteAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user, ok := c.Get("user"); ok && user.(*models.User).IsReadOnly {
+			abortReadOnly(c)
+			return
+		}
+		c.Next()
+	}
+}
+
+func abortReadOnly(c *gin.Context) {
+	c.JSON(http.StatusForbidden, gin.H{"error": "API key is read-only"})
+	c.Abort()
+}
+
+// isReadMethod reports whether an HTTP method only reads
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// QueryAPIKey lets clients that can't set headers (such as feed readers)
+// authenticate with ?<param>=<api key>. It must run before RequireAuth; an
+// explicit Authorization header takes precedence.
+func QueryAPIKey(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if key := c.Query(param); key != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+key)
+			}
+		}
 		c.Next()
 	}
 }
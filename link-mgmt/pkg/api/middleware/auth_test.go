@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// authRouter serves GET / behind RequireAuth(database)
+func authRouter(database *db.DB) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", RequireAuth(database), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRequireAuthMissingHeader(t *testing.T) {
+	w := httptest.NewRecorder()
+	authRouter(nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", w.Code)
+	}
+}
+
+func TestRequireAuthQueryTimeoutIs504(t *testing.T) {
+	// pgxpool connects lazily, so a cancelled request never reaches the server
+	pool, err := pgxpool.New(context.Background(), "postgres://test@127.0.0.1:1/test?connect_timeout=1")
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	defer pool.Close()
+	database := &db.DB{Pool: pool, QueryTimeout: time.Second}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer key")
+	w := httptest.NewRecorder()
+	authRouter(database).ServeHTTP(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want 504; body %s", w.Code, w.Body)
+	}
+}
+
+func TestRequireAuthExpiredKey(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	dbtest.Exec(t, database, `UPDATE users SET expires_at = NOW() - interval '1 second' WHERE id = $1`, user.ID)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+user.APIKey)
+	w := httptest.NewRecorder()
+	authRouter(database).ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "API key expired") {
+		t.Errorf("body = %s, want it to say the API key expired", w.Body)
+	}
+}
+
+func TestRequireAuthValidKey(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+user.APIKey)
+	w := httptest.NewRecorder()
+	authRouter(database).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200; body %s", w.Code, w.Body)
+	}
+}
+
+func TestRequireWriteAccess(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     *models.User
+		wantCode int
+	}{
+		{"read-only key", &models.User{IsReadOnly: true}, http.StatusForbidden},
+		{"full key", &models.User{}, http.StatusOK},
+		{"no user", nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				if tt.user != nil {
+					c.Set("user", tt.user)
+				}
+			}, RequireWriteAccess(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRequireAuthReadOnlyKey(t *testing.T) {
+	database := dbtest.New(t)
+	user := dbtest.CreateUser(t, database)
+	key := dbtest.CreateReadOnlyKey(t, database, user.ID)
+
+	router := gin.New()
+	router.Use(RequireAuth(database))
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		router.Handle(method, "/", func(c *gin.Context) { c.Status(http.StatusOK) })
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		want := http.StatusForbidden
+		if method == http.MethodGet {
+			want = http.StatusOK
+		}
+		if w.Code != want {
+			t.Errorf("%s with a read-only key: status = %d, want %d", method, w.Code, want)
+		}
+	}
+}
@@ -9,7 +9,8 @@ import (
 func ErrorHandler() gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "internal server error",
+			"error":      "internal server error",
+			"request_id": GetRequestID(c),
 		})
 		c.Abort()
 	})
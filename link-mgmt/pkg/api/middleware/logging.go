@@ -1,24 +1,120 @@
 package middleware
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-func RequestLogger() gin.HandlerFunc {
-	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		return fmt.Sprintf("%s - [%s] \"%s %s %s %d %s \"%s\" %s\"\n",
-			param.ClientIP,
-			param.TimeStamp.Format(time.RFC1123),
-			param.Method,
-			param.Path,
-			param.Request.Proto,
-			param.StatusCode,
-			param.Latency,
-			param.Request.UserAgent(),
-			param.ErrorMessage,
-		)
-	})
+// Log formats accepted by RequestLogger
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// RequestLogger logs one line per request, either human-readable text (default)
+// or a JSON object per line for log aggregators.
+func RequestLogger(format string) gin.HandlerFunc {
+	if format == LogFormatJSON {
+		return gin.LoggerWithFormatter(jsonLogFormatter)
+	}
+	return gin.LoggerWithFormatter(textLogFormatter)
+}
+
+func textLogFormatter(param gin.LogFormatterParams) string {
+	return fmt.Sprintf("%s - [%s] %s \"%s %s %s %d %s \"%s\" %s\"\n",
+		param.ClientIP,
+		param.TimeStamp.Format(time.RFC1123),
+		requestIDFromKeys(param.Keys),
+		param.Method,
+		redactPath(param.Path),
+		param.Request.Proto,
+		param.StatusCode,
+		param.Latency,
+		param.Request.UserAgent(),
+		param.ErrorMessage,
+	)
+}
+
+// redactedQueryParams are query parameters that carry credentials (see
+// QueryAPIKey) and must never reach the request log
+var redactedQueryParams = []string{"key"}
+
+// redactPath masks credential query parameters in a logged path
+func redactPath(path string) string {
+	base, rawQuery, found := strings.Cut(path, "?")
+	if !found {
+		return path
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		// Unparseable queries are dropped rather than risk logging a key
+		return base + "?REDACTED"
+	}
+	redacted := false
+	for _, name := range redactedQueryParams {
+		if query.Has(name) {
+			query.Set(name, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return path
+	}
+	return base + "?" + query.Encode()
+}
+
+// requestLogEntry is the shape of a JSON request log line
+type requestLogEntry struct {
+	Time      string  `json:"time"`
+	RequestID string  `json:"request_id,omitempty"`
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	Status    int     `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	ClientIP  string  `json:"client_ip"`
+	UserAgent string  `json:"user_agent,omitempty"`
+	UserID    string  `json:"user_id,omitempty"`
+	BodySize  int     `json:"body_size"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func jsonLogFormatter(param gin.LogFormatterParams) string {
+	entry := requestLogEntry{
+		Time:      param.TimeStamp.Format(time.RFC3339),
+		Method:    param.Method,
+		Path:      redactPath(param.Path),
+		Status:    param.StatusCode,
+		LatencyMS: float64(param.Latency.Microseconds()) / 1000,
+		ClientIP:  param.ClientIP,
+		UserAgent: param.Request.UserAgent(),
+		BodySize:  param.BodySize,
+		Error:     param.ErrorMessage,
+	}
+	if requestID, ok := param.Keys[requestIDKey].(string); ok {
+		entry.RequestID = requestID
+	}
+	// userID is set by RequireAuth on authenticated routes
+	if userID, ok := param.Keys["userID"].(uuid.UUID); ok {
+		entry.UserID = userID.String()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Sprintf("{\"error\":%q}\n", err.Error())
+	}
+	return string(data) + "\n"
+}
+
+// requestIDFromKeys returns the ID set by RequestID, or "-" if it didn't run
+func requestIDFromKeys(keys map[any]any) string {
+	if id, ok := keys[requestIDKey].(string); ok && id != "" {
+		return id
+	}
+	return "-"
 }
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// logRequest serves one request through RequestLogger(format) and returns
+// what was logged. The handler authenticates as userID and answers status.
+func logRequest(t *testing.T, format, target string, userID uuid.UUID, status int) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	previous := gin.DefaultWriter
+	gin.DefaultWriter = &out
+	t.Cleanup(func() { gin.DefaultWriter = previous })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), RequestLogger(format))
+	router.GET("/api/v1/links", func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.String(status, "hello")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set("User-Agent", "test-agent")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	return out.String()
+}
+
+func TestRequestLoggerJSON(t *testing.T) {
+	userID := uuid.New()
+	logged := logRequest(t, LogFormatJSON, "/api/v1/links?favorites=true", userID, http.StatusTeapot)
+
+	lines := strings.Split(strings.TrimSuffix(logged, "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("logged %d lines, want 1: %q", len(lines), logged)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("log line is not JSON: %v\n%s", err, lines[0])
+	}
+
+	want := map[string]interface{}{
+		"request_id": "req-123",
+		"method":     "GET",
+		"path":       "/api/v1/links?favorites=true",
+		"status":     float64(http.StatusTeapot),
+		"user_agent": "test-agent",
+		"user_id":    userID.String(),
+		"body_size":  float64(len("hello")),
+	}
+	for field, value := range want {
+		if entry[field] != value {
+			t.Errorf("%s = %v, want %v", field, entry[field], value)
+		}
+	}
+	for _, field := range []string{"time", "latency_ms", "client_ip"} {
+		if _, ok := entry[field]; !ok {
+			t.Errorf("%s missing from %s", field, lines[0])
+		}
+	}
+}
+
+func TestRequestLoggerTextByDefault(t *testing.T) {
+	for _, format := range []string{"", LogFormatText, "unknown"} {
+		logged := logRequest(t, format, "/api/v1/links", uuid.New(), http.StatusOK)
+
+		if json.Valid([]byte(logged)) {
+			t.Errorf("format %q logged JSON: %s", format, logged)
+		}
+		for _, part := range []string{"req-123", `"GET /api/v1/links`, " 200 "} {
+			if !strings.Contains(logged, part) {
+				t.Errorf("format %q: log line %q doesn't contain %q", format, logged, part)
+			}
+		}
+	}
+}
+
+func TestRedactPath(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"/api/v1/links", "/api/v1/links"},
+		{"/api/v1/links?favorites=true", "/api/v1/links?favorites=true"},
+		{"/api/v1/links.atom?key=secret", "/api/v1/links.atom?key=REDACTED"},
+		{"/api/v1/links.atom?limit=5&key=secret", "/api/v1/links.atom?key=REDACTED&limit=5"},
+		{"/api/v1/links.atom?key=a&key=b", "/api/v1/links.atom?key=REDACTED"},
+		{"/api/v1/links.atom?key=%zz", "/api/v1/links.atom?REDACTED"},
+	}
+	for _, tt := range tests {
+		got := redactPath(tt.path)
+		if got != tt.want {
+			t.Errorf("redactPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+		if strings.Contains(got, "secret") {
+			t.Errorf("redactPath(%q) = %q leaks the key", tt.path, got)
+		}
+	}
+}
+
+func TestRequestLoggerRedactsKey(t *testing.T) {
+	for _, format := range []string{LogFormatJSON, LogFormatText} {
+		logged := logRequest(t, format, "/api/v1/links?key=secret-key", uuid.New(), http.StatusOK)
+		if strings.Contains(logged, "secret-key") {
+			t.Errorf("%s log leaks the query key: %s", format, logged)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeClock is a settable time source for RateLimiter.now
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(requestsPerMinute int) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(requestsPerMinute)
+	limiter.now = clock.now
+	return limiter, clock
+}
+
+func TestRateLimiterAllowsBurstThenDenies(t *testing.T) {
+	limiter, _ := newTestLimiter(3)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.Allow("key"); !ok {
+			t.Fatalf("request %d denied within the limit", i+1)
+		}
+	}
+
+	ok, wait := limiter.Allow("key")
+	if ok {
+		t.Fatal("request over the limit allowed")
+	}
+	// 3 per minute refills one token every 20 seconds
+	if wait != 20*time.Second {
+		t.Errorf("wait = %s, want 20s", wait)
+	}
+}
+
+func TestRateLimiterRefills(t *testing.T) {
+	limiter, clock := newTestLimiter(60)
+
+	for i := 0; i < 60; i++ {
+		limiter.Allow("key")
+	}
+	if ok, wait := limiter.Allow("key"); ok || wait != time.Second {
+		t.Fatalf("empty bucket: Allow = %v, %s; want false, 1s", ok, wait)
+	}
+
+	clock.advance(500 * time.Millisecond)
+	if ok, wait := limiter.Allow("key"); ok || wait != 500*time.Millisecond {
+		t.Errorf("half a token: Allow = %v, %s; want false, 500ms", ok, wait)
+	}
+
+	clock.advance(500 * time.Millisecond)
+	if ok, _ := limiter.Allow("key"); !ok {
+		t.Error("denied after a token refilled")
+	}
+	if ok, _ := limiter.Allow("key"); ok {
+		t.Error("allowed a second request on one refilled token")
+	}
+
+	// Refilling stops at the burst size
+	clock.advance(time.Hour)
+	for i := 0; i < 60; i++ {
+		if ok, _ := limiter.Allow("key"); !ok {
+			t.Fatalf("request %d denied after a full refill", i+1)
+		}
+	}
+	if ok, _ := limiter.Allow("key"); ok {
+		t.Error("bucket refilled beyond its burst size")
+	}
+}
+
+func TestRateLimiterKeysAreIndependent(t *testing.T) {
+	limiter, _ := newTestLimiter(2)
+
+	limiter.Allow("a")
+	limiter.Allow("a")
+	if ok, _ := limiter.Allow("a"); ok {
+		t.Fatal("key a not limited")
+	}
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.Allow("b"); !ok {
+			t.Errorf("key b request %d denied because of key a", i+1)
+		}
+	}
+}
+
+func TestRateLimiterSweepsIdleBuckets(t *testing.T) {
+	limiter, clock := newTestLimiter(60)
+
+	limiter.Allow("idle")
+	clock.advance(30 * time.Second)
+	limiter.Allow("active")
+
+	// The idle bucket is full again after a minute; the active one isn't
+	clock.advance(40 * time.Second)
+	limiter.Allow("other")
+
+	if _, ok := limiter.buckets["idle"]; ok {
+		t.Error("idle bucket not swept")
+	}
+	if _, ok := limiter.buckets["active"]; !ok {
+		t.Error("recently used bucket was swept")
+	}
+
+	// A swept key starts over with a full bucket
+	for i := 0; i < 60; i++ {
+		if ok, _ := limiter.Allow("idle"); !ok {
+			t.Fatalf("swept key denied on request %d", i+1)
+		}
+	}
+}
+
+// rateLimitedRouter serves GET / behind RateLimit, authenticating each
+// request as the user whose API key is in the X-Key header
+func rateLimitedRouter(limiter *RateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		c.Set("user", &models.User{APIKey: c.GetHeader("X-Key")})
+		c.Next()
+	}, RateLimit(limiter), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func requestAs(router *gin.Engine, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Key", key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitResponds429WithRetryAfter(t *testing.T) {
+	limiter, _ := newTestLimiter(2)
+	router := rateLimitedRouter(limiter)
+
+	for i := 0; i < 2; i++ {
+		if w := requestAs(router, "a"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i+1, w.Code)
+		}
+	}
+
+	w := requestAs(router, "a")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("over the limit: status = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want %q", got, "30")
+	}
+
+	if w := requestAs(router, "b"); w.Code != http.StatusOK {
+		t.Errorf("other key: status = %d, want 200", w.Code)
+	}
+}
+
+func TestRateLimitRetryAfterIsAtLeastOneSecond(t *testing.T) {
+	limiter, clock := newTestLimiter(60)
+	router := rateLimitedRouter(limiter)
+
+	for i := 0; i < 60; i++ {
+		requestAs(router, "a")
+	}
+	clock.advance(900 * time.Millisecond)
+
+	w := requestAs(router, "a")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Retry-After = %q, want %q (rounded up)", got, "1")
+	}
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// requestIDRouter returns a router using RequestID and ErrorHandler, where
+// GET /echo writes back the request ID it sees and GET /panic panics
+func requestIDRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), ErrorHandler())
+	router.GET("/echo", func(c *gin.Context) {
+		c.String(http.StatusOK, GetRequestID(c))
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return router
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"generated when missing", "", false},
+		{"incoming ID preserved", "req-abc.123", true},
+		{"incoming UUID preserved", "0b8e6a52-3c5f-4d8e-9f3b-1a2b3c4d5e6f", true},
+		{"replaced when it has spaces", "two words", false},
+		{"replaced when too long", strings.Repeat("x", maxRequestIDLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/echo", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			requestIDRouter().ServeHTTP(w, req)
+
+			header := w.Header().Get(RequestIDHeader)
+			if header != w.Body.String() {
+				t.Errorf("response header %q doesn't match the ID on the context %q", header, w.Body.String())
+			}
+			if tt.keep {
+				if header != tt.incoming {
+					t.Errorf("%s = %q, want the incoming %q", RequestIDHeader, header, tt.incoming)
+				}
+			} else if _, err := uuid.Parse(header); err != nil {
+				t.Errorf("%s = %q, want a generated UUID", RequestIDHeader, header)
+			}
+		})
+	}
+}
+
+func TestRequestIDDiffersPerRequest(t *testing.T) {
+	router := requestIDRouter()
+	ids := map[string]bool{}
+	for range 3 {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/echo", nil))
+		ids[w.Header().Get(RequestIDHeader)] = true
+	}
+	if len(ids) != 3 {
+		t.Errorf("got %d distinct IDs over 3 requests, want 3", len(ids))
+	}
+}
+
+func TestErrorHandlerIncludesRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(RequestIDHeader, "req-panic")
+	w := httptest.NewRecorder()
+	requestIDRouter().ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v\n%s", err, w.Body.String())
+	}
+	if body["request_id"] != "req-panic" {
+		t.Errorf("request_id = %q, want %q", body["request_id"], "req-panic")
+	}
+	if got := w.Header().Get(RequestIDHeader); got != "req-panic" {
+		t.Errorf("%s = %q, want %q", RequestIDHeader, got, "req-panic")
+	}
+}
@@ -0,0 +1,23 @@
+// Package openapi holds the hand-written OpenAPI 3 document for the API.
+// Update openapi.json alongside any route or model change in pkg/api.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec returns the raw OpenAPI document
+func Spec() []byte {
+	return spec
+}
+
+// Handler serves the OpenAPI document
+func Handler(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+}
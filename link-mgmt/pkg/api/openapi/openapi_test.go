@@ -0,0 +1,177 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// operationMethods are the keys of a path item that hold operations
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// pathParamPattern matches the {name} segments of a path template
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// document is the parsed spec as generic JSON
+type document map[string]interface{}
+
+func parseSpec(t *testing.T) document {
+	t.Helper()
+
+	var doc document
+	if err := json.Unmarshal(Spec(), &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	return doc
+}
+
+// resolve follows a local $ref ("#/components/...") through doc
+func (doc document) resolve(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("non-local $ref %q", ref)
+	}
+	var node interface{} = map[string]interface{}(doc)
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("$ref %q: %q is not inside an object", ref, token)
+		}
+		if node, ok = obj[token]; !ok {
+			return nil, fmt.Errorf("$ref %q: %q not found", ref, token)
+		}
+	}
+	return node, nil
+}
+
+// deref returns node, or what it points to if it is a $ref object
+func (doc document) deref(t *testing.T, node interface{}) map[string]interface{} {
+	t.Helper()
+
+	obj, _ := node.(map[string]interface{})
+	if ref, ok := obj["$ref"].(string); ok {
+		target, err := doc.resolve(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		obj, _ = target.(map[string]interface{})
+	}
+	return obj
+}
+
+func TestSpecHeader(t *testing.T) {
+	doc := parseSpec(t)
+
+	if version, _ := doc["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		t.Errorf("openapi = %q, want a 3.x version", version)
+	}
+	info, _ := doc["info"].(map[string]interface{})
+	for _, field := range []string{"title", "version"} {
+		if value, _ := info[field].(string); value == "" {
+			t.Errorf("info.%s is missing", field)
+		}
+	}
+	if paths, _ := doc["paths"].(map[string]interface{}); len(paths) == 0 {
+		t.Error("paths is empty")
+	}
+}
+
+func TestSpecRefsResolve(t *testing.T) {
+	doc := parseSpec(t)
+
+	var walk func(path string, node interface{})
+	walk = func(path string, node interface{}) {
+		switch node := node.(type) {
+		case map[string]interface{}:
+			for key, child := range node {
+				if key == "$ref" {
+					ref, ok := child.(string)
+					if !ok {
+						t.Errorf("%s: $ref is not a string", path)
+						continue
+					}
+					if _, err := doc.resolve(ref); err != nil {
+						t.Errorf("%s: %v", path, err)
+					}
+					continue
+				}
+				walk(path+"/"+key, child)
+			}
+		case []interface{}:
+			for i, child := range node {
+				walk(fmt.Sprintf("%s/%d", path, i), child)
+			}
+		}
+	}
+	walk("#", map[string]interface{}(doc))
+}
+
+func TestSpecOperations(t *testing.T) {
+	doc := parseSpec(t)
+	paths, _ := doc["paths"].(map[string]interface{})
+	components, _ := doc["components"].(map[string]interface{})
+	schemes, _ := components["securitySchemes"].(map[string]interface{})
+
+	operationIDs := make(map[string]string)
+	for path, item := range paths {
+		pathItem, _ := item.(map[string]interface{})
+		pathParams := doc.declaredPathParams(t, pathItem["parameters"])
+
+		for _, method := range operationMethods {
+			op, ok := pathItem[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			where := strings.ToUpper(method) + " " + path
+
+			if responses, _ := op["responses"].(map[string]interface{}); len(responses) == 0 {
+				t.Errorf("%s has no responses", where)
+			}
+
+			if id, _ := op["operationId"].(string); id != "" {
+				if other, dup := operationIDs[id]; dup {
+					t.Errorf("%s reuses operationId %q of %s", where, id, other)
+				}
+				operationIDs[id] = where
+			}
+
+			declared := doc.declaredPathParams(t, op["parameters"])
+			for name := range pathParams {
+				declared[name] = true
+			}
+			for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+				if !declared[match[1]] {
+					t.Errorf("%s doesn't declare path parameter %q", where, match[1])
+				}
+			}
+
+			security, _ := op["security"].([]interface{})
+			for _, requirement := range security {
+				for scheme := range requirement.(map[string]interface{}) {
+					if _, ok := schemes[scheme]; !ok {
+						t.Errorf("%s uses undefined security scheme %q", where, scheme)
+					}
+				}
+			}
+		}
+	}
+}
+
+// declaredPathParams returns the names of the required path parameters in a
+// parameters list
+func (doc document) declaredPathParams(t *testing.T, params interface{}) map[string]bool {
+	t.Helper()
+
+	declared := make(map[string]bool)
+	list, _ := params.([]interface{})
+	for _, param := range list {
+		p := doc.deref(t, param)
+		if p["in"] == "path" && p["required"] == true {
+			name, _ := p["name"].(string)
+			declared[name] = true
+		}
+	}
+	return declared
+}
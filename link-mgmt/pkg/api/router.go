@@ -1,8 +1,13 @@
 package api
 
 import (
+	"context"
+	"log"
+	"time"
+
 	"link-mgmt/pkg/api/handlers"
 	"link-mgmt/pkg/api/middleware"
+	"link-mgmt/pkg/api/openapi"
 	"link-mgmt/pkg/config"
 	"link-mgmt/pkg/db"
 	"link-mgmt/pkg/scraper"
@@ -11,46 +16,85 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(db *db.DB, cfg *config.Config) *gin.Engine {
-	router := gin.Default()
+// NewRouter builds the API routes. Cancelling lifecycle aborts in-flight
+// scrapes, so it should be cancelled when the server starts shutting down.
+func NewRouter(lifecycle context.Context, db *db.DB, cfg *config.Config) *gin.Engine {
+	// gin.New rather than gin.Default: request logging and panic recovery
+	// are provided by our own middleware below
+	router := gin.New()
 
 	// Initialize services
 	// Use Scraper.BaseURL from config (defaults to CLI.BaseURL if not set)
-	scraperBaseURL := cfg.Scraper.BaseURL
-	if scraperBaseURL == "" {
-		scraperBaseURL = cfg.CLI.BaseURL
+	scraperService := scraper.NewScraperService(cfg.ScraperBaseURL())
+	scraperService.EnableCache(time.Duration(cfg.Scraper.CacheTTL) * time.Second)
+	scraperService.SetUserAgent(cfg.Scraper.UserAgent)
+	if adapter, err := scraper.AdapterByName(cfg.Scraper.Adapter); err != nil {
+		log.Printf("Warning: invalid scraper.adapter, using the default: %v", err)
+	} else {
+		scraperService.SetAdapter(adapter)
 	}
-	scraperService := scraper.NewScraperService(scraperBaseURL)
 	linkService := services.NewLinkService(db, scraperService)
+	linkService.SetLifecycle(lifecycle)
+	linkService.SetMaxTextLength(cfg.API.MaxTextLength)
+	linkService.SetMaxConcurrentScrapes(cfg.Scraper.MaxConcurrent)
 
 	// Middleware
-	router.Use(middleware.RequestLogger())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.RequestLogger(cfg.API.LogFormat))
 	router.Use(middleware.ErrorHandler())
 
+	// Per-API-key rate limiting, applied after auth on authenticated routes
+	requireAuth := []gin.HandlerFunc{middleware.RequireAuth(db)}
+	if cfg.API.RateLimitPerMinute > 0 {
+		limiter := middleware.NewRateLimiter(cfg.API.RateLimitPerMinute)
+		requireAuth = append(requireAuth, middleware.RateLimit(limiter))
+	}
+
 	// Health check
 	router.GET("/health", handlers.HealthCheck)
+	router.GET("/health/ready", handlers.ReadinessCheck(db))
 
 	// API routes
 	v1 := router.Group("/api/v1")
 	{
+		// API contract (public)
+		v1.GET("/openapi.json", openapi.Handler)
+		v1.GET("/version", handlers.Version)
+
+		// Atom feed of recent links, authenticated with ?key= for feed readers
+		v1.GET("/links.atom", append([]gin.HandlerFunc{middleware.QueryAPIKey("key")}, append(requireAuth, handlers.LinksFeed(linkService))...)...)
+
 		// Links
 		links := v1.Group("/links")
-		links.Use(middleware.RequireAuth(db))
+		links.Use(requireAuth...)
 		{
 			links.GET("", handlers.ListLinks(linkService))
 			links.POST("", handlers.CreateLink(linkService))
+			links.DELETE("", handlers.DeleteLinks(linkService))
+			links.POST("/batch", handlers.CreateLinks(linkService))
 			links.POST("/with-scraping", handlers.CreateLinkWithScraping(linkService))
+			links.PUT("/reorder", handlers.ReorderLinks(linkService))
+			links.GET("/recent", handlers.RecentLinks(linkService))
+			links.POST("/transfer", handlers.TransferLinks(linkService))
 			links.GET("/:id", handlers.GetLink(linkService))
+			links.GET("/:id/similar", handlers.SimilarLinks(linkService))
 			links.PUT("/:id", handlers.UpdateLink(linkService))
 			links.DELETE("/:id", handlers.DeleteLink(linkService))
 			links.POST("/:id/enrich", handlers.EnrichLink(linkService))
+			links.GET("/:id/enrich/stream", middleware.RequireWriteAccess(), handlers.EnrichLinkStream(linkService))
+			links.POST("/:id/favorite", handlers.ToggleFavorite(linkService))
+			links.POST("/:id/read", handlers.SetLinkRead(linkService))
+			links.POST("/:id/visit", handlers.RecordVisit(linkService))
 		}
 
 		// Users
 		users := v1.Group("/users")
 		{
-			users.POST("", handlers.CreateUser(db))
-			users.GET("/me", middleware.RequireAuth(db), handlers.GetCurrentUser(db))
+			users.POST("", handlers.CreateUser(db, cfg.API.KeyExpiryDays))
+			users.GET("/me", append(requireAuth, handlers.GetCurrentUser(db))...)
+			users.DELETE("/me", append(requireAuth, handlers.DeleteCurrentUser(db))...)
+			users.POST("/me/rotate-key", append(requireAuth, handlers.RotateAPIKey(db, cfg.API.KeyExpiryDays))...)
+			users.POST("/me/keys", append(requireAuth, handlers.CreateReadOnlyAPIKey(db, cfg.API.KeyExpiryDays))...)
 		}
 	}
 
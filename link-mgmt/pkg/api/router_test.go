@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"link-mgmt/pkg/api/openapi"
+	"link-mgmt/pkg/config"
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// ginParamPattern matches the :name segments of a gin route
+var ginParamPattern = regexp.MustCompile(`:([^/]+)`)
+
+// specPath converts a gin route path to an OpenAPI path template
+func specPath(route string) string {
+	return ginParamPattern.ReplaceAllString(route, "{$1}")
+}
+
+func TestOpenAPISpecCoversRoutes(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openapi.Spec(), &spec); err != nil {
+		t.Fatalf("openapi.json doesn't parse: %v", err)
+	}
+
+	// Routes only reach the database when they handle a request
+	router := NewRouter(context.Background(), nil, config.DefaultConfig())
+
+	routes := make(map[string]bool)
+	for _, route := range router.Routes() {
+		path := specPath(route.Path)
+		routes[route.Method+" "+path] = true
+		operations, ok := spec.Paths[path]
+		if !ok {
+			t.Errorf("%s %s: path %s missing from openapi.json", route.Method, route.Path, path)
+			continue
+		}
+		if _, ok := operations[strings.ToLower(route.Method)]; !ok {
+			t.Errorf("%s %s: no %s operation under %s in openapi.json", route.Method, route.Path, strings.ToLower(route.Method), path)
+		}
+	}
+
+	// The spec shouldn't document routes that don't exist either
+	for path, operations := range spec.Paths {
+		for key := range operations {
+			if key == "parameters" {
+				continue
+			}
+			if method := strings.ToUpper(key); !routes[method+" "+path] {
+				t.Errorf("openapi.json documents %s %s, which isn't routed", method, path)
+			}
+		}
+	}
+}
+
+func TestLinksFeedQueryKey(t *testing.T) {
+	database := dbtest.New(t)
+	router := NewRouter(context.Background(), database, config.DefaultConfig())
+
+	user := dbtest.CreateUser(t, database)
+	title := "Feed Entry"
+	dbtest.CreateLink(t, database, user.ID, "https://example.com/feed", &title)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/links.atom?key="+user.APIKey, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/atom+xml") {
+		t.Errorf("Content-Type = %q, want application/atom+xml", ct)
+	}
+	var feed struct {
+		Entries []struct {
+			Title string `xml:"title"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("feed doesn't parse: %v", err)
+	}
+	if len(feed.Entries) != 1 || feed.Entries[0].Title != title {
+		t.Errorf("entries = %+v, want the user's link", feed.Entries)
+	}
+
+	for _, target := range []string{"/api/v1/links.atom", "/api/v1/links.atom?key=wrong"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("GET %s: status = %d, want %d", target, w.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+// readOnlyRoutes are the authenticated routes a read-only API key may use
+var readOnlyRoutes = map[string]bool{
+	"GET /api/v1/links.atom":        true,
+	"GET /api/v1/links":             true,
+	"GET /api/v1/links/recent":      true,
+	"GET /api/v1/links/:id":         true,
+	"GET /api/v1/links/:id/similar": true,
+	"GET /api/v1/users/me":          true,
+}
+
+// publicRoutes need no API key at all
+var publicRoutes = map[string]bool{
+	"GET /health":              true,
+	"GET /health/ready":        true,
+	"GET /api/v1/openapi.json": true,
+	"GET /api/v1/version":      true,
+	"POST /api/v1/users":       true,
+}
+
+func TestReadOnlyKeyOnEveryRoute(t *testing.T) {
+	database := dbtest.New(t)
+	router := NewRouter(context.Background(), database, config.DefaultConfig())
+
+	user := dbtest.CreateUser(t, database)
+	key := dbtest.CreateReadOnlyKey(t, database, user.ID)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/read-only", nil)
+
+	for _, route := range router.Routes() {
+		name := route.Method + " " + route.Path
+		if publicRoutes[name] {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			path := strings.ReplaceAll(route.Path, ":id", link.ID.String())
+			req := httptest.NewRequest(route.Method, path, strings.NewReader(`{}`))
+			req.Header.Set("Authorization", "Bearer "+key)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if readOnlyRoutes[name] {
+				if w.Code != http.StatusOK {
+					t.Errorf("status = %d, want %d for a read: %s", w.Code, http.StatusOK, w.Body.String())
+				}
+				return
+			}
+			if w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d for a write: %s", w.Code, http.StatusForbidden, w.Body.String())
+			}
+		})
+	}
+
+	// Nothing was written
+	if _, err := database.GetLinkByID(context.Background(), link.ID, user.ID); err != nil {
+		t.Errorf("the link is gone after read-only requests: %v", err)
+	}
+	if count, err := database.CountLinksByUserID(context.Background(), user.ID, models.LinkFilter{}); err != nil || count != 1 {
+		t.Errorf("CountLinksByUserID = %d, %v; want 1, nil", count, err)
+	}
+}
+
+func TestReadOnlyRouteListsAreRouted(t *testing.T) {
+	router := NewRouter(context.Background(), nil, config.DefaultConfig())
+	routed := make(map[string]bool)
+	for _, route := range router.Routes() {
+		routed[route.Method+" "+route.Path] = true
+	}
+	for _, list := range []map[string]bool{readOnlyRoutes, publicRoutes} {
+		for name := range list {
+			if !routed[name] {
+				t.Errorf("%s is listed but not routed", name)
+			}
+		}
+	}
+}
+
+func TestDeleteAccountRevokesKeys(t *testing.T) {
+	database := dbtest.New(t)
+	router := NewRouter(context.Background(), database, config.DefaultConfig())
+
+	user := dbtest.CreateUser(t, database)
+	readOnlyKey := dbtest.CreateReadOnlyKey(t, database, user.ID)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/deleted", nil)
+
+	request := func(method, path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// A read-only key can't delete the account
+	if w := request(http.MethodDelete, "/api/v1/users/me", readOnlyKey); w.Code != http.StatusForbidden {
+		t.Fatalf("read-only delete: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	if w := request(http.MethodDelete, "/api/v1/users/me", user.APIKey); w.Code != http.StatusOK {
+		t.Fatalf("delete: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	for name, key := range map[string]string{"primary": user.APIKey, "read-only": readOnlyKey} {
+		if w := request(http.MethodGet, "/api/v1/links", key); w.Code != http.StatusUnauthorized {
+			t.Errorf("%s key after delete: status = %d, want %d", name, w.Code, http.StatusUnauthorized)
+		}
+	}
+	if _, err := database.GetLinkByID(context.Background(), link.ID, user.ID); err == nil {
+		t.Error("link still exists after the account was deleted")
+	}
+}
@@ -1,24 +1,57 @@
 package cli
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"link-mgmt/pkg/cli/browser"
 	"link-mgmt/pkg/cli/client"
+	"link-mgmt/pkg/cli/clipboard"
+	"link-mgmt/pkg/cli/links"
+	"link-mgmt/pkg/cli/logger"
 	"link-mgmt/pkg/cli/tui"
 	"link-mgmt/pkg/config"
 	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/utils"
+
+	"github.com/google/uuid"
 )
 
 type App struct {
-	cfg    *config.Config
-	client *client.Client
+	cfg       *config.Config
+	client    *client.Client
+	browser   browser.Launcher
+	clipboard clipboard.Writer
+	stdin     io.Reader
+	quiet     bool // print results only, without progress and status decorations
 }
 
 func NewApp(cfg *config.Config) *App {
 	return &App{
-		cfg: cfg,
+		cfg:       cfg,
+		browser:   browser.System{},
+		clipboard: clipboard.System{},
+		stdin:     os.Stdin,
+	}
+}
+
+// SetQuiet turns off progress and status output, leaving only results, for
+// scripts and CI logs. Errors are returned as usual.
+func (a *App) SetQuiet(quiet bool) {
+	a.quiet = quiet
+}
+
+// statusf prints a progress or status line unless quiet is set
+func (a *App) statusf(format string, args ...interface{}) {
+	if !a.quiet {
+		fmt.Printf(format, args...)
 	}
 }
 
@@ -35,21 +68,30 @@ func (a *App) getClient() (*client.Client, error) {
 		return nil, fmt.Errorf("API key not configured")
 	}
 
-	a.client = client.NewClient(a.cfg.CLI.BaseURL, a.cfg.CLI.APIKey)
+	a.client = a.newAPIClient(a.cfg.CLI.APIKey)
 	return a.client, nil
 }
 
+// newAPIClient creates an API client for the configured base URL and path
+// prefix that authenticates with apiKey (none if empty)
+func (a *App) newAPIClient(apiKey string) *client.Client {
+	apiClient := client.NewClient(a.cfg.CLI.BaseURL, apiKey)
+	apiClient.SetPathPrefix(a.cfg.CLI.APIPrefix)
+	return apiClient
+}
+
 // getClientForRegistration returns an HTTP client without API key (for registration)
 func (a *App) getClientForRegistration() (*client.Client, error) {
 	if a.cfg.CLI.BaseURL == "" {
 		return nil, fmt.Errorf("base URL not configured (set cli.base_url)")
 	}
 	// Use empty API key for registration endpoint (doesn't require auth)
-	return client.NewClient(a.cfg.CLI.BaseURL, ""), nil
+	return a.newAPIClient(""), nil
 }
 
-// SaveLink saves a link to the API
-func (a *App) SaveLink(url string) error {
+// SaveLink saves a link to the API. With verify set, the API first checks
+// that the URL is reachable and refuses to save it if not.
+func (a *App) SaveLink(url string, verify bool) error {
 	apiClient, err := a.getClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
@@ -59,7 +101,7 @@ func (a *App) SaveLink(url string) error {
 		URL: url,
 	}
 
-	created, err := apiClient.CreateLink(linkCreate)
+	created, err := apiClient.CreateLink(linkCreate, verify)
 	if err != nil {
 		return fmt.Errorf("failed to save link: %w", err)
 	}
@@ -74,15 +116,374 @@ func (a *App) SaveLink(url string) error {
 	return nil
 }
 
-func (a *App) Run() error {
+// AddLink creates a link non-interactively, optionally letting the API scrape
+// the page for title and text first, and prints the created link. With
+// verify set, the API first checks that the URL is reachable. Quiet prints
+// only the link.
+func (a *App) AddLink(url string, scrape, verify bool) error {
 	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if scrape {
+		a.statusf("⏳ Saving and scraping link... (this may take a few seconds)\n")
+	}
+
+	created, err := apiClient.CreateLinkWithScraping(
+		models.LinkCreate{URL: url},
+		scrape,
+		a.cfg.CLI.ScrapeTimeout,
+		true, // only fill empty
+		verify,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add link: %w", err)
+	}
+
+	a.statusf("✓ Link added successfully!\n")
+	links.WriteToStdout(links.FormatLinkDetails(created))
+	return nil
+}
+
+// UpdateLink applies a sparse update to a link identified by full UUID or
+// short ID prefix (as shown by list output) and prints the result
+func (a *App) UpdateLink(id string, update models.LinkUpdate) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if update.URL == nil && update.Title == nil && update.Description == nil && update.Text == nil && update.Notes == nil && len(update.ClearFields) == 0 {
+		return fmt.Errorf("nothing to update (use --set-title, --set-description, --set-text, --set-notes, or --clear)")
+	}
+	if err := update.Validate(); err != nil {
+		return err
+	}
+
+	linkID, err := a.resolveLinkID(apiClient, id)
 	if err != nil {
 		return err
 	}
 
-	// No scraper service needed - API handles it
-	model := tui.NewRootModel(apiClient, a.cfg.CLI.ScrapeTimeout)
-	p := tea.NewProgram(model)
-	_, err = p.Run()
+	updated, err := apiClient.UpdateLink(linkID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update link: %w", err)
+	}
+
+	fmt.Println("✓ Link updated successfully!")
+	fmt.Printf("  ID: %s\n", updated.ID.String())
+	fmt.Printf("  URL: %s\n", updated.URL)
+	fmt.Printf("  Title: %s\n", links.GetTitle(*updated))
+	if updated.Description != nil && *updated.Description != "" {
+		fmt.Printf("  Description: %s\n", *updated.Description)
+	}
+	if updated.Text != nil && *updated.Text != "" {
+		fmt.Printf("  Text: %d characters\n", len(*updated.Text))
+	}
+	if updated.Notes != nil && *updated.Notes != "" {
+		fmt.Printf("  Notes: %s\n", *updated.Notes)
+	}
+
+	return nil
+}
+
+// resolveLinkID parses a full UUID, or matches a short ID prefix against the user's links
+func (a *App) resolveLinkID(apiClient *client.Client, id string) (uuid.UUID, error) {
+	if linkID, err := uuid.Parse(strings.TrimSpace(id)); err == nil {
+		return linkID, nil
+	}
+
+	all, err := apiClient.ListLinks()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to list links: %w", err)
+	}
+
+	return links.ResolveLinkID(id, all)
+}
+
+// ViewLink prints all details of a link identified by full UUID or short ID prefix
+func (a *App) ViewLink(id string) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	linkID, err := a.resolveLinkID(apiClient, id)
+	if err != nil {
+		return err
+	}
+
+	link, err := apiClient.GetLink(linkID)
+	if err != nil {
+		return fmt.Errorf("failed to get link: %w", err)
+	}
+
+	links.WriteToStdout(links.FormatLinkDetails(link))
+	return nil
+}
+
+// ExportMarkdown prints a link identified by full UUID or short ID prefix as
+// a Markdown snippet
+func (a *App) ExportMarkdown(id string) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	linkID, err := a.resolveLinkID(apiClient, id)
+	if err != nil {
+		return err
+	}
+
+	link, err := apiClient.GetLink(linkID)
+	if err != nil {
+		return fmt.Errorf("failed to get link: %w", err)
+	}
+
+	links.WriteToStdout(links.FormatMarkdown(link))
+	return nil
+}
+
+// OpenLink opens a link identified by full UUID or short ID prefix in the default browser
+func (a *App) OpenLink(id string) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	linkID, err := a.resolveLinkID(apiClient, id)
+	if err != nil {
+		return err
+	}
+
+	link, err := apiClient.GetLink(linkID)
+	if err != nil {
+		return fmt.Errorf("failed to get link: %w", err)
+	}
+
+	// A failed count (e.g. with a read-only key) shouldn't stop the link opening
+	if _, err := apiClient.RecordVisit(linkID); err != nil {
+		logger.Error(err, "failed to record visit")
+	}
+
+	if err := a.browser.Open(link.URL); err != nil {
+		return err
+	}
+
+	fmt.Printf("Opened %s\n", link.URL)
+	return nil
+}
+
+// CopyURL copies the URL of a link identified by full UUID or short ID prefix
+// to the clipboard
+func (a *App) CopyURL(id string) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	linkID, err := a.resolveLinkID(apiClient, id)
+	if err != nil {
+		return err
+	}
+
+	link, err := apiClient.GetLink(linkID)
+	if err != nil {
+		return fmt.Errorf("failed to get link: %w", err)
+	}
+
+	if err := a.clipboard.Write(link.URL); err != nil {
+		if errors.Is(err, clipboard.ErrUnavailable) {
+			// Still useful without a clipboard: print the URL to copy by hand
+			fmt.Println(link.URL)
+		}
+		return err
+	}
+
+	fmt.Printf("Copied %s\n", link.URL)
+	return nil
+}
+
+// DeleteLink deletes a link identified by full UUID or short ID prefix.
+// Unless skipConfirm is set, it asks for confirmation on stdin first.
+func (a *App) DeleteLink(id string, skipConfirm bool) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	linkID, err := a.resolveLinkID(apiClient, id)
+	if err != nil {
+		return err
+	}
+
+	link, err := apiClient.GetLink(linkID)
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("no link found with ID %s", linkID)
+		}
+		return fmt.Errorf("failed to get link: %w", err)
+	}
+
+	if !skipConfirm {
+		label := link.URL
+		if link.Title != nil && *link.Title != "" {
+			label = fmt.Sprintf("%s (%s)", *link.Title, link.URL)
+		}
+		ok, err := a.confirm(fmt.Sprintf("Delete %s?", label))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	if err := apiClient.DeleteLink(linkID); err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("no link found with ID %s", linkID)
+		}
+		return fmt.Errorf("failed to delete link: %w", err)
+	}
+
+	fmt.Printf("✓ Deleted %s\n", link.URL)
+	fmt.Printf("  ID: %s\n", linkID.String())
+	return nil
+}
+
+// confirm asks a y/N question on stdin; anything but y/yes is a no
+func (a *App) confirm(question string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	return utils.IsConfirmed(answer), nil
+}
+
+// confirmDelete asks before deleting count links at once: y/N, or typing the
+// count when it is above cli.bulk_confirm_threshold
+func (a *App) confirmDelete(question string, count int) (bool, error) {
+	if !utils.NeedsCountConfirmation(count, a.cfg.CLI.BulkConfirmThreshold) {
+		return a.confirm(question)
+	}
+	fmt.Printf("%s Type %d to confirm: ", question, count)
+	answer, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	return utils.IsCountConfirmed(answer, count), nil
+}
+
+// ListLinks prints the user's links matching the filter as a table. fields
+// is a comma-separated list of links.TableFields selecting the columns;
+// empty shows the default ones. groupBy is links.GroupByDomain to group the
+// links under their domain, or empty for a single table.
+func (a *App) ListLinks(filter models.LinkFilter, fields, groupBy string) error {
+	columns, err := links.ParseTableFields(fields)
+	if err != nil {
+		return err
+	}
+	if err := links.ValidateGroupBy(groupBy); err != nil {
+		return err
+	}
+
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	linkList, err := apiClient.ListLinksWithFilter(filter)
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+
+	if len(linkList) == 0 {
+		switch {
+		case filter.FavoritesOnly:
+			links.WriteToStdout(links.FormatEmptyState("No favorite links found."))
+			return nil
+		case filter.UnreadOnly:
+			links.WriteToStdout(links.FormatEmptyState("No unread links."))
+			return nil
+		case filter.CreatedAfter != nil || filter.CreatedBefore != nil:
+			links.WriteToStdout(links.FormatEmptyState("No links found in that date range."))
+			return nil
+		}
+	}
+
+	if groupBy == links.GroupByDomain {
+		links.WriteToStdout(links.FormatGroupedTableOutput(links.GroupLinksByDomain(linkList), a.cfg.CLI.URLDisplayWidth, columns))
+		return nil
+	}
+	links.WriteToStdout(links.FormatTableOutput(linkList, a.cfg.CLI.URLDisplayWidth, columns))
+	return nil
+}
+
+// Run starts the interactive menu. Without an API key it first offers to
+// register an account, as --register would, and quits if that's declined.
+func (a *App) Run() error {
+	if a.cfg.CLI.BaseURL != "" && a.cfg.CLI.APIKey == "" {
+		registered, err := a.offerRegistration()
+		if err != nil {
+			return err
+		}
+		if !registered {
+			return nil
+		}
+	}
+
+	model, err := a.newRootModel()
+	if err != nil {
+		return err
+	}
+	_, err = tea.NewProgram(model).Run()
 	return err
 }
+
+// offerRegistration asks for an email to register when no API key is
+// configured. It reports whether an account was registered; an empty answer
+// declines.
+func (a *App) offerRegistration() (bool, error) {
+	fmt.Println("No API key configured.")
+	fmt.Print("Enter an email to register a new account (or press Enter to quit): ")
+	answer, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read email: %w", err)
+	}
+
+	email := strings.TrimSpace(answer)
+	if email == "" {
+		fmt.Println("To use an existing account instead: --config-set cli.api_key=<key>")
+		return false, nil
+	}
+	if err := a.RegisterUser(email); err != nil {
+		return false, fmt.Errorf("failed to register user: %w", err)
+	}
+	fmt.Println()
+	return true, nil
+}
+
+// newRootModel builds the root menu for the configured API, with the scraper
+// health check when the scraper service is configured
+func (a *App) newRootModel() (tea.Model, error) {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// The API does the scraping; the scraper client is only used for a quick
+	// health check so the add form can skip scraping when the service is down
+	var scraperHealth tui.ScraperHealthFunc
+	if scraperService, err := a.getScraperService(); err == nil {
+		scraperHealth = scraperService.CheckHealthWithContext
+	}
+
+	model := tui.NewRootModel(apiClient, a.browser, a.clipboard, scraperHealth, a.cfg.CLI.ScrapeTimeout, a.cfg.CLI.PreviewLength,
+		a.cfg.CLI.BulkConfirmThreshold, func() tea.Model { return a.newConfigEditor(true) })
+	return model, nil
+}
@@ -0,0 +1,519 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"link-mgmt/pkg/cli/links"
+	"link-mgmt/pkg/cli/logger"
+	"link-mgmt/pkg/config"
+	"link-mgmt/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// TestMain sends log output to stderr, so the error paths these tests take
+// don't leave log files under tmp/ in the source tree
+func TestMain(m *testing.M) {
+	if err := logger.Configure([]string{"stderr"}, "error"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeLauncher records the URLs it is asked to open instead of starting a browser
+type fakeLauncher struct {
+	opened []string
+	err    error
+}
+
+func (l *fakeLauncher) Open(url string) error {
+	l.opened = append(l.opened, url)
+	return l.err
+}
+
+// testAPI is a stub API server that records the requests it receives
+type testAPI struct {
+	*http.ServeMux
+
+	mu    sync.Mutex
+	calls []string // "METHOD /path" of every request, in order
+}
+
+// newTestAPI starts a stub API server and returns it with an App using it.
+// Routes are registered on the returned API's ServeMux.
+func newTestAPI(t *testing.T) (*testAPI, *App) {
+	t.Helper()
+
+	api, url := startStubServer(t)
+	cfg := config.DefaultConfig()
+	cfg.CLI.BaseURL = url
+	cfg.CLI.APIKey = "test-key"
+	app := NewApp(cfg)
+	app.browser = &fakeLauncher{}
+	return api, app
+}
+
+// startStubServer starts a stub server recording its requests and returns it
+// with its URL
+func startStubServer(t *testing.T) (*testAPI, string) {
+	t.Helper()
+
+	api := &testAPI{ServeMux: http.NewServeMux()}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		api.calls = append(api.calls, r.Method+" "+r.URL.Path)
+		api.mu.Unlock()
+		api.ServeMux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return api, server.URL
+}
+
+// Calls returns the requests received so far
+func (a *testAPI) Calls() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.calls)
+}
+
+// respondJSON returns a handler that writes body as JSON with status
+func respondJSON(status int, body interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+// captureStdout returns what fn prints to standard output
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	fn()
+	w.Close()
+	return <-out
+}
+
+// assertCalls fails the test unless the API received exactly want, in order
+func assertCalls(t *testing.T, api *testAPI, want ...string) {
+	t.Helper()
+	if got := api.Calls(); !slices.Equal(got, want) {
+		t.Errorf("API calls = %q, want %q", got, want)
+	}
+}
+
+func TestOpenLink(t *testing.T) {
+	link := models.Link{ID: uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000001"), URL: "https://example.com/article"}
+	other := models.Link{ID: uuid.MustParse("7b5d0e2f-0000-4000-8000-000000000002"), URL: "https://example.org"}
+
+	t.Run("opens the link resolved from a prefix and counts the visit", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		api.Handle("GET /api/v1/links", respondJSON(http.StatusOK, []models.Link{link, other}))
+		api.Handle("GET /api/v1/links/{id}", respondJSON(http.StatusOK, link))
+		api.Handle("POST /api/v1/links/{id}/visit", respondJSON(http.StatusOK, map[string]int{"visit_count": 1}))
+
+		if err := app.OpenLink("3f2a"); err != nil {
+			t.Fatalf("OpenLink: %v", err)
+		}
+		if got := app.browser.(*fakeLauncher).opened; !slices.Equal(got, []string{link.URL}) {
+			t.Errorf("opened %q, want %q", got, link.URL)
+		}
+		assertCalls(t, api,
+			"GET /api/v1/links",
+			"GET /api/v1/links/"+link.ID.String(),
+			"POST /api/v1/links/"+link.ID.String()+"/visit",
+		)
+	})
+
+	t.Run("opens the link even if the visit isn't counted", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		api.Handle("GET /api/v1/links/{id}", respondJSON(http.StatusOK, link))
+		api.Handle("POST /api/v1/links/{id}/visit", respondJSON(http.StatusForbidden, map[string]string{"error": "API key is read-only"}))
+
+		if err := app.OpenLink(link.ID.String()); err != nil {
+			t.Fatalf("OpenLink: %v", err)
+		}
+		if got := app.browser.(*fakeLauncher).opened; !slices.Equal(got, []string{link.URL}) {
+			t.Errorf("opened %q, want %q", got, link.URL)
+		}
+	})
+
+	t.Run("returns the launcher's error", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		api.Handle("GET /api/v1/links/{id}", respondJSON(http.StatusOK, link))
+		api.Handle("POST /api/v1/links/{id}/visit", respondJSON(http.StatusOK, map[string]int{"visit_count": 1}))
+		launchErr := errors.New("no browser")
+		app.browser = &fakeLauncher{err: launchErr}
+
+		if err := app.OpenLink(link.ID.String()); !errors.Is(err, launchErr) {
+			t.Errorf("OpenLink error = %v, want %v", err, launchErr)
+		}
+	})
+
+	t.Run("doesn't open an unknown link", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		api.Handle("GET /api/v1/links/{id}", respondJSON(http.StatusNotFound, map[string]string{"error": "link not found"}))
+
+		if err := app.OpenLink(link.ID.String()); err == nil {
+			t.Fatal("OpenLink succeeded, want an error")
+		}
+		if got := app.browser.(*fakeLauncher).opened; len(got) != 0 {
+			t.Errorf("opened %q, want nothing", got)
+		}
+	})
+}
+
+// addLinkRequest is the body the CLI sends to create a link with scraping
+type addLinkRequest struct {
+	URL    string `json:"url"`
+	Scrape *struct {
+		Enabled       bool `json:"enabled"`
+		Timeout       int  `json:"timeout"`
+		OnlyFillEmpty bool `json:"only_fill_empty"`
+	} `json:"scrape"`
+}
+
+func TestAddLink(t *testing.T) {
+	title := "Example Article"
+	for _, scrape := range []bool{true, false} {
+		t.Run(fmt.Sprintf("scrape=%v", scrape), func(t *testing.T) {
+			api, app := newTestAPI(t)
+			app.cfg.CLI.ScrapeTimeout = 12
+
+			var got addLinkRequest
+			var query string
+			api.HandleFunc("POST /api/v1/links/with-scraping", func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decoding request: %v", err)
+				}
+				link := models.Link{ID: uuid.New(), URL: got.URL}
+				if got.Scrape != nil && got.Scrape.Enabled {
+					link.Title = &title
+				}
+				respondJSON(http.StatusCreated, link)(w, r)
+			})
+
+			var err error
+			out := captureStdout(t, func() { err = app.AddLink("https://example.com/article", scrape, false) })
+			if err != nil {
+				t.Fatalf("AddLink: %v", err)
+			}
+
+			if got.URL != "https://example.com/article" {
+				t.Errorf("sent URL %q, want %q", got.URL, "https://example.com/article")
+			}
+			if query != "" {
+				t.Errorf("query = %q, want none without verify", query)
+			}
+			if scrape {
+				if got.Scrape == nil || !got.Scrape.Enabled || got.Scrape.Timeout != 12 || !got.Scrape.OnlyFillEmpty {
+					t.Errorf("scrape options = %+v, want enabled with the configured 12s timeout, filling empty fields", got.Scrape)
+				}
+				if !strings.Contains(out, title) {
+					t.Errorf("output doesn't show the scraped title:\n%s", out)
+				}
+			} else if got.Scrape != nil {
+				t.Errorf("scrape options = %+v, want none", got.Scrape)
+			}
+			if !strings.Contains(out, "Link added successfully") {
+				t.Errorf("output doesn't confirm the link was added:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestAddLinkQuiet(t *testing.T) {
+	title := "Example Article"
+	for _, quiet := range []bool{false, true} {
+		api, app := newTestAPI(t)
+		api.Handle("POST /api/v1/links/with-scraping", respondJSON(http.StatusCreated,
+			models.Link{ID: uuid.New(), URL: "https://example.com/article", Title: &title}))
+		app.SetQuiet(quiet)
+
+		var err error
+		out := captureStdout(t, func() { err = app.AddLink("https://example.com/article", true, false) })
+		if err != nil {
+			t.Fatalf("quiet %v: AddLink: %v", quiet, err)
+		}
+		if !strings.Contains(out, title) {
+			t.Errorf("quiet %v: output missing the link:\n%s", quiet, out)
+		}
+		for _, decoration := range []string{"⏳", "✓", "Link added successfully"} {
+			if got := strings.Contains(out, decoration); got == quiet {
+				t.Errorf("quiet %v: output contains %q = %v:\n%s", quiet, decoration, got, out)
+			}
+		}
+	}
+}
+
+func TestAddLinkVerifyAndFailure(t *testing.T) {
+	api, app := newTestAPI(t)
+	var query string
+	api.HandleFunc("POST /api/v1/links/with-scraping", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		respondJSON(http.StatusUnprocessableEntity, map[string]string{"error": "URL is unreachable"})(w, r)
+	})
+
+	err := app.AddLink("https://unreachable.example", false, true)
+	if err == nil || !strings.Contains(err.Error(), "URL is unreachable") {
+		t.Errorf("AddLink error = %v, want the API's error", err)
+	}
+	if query != "verify=true" {
+		t.Errorf("query = %q, want verify=true", query)
+	}
+}
+
+func TestDeleteLink(t *testing.T) {
+	link := models.Link{ID: uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000001"), URL: "https://example.com/old"}
+	other := models.Link{ID: uuid.MustParse("7b5d0e2f-0000-4000-8000-000000000002"), URL: "https://example.org"}
+	linkPath := "/api/v1/links/" + link.ID.String()
+
+	tests := []struct {
+		name        string
+		id          string
+		skipConfirm bool
+		stdin       string
+		wantDeleted bool
+	}{
+		{"prefix with --yes", "3f2a", true, "", true},
+		{"confirmed", link.ID.String(), false, "y\n", true},
+		{"declined", link.ID.String(), false, "n\n", false},
+		{"empty answer is no", link.ID.String(), false, "\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, app := newTestAPI(t)
+			app.stdin = strings.NewReader(tt.stdin)
+			api.Handle("GET /api/v1/links", respondJSON(http.StatusOK, []models.Link{link, other}))
+			api.Handle("GET /api/v1/links/{id}", respondJSON(http.StatusOK, link))
+			api.HandleFunc("DELETE /api/v1/links/{id}", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			var err error
+			out := captureStdout(t, func() { err = app.DeleteLink(tt.id, tt.skipConfirm) })
+			if err != nil {
+				t.Fatalf("DeleteLink: %v", err)
+			}
+
+			calls := api.Calls()
+			deleted := slices.Contains(calls, "DELETE "+linkPath)
+			if deleted != tt.wantDeleted {
+				t.Errorf("deleted = %v, want %v (calls %q)", deleted, tt.wantDeleted, calls)
+			}
+			prompted := strings.Contains(out, "[y/N]")
+			if prompted == tt.skipConfirm {
+				t.Errorf("prompted = %v with skipConfirm %v:\n%s", prompted, tt.skipConfirm, out)
+			}
+			if tt.wantDeleted && !strings.Contains(out, "Deleted "+link.URL) {
+				t.Errorf("output doesn't confirm the delete:\n%s", out)
+			}
+			if !tt.wantDeleted && !strings.Contains(out, "Cancelled") {
+				t.Errorf("output doesn't say the delete was cancelled:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestDeleteLinkNotFound(t *testing.T) {
+	api, app := newTestAPI(t)
+	api.Handle("GET /api/v1/links/{id}", respondJSON(http.StatusNotFound, map[string]string{"error": "link not found"}))
+	id := uuid.New()
+
+	err := app.DeleteLink(id.String(), true)
+	if err == nil || !strings.Contains(err.Error(), "no link found with ID "+id.String()) {
+		t.Errorf("DeleteLink error = %v, want a not-found message", err)
+	}
+	assertCalls(t, api, "GET /api/v1/links/"+id.String())
+}
+
+func TestDeleteLinkUnknownPrefix(t *testing.T) {
+	api, app := newTestAPI(t)
+	api.Handle("GET /api/v1/links", respondJSON(http.StatusOK, []models.Link{{ID: uuid.New(), URL: "https://example.com"}}))
+
+	if err := app.DeleteLink("zzzz", true); err == nil {
+		t.Error("DeleteLink with an unknown prefix succeeded, want an error")
+	}
+	assertCalls(t, api, "GET /api/v1/links")
+}
+
+func TestConfirmDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		stdin      string
+		want       bool
+		wantPrompt string
+	}{
+		{"at the threshold takes y", 3, "y\n", true, "[y/N]"},
+		{"above the threshold takes the count", 4, "4\n", true, "Type 4 to confirm"},
+		{"above the threshold rejects y", 4, "y\n", false, "Type 4 to confirm"},
+		{"above the threshold rejects a wrong count", 4, "3\n", false, "Type 4 to confirm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, app := newTestAPI(t)
+			app.cfg.CLI.BulkConfirmThreshold = 3
+			app.stdin = strings.NewReader(tt.stdin)
+
+			var got bool
+			var err error
+			out := captureStdout(t, func() { got, err = app.confirmDelete("Delete links?", tt.count) })
+			if err != nil {
+				t.Fatalf("confirmDelete: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("confirmDelete(%d) answered %q = %v, want %v", tt.count, tt.stdin, got, tt.want)
+			}
+			if !strings.Contains(out, tt.wantPrompt) {
+				t.Errorf("prompt doesn't contain %q:\n%s", tt.wantPrompt, out)
+			}
+		})
+	}
+}
+
+func TestNewRootModel(t *testing.T) {
+	_, app := newTestAPI(t)
+
+	model, err := app.newRootModel()
+	if err != nil {
+		t.Fatalf("newRootModel: %v", err)
+	}
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if view := model.View(); !strings.Contains(view, "Manage links") {
+		t.Errorf("root model doesn't show the menu:\n%s", view)
+	}
+
+	app.cfg.CLI.BaseURL = ""
+	app.client = nil
+	if _, err := app.newRootModel(); err == nil {
+		t.Error("newRootModel succeeded without a base URL")
+	}
+	// Run reports the same error before starting a terminal program
+	if err := app.Run(); err == nil || !strings.Contains(err.Error(), "base URL not configured") {
+		t.Errorf("Run error = %v, want the missing base URL", err)
+	}
+}
+
+func TestRunOffersRegistration(t *testing.T) {
+	t.Run("empty answer quits", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		app.cfg.CLI.APIKey = ""
+		app.stdin = strings.NewReader("\n")
+
+		var err error
+		out := captureStdout(t, func() { err = app.Run() })
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if !strings.Contains(out, "No API key configured") || !strings.Contains(out, "cli.api_key") {
+			t.Errorf("output doesn't offer registration or the alternative:\n%s", out)
+		}
+		assertCalls(t, api)
+	})
+
+	t.Run("email registers", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		api, app := newTestAPI(t)
+		app.cfg.CLI.APIKey = ""
+		app.stdin = strings.NewReader("new@example.com\n")
+		api.Handle("POST /api/v1/users", respondJSON(http.StatusCreated, map[string]interface{}{
+			"id":      "3f2a9c1e-0000-4000-8000-000000000001",
+			"email":   "new@example.com",
+			"api_key": "new-api-key",
+		}))
+
+		var registered bool
+		var err error
+		captureStdout(t, func() { registered, err = app.offerRegistration() })
+		if err != nil || !registered {
+			t.Fatalf("offerRegistration = %v, %v; want registered", registered, err)
+		}
+		if app.cfg.CLI.APIKey != "new-api-key" {
+			t.Errorf("configured API key = %q, want the new key", app.cfg.CLI.APIKey)
+		}
+		assertCalls(t, api, "POST /api/v1/users")
+	})
+}
+
+func TestListLinksFields(t *testing.T) {
+	link := models.Link{ID: uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000001"), URL: "https://example.com/article", VisitCount: 3}
+
+	t.Run("selected columns", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		api.Handle("GET /api/v1/links", respondJSON(http.StatusOK, []models.Link{link}))
+
+		var err error
+		out := captureStdout(t, func() { err = app.ListLinks(models.LinkFilter{}, "url,visits", "") })
+		if err != nil {
+			t.Fatalf("ListLinks: %v", err)
+		}
+		if !strings.Contains(out, "URL") || !strings.Contains(out, "Visits") || strings.Contains(out, "Created") {
+			t.Errorf("table doesn't show just the URL and visits columns:\n%s", out)
+		}
+	})
+
+	t.Run("invalid field is rejected before calling the API", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		err := app.ListLinks(models.LinkFilter{}, "url,tags", "")
+		if err == nil || !strings.Contains(err.Error(), `invalid field: "tags"`) {
+			t.Errorf("ListLinks error = %v, want the invalid field named", err)
+		}
+		assertCalls(t, api)
+	})
+}
+
+func TestListLinksGroupByDomain(t *testing.T) {
+	t.Run("groups under each domain", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		api.Handle("GET /api/v1/links", respondJSON(http.StatusOK, []models.Link{
+			{ID: uuid.New(), URL: "https://go.dev/doc"},
+			{ID: uuid.New(), URL: "https://example.com/a"},
+			{ID: uuid.New(), URL: "https://www.example.com:8443/b"},
+		}))
+
+		var err error
+		out := captureStdout(t, func() { err = app.ListLinks(models.LinkFilter{}, "", links.GroupByDomain) })
+		if err != nil {
+			t.Fatalf("ListLinks: %v", err)
+		}
+		example, golang := strings.Index(out, "example.com (2)"), strings.Index(out, "go.dev (1)")
+		if example < 0 || golang < example {
+			t.Errorf("output doesn't list example.com (2) before go.dev (1):\n%s", out)
+		}
+	})
+
+	t.Run("invalid group-by is rejected before calling the API", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		err := app.ListLinks(models.LinkFilter{}, "", "tag")
+		if err == nil || !strings.Contains(err.Error(), `invalid group-by "tag"`) {
+			t.Errorf("ListLinks error = %v, want the invalid group-by named", err)
+		}
+		assertCalls(t, api)
+	})
+}
@@ -0,0 +1,42 @@
+package browser
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Launcher opens a URL in an external application
+type Launcher interface {
+	Open(url string) error
+}
+
+// System opens URLs with the operating system's default browser
+type System struct{}
+
+// Open starts the platform URL handler (xdg-open, open, or start) without
+// waiting for the browser to exit
+func (System) Open(url string) error {
+	cmd := command(runtime.GOOS, url)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	// Reap the launcher process in the background
+	go cmd.Wait()
+	return nil
+}
+
+// command builds the launcher command for the given GOOS
+func command(goos, url string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		// cmd treats & as a command separator, so escape it; the empty
+		// argument is the window title expected by start
+		return exec.Command("cmd", "/c", "start", "", strings.ReplaceAll(url, "&", "^&"))
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
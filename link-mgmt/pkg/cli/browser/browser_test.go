@@ -0,0 +1,27 @@
+package browser
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	url := "https://example.com/?a=1&b=2"
+	tests := []struct {
+		goos string
+		want []string
+	}{
+		{"linux", []string{"xdg-open", url}},
+		{"freebsd", []string{"xdg-open", url}},
+		{"darwin", []string{"open", url}},
+		{"windows", []string{"cmd", "/c", "start", "", "https://example.com/?a=1^&b=2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			if got := command(tt.goos, url).Args; !slices.Equal(got, tt.want) {
+				t.Errorf("command(%q) args = %q, want %q", tt.goos, got, tt.want)
+			}
+		})
+	}
+}
@@ -13,8 +13,45 @@ import (
 // Client is an HTTP client for interacting with the link management API
 type Client struct {
 	baseURL    string
+	pathPrefix string // prepended to every request path; empty or "/..." without a trailing slash
 	apiKey     string
 	httpClient *http.Client
+	etags      *etagCache // GET responses to revalidate with If-None-Match
+}
+
+// APIError is returned when the API responds with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// UserMessage returns a friendly message for the status code
+func (e *APIError) UserMessage() string {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return fmt.Sprintf("Invalid request: %s", e.Message)
+	case http.StatusUnauthorized:
+		return fmt.Sprintf("Unauthorized: %s. Check cli.api_key or register with --register <email>.", e.Message)
+	case http.StatusForbidden:
+		return fmt.Sprintf("Access denied: %s.", e.Message)
+	case http.StatusNotFound:
+		return "Not found. It may have been deleted."
+	case http.StatusConflict:
+		return fmt.Sprintf("Conflict: %s", e.Message)
+	case http.StatusTooManyRequests:
+		return "Rate limit exceeded. Please wait before trying again."
+	case http.StatusGatewayTimeout:
+		return "The server timed out. Please try again."
+	default:
+		if e.StatusCode >= 500 {
+			return fmt.Sprintf("Server error (%d). Please try again later.", e.StatusCode)
+		}
+		return e.Message
+	}
 }
 
 // NewClient creates a new API client
@@ -28,12 +65,30 @@ func NewClient(baseURL, apiKey string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		etags: newETagCache(),
+	}
+}
+
+// SetPathPrefix mounts every request path under prefix, for an API served
+// from a subpath behind a reverse proxy (e.g. "/link-mgmt" requests
+// /link-mgmt/api/v1/links). An empty prefix requests paths as-is.
+func (c *Client) SetPathPrefix(prefix string) {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix != "" {
+		prefix = "/" + prefix
 	}
+	c.pathPrefix = prefix
+}
+
+// CheckReady calls the API readiness endpoint, which fails when the API is
+// up but its database isn't
+func (c *Client) CheckReady() error {
+	return c.doGetRequest("/health/ready", nil)
 }
 
 // buildRequest creates an HTTP request with proper headers
 func (c *Client) buildRequest(method, path string, body io.Reader) (*http.Request, error) {
-	url := fmt.Sprintf("%s%s", c.baseURL, path)
+	url := fmt.Sprintf("%s%s%s", c.baseURL, c.pathPrefix, path)
 
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
@@ -52,42 +107,98 @@ func (c *Client) buildRequest(method, path string, body io.Reader) (*http.Reques
 
 // doRequest performs an HTTP request and handles the response
 func (c *Client) doRequest(req *http.Request, result interface{}) error {
+	_, err := c.doRequestWithHeader(req, result)
+	return err
+}
+
+// doRequestWithHeader is doRequest that also returns the response headers.
+// A GET answered with an ETag before is sent with If-None-Match, and a 304
+// Not Modified reuses the body cached from that answer.
+func (c *Client) doRequestWithHeader(req *http.Request, result interface{}) (http.Header, error) {
+	cacheKey := ""
+	var cached etagEntry
+	var hasCached bool
+	if req.Method == http.MethodGet {
+		cacheKey = req.URL.String()
+		if cached, hasCached = c.etags.get(cacheKey); hasCached {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && hasCached:
+		resp.StatusCode = http.StatusOK
+		body = cached.body
+	case cacheKey != "" && resp.StatusCode == http.StatusOK:
+		c.etags.put(cacheKey, resp.Header.Get("ETag"), body)
 	}
 
 	// Check for HTTP errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errorResp struct {
-			Error string `json:"error"`
-		}
-		if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, errorResp.Error)
-		}
-		// If JSON parsing failed, return the raw body
-		errorMsg := string(body)
-		if errorMsg == "" {
-			errorMsg = resp.Status
-		}
-		return fmt.Errorf("API error (%d): %s", resp.StatusCode, errorMsg)
+		return nil, newAPIError(resp, body)
 	}
 
 	// Parse JSON response if result is provided
 	if result != nil {
 		if err := json.Unmarshal(body, result); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
+			return nil, newDecodeError(resp, body, err)
 		}
 	}
 
-	return nil
+	return resp.Header, nil
+}
+
+// maxSnippetLength bounds how much of an undecodable response body is quoted
+// in an error
+const maxSnippetLength = 200
+
+// newDecodeError describes a successful response whose body isn't the
+// expected JSON, quoting its content type and the start of the body so that,
+// say, a proxy's HTML login page is recognizable
+func newDecodeError(resp *http.Response, body []byte, cause error) error {
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "no content type"
+	}
+	return fmt.Errorf("failed to parse response (%s): %w; body: %q", contentType, cause, bodySnippet(body))
+}
+
+// bodySnippet collapses whitespace in body and cuts it to maxSnippetLength
+// characters
+func bodySnippet(body []byte) string {
+	text := strings.Join(strings.Fields(strings.ToValidUTF8(string(body), "")), " ")
+	if runes := []rune(text); len(runes) > maxSnippetLength {
+		return string(runes[:maxSnippetLength]) + "..."
+	}
+	return text
+}
+
+// newAPIError builds an APIError from an error response body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	var errorResp struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
+		return &APIError{StatusCode: resp.StatusCode, Message: errorResp.Error}
+	}
+	// If JSON parsing failed, return the raw body
+	errorMsg := string(body)
+	if errorMsg == "" {
+		errorMsg = resp.Status
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: errorMsg}
 }
 
 // doJSONRequest performs a JSON request (POST, PUT, PATCH)
@@ -119,6 +230,16 @@ func (c *Client) doGetRequest(path string, result interface{}) error {
 	return c.doRequest(req, result)
 }
 
+// doGetRequestWithHeader performs a GET request and returns the response headers
+func (c *Client) doGetRequestWithHeader(path string, result interface{}) (http.Header, error) {
+	req, err := c.buildRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doRequestWithHeader(req, result)
+}
+
 // doDeleteRequest performs a DELETE request
 func (c *Client) doDeleteRequest(path string) error {
 	req, err := c.buildRequest(http.MethodDelete, path, nil)
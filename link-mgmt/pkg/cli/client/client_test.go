@@ -0,0 +1,192 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+// testAPIKey is the key test clients authenticate with
+const testAPIKey = "test-key"
+
+// newTestClient returns a client talking to a test server that answers every
+// request with handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, testAPIKey)
+}
+
+// respondJSON returns a handler answering with status and a JSON body
+func respondJSON(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+func TestAPIErrorStatus(t *testing.T) {
+	id := uuid.New()
+	calls := []struct {
+		name string
+		call func(c *Client) error
+	}{
+		{"get", func(c *Client) error { _, err := c.GetLink(id); return err }},
+		{"create", func(c *Client) error {
+			_, err := c.CreateLink(models.LinkCreate{URL: "https://example.com"}, false)
+			return err
+		}},
+		{"delete", func(c *Client) error { return c.DeleteLink(id) }},
+	}
+	statuses := []int{
+		http.StatusBadRequest,
+		http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusNotFound,
+		http.StatusConflict,
+		http.StatusInternalServerError,
+	}
+
+	for _, call := range calls {
+		for _, status := range statuses {
+			t.Run(fmt.Sprintf("%s %d", call.name, status), func(t *testing.T) {
+				c := newTestClient(t, respondJSON(status, `{"error": "something went wrong"}`))
+
+				err := call.call(c)
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("err = %v, want an *APIError", err)
+				}
+				if apiErr.StatusCode != status || apiErr.Message != "something went wrong" {
+					t.Errorf("APIError = %d %q, want %d %q", apiErr.StatusCode, apiErr.Message, status, "something went wrong")
+				}
+			})
+		}
+	}
+}
+
+func TestAPIErrorUserMessage(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusBadRequest, "Invalid request: bad"},
+		{http.StatusUnauthorized, "Unauthorized: bad"},
+		{http.StatusForbidden, "Access denied: bad."},
+		{http.StatusNotFound, "Not found."},
+		{http.StatusConflict, "Conflict: bad"},
+		{http.StatusTooManyRequests, "Rate limit exceeded."},
+		{http.StatusGatewayTimeout, "The server timed out."},
+		{http.StatusBadGateway, "Server error (502)."},
+		{http.StatusTeapot, "bad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			err := &APIError{StatusCode: tt.status, Message: "bad"}
+			if got := err.UserMessage(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("UserMessage() = %q, want it to start with %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathPrefix(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"", "/api/v1/users/me"},
+		{"/link-mgmt", "/link-mgmt/api/v1/users/me"},
+		{"link-mgmt", "/link-mgmt/api/v1/users/me"},
+		{"/link-mgmt/", "/link-mgmt/api/v1/users/me"},
+		{" /apps/links/ ", "/apps/links/api/v1/users/me"},
+		{"/", "/api/v1/users/me"},
+	}
+	for _, tt := range tests {
+		var got string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			got = r.URL.Path
+			respondJSON(http.StatusOK, `{"id": "3f2a9c1e-0000-4000-8000-000000000001", "email": "me@example.com"}`)(w, r)
+		})
+		c.SetPathPrefix(tt.prefix)
+
+		if _, err := c.GetCurrentUser(); err != nil {
+			t.Fatalf("prefix %q: GetCurrentUser: %v", tt.prefix, err)
+		}
+		if got != tt.want {
+			t.Errorf("prefix %q: requested %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeErrorQuotesResponse(t *testing.T) {
+	loginPage := `<!DOCTYPE html>
+<html>
+  <head><title>Sign in</title></head>
+  <body>Please sign in to continue</body>
+</html>`
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantIn      []string
+	}{
+		{"HTML login page", "text/html; charset=utf-8", loginPage, []string{"text/html; charset=utf-8", "<title>Sign in</title>"}},
+		{"no content type", "", "not json", []string{"no content type", `"not json"`}},
+		{"long body", "text/plain", strings.Repeat("x", 500), []string{"text/plain", strings.Repeat("x", maxSnippetLength) + "..."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header()["Content-Type"] = nil // keep net/http from sniffing one
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := c.GetCurrentUser()
+			if err == nil {
+				t.Fatal("GetCurrentUser succeeded, want a decode error")
+			}
+			for _, want := range tt.wantIn {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't contain %q", err, want)
+				}
+			}
+			if strings.Contains(err.Error(), strings.Repeat("x", maxSnippetLength+1)) {
+				t.Errorf("error quotes more than %d characters of the body", maxSnippetLength)
+			}
+		})
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"  {\n\t\"a\": 1 }  ", `{ "a": 1 }`},
+		{strings.Repeat("é", maxSnippetLength), strings.Repeat("é", maxSnippetLength)},
+		{strings.Repeat("é", maxSnippetLength+1), strings.Repeat("é", maxSnippetLength) + "..."},
+		{"bad \xff bytes", "bad bytes"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := bodySnippet([]byte(tt.body)); got != tt.want {
+			t.Errorf("bodySnippet(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"sync"
+)
+
+// maxCachedResponses bounds the ETag cache; when it is full, an arbitrary
+// entry makes room for the new one
+const maxCachedResponses = 100
+
+// etagCache keeps the last body and ETag of GET responses by URL, so a
+// repeated request can send If-None-Match and reuse the body on 304
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]etagEntry)}
+}
+
+// get returns the cached response for url, if any
+func (c *etagCache) get(url string) (etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	return entry, ok
+}
+
+// put stores the response for url; one without an ETag drops any entry
+func (c *etagCache) put(url, etag string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if etag == "" {
+		delete(c.entries, url)
+		return
+	}
+	if _, ok := c.entries[url]; !ok && len(c.entries) >= maxCachedResponses {
+		for key := range c.entries {
+			delete(c.entries, key)
+			break
+		}
+	}
+	c.entries[url] = etagEntry{etag: etag, body: body}
+}
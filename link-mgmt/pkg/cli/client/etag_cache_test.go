@@ -0,0 +1,72 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestETagRevalidation(t *testing.T) {
+	id := uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000001")
+	var title atomic.Value
+	title.Store("First")
+	var sent []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get("If-None-Match"))
+		etag := `"` + title.Load().(string) + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		respondJSON(http.StatusOK, fmt.Sprintf(`{"id": "%s", "url": "https://example.com", "title": %q}`, id, title.Load()))(w, r)
+	})
+
+	wantTitles := []string{"First", "First", "Second"}
+	for i, want := range wantTitles {
+		if i == 2 {
+			title.Store("Second")
+		}
+		link, err := c.GetLink(id)
+		if err != nil {
+			t.Fatalf("GetLink %d: %v", i+1, err)
+		}
+		if link.Title == nil || *link.Title != want {
+			t.Errorf("GetLink %d: title %v, want %q", i+1, link.Title, want)
+		}
+	}
+
+	// The first request has nothing to revalidate; the next send the ETag last seen
+	wantSent := []string{"", `"First"`, `"First"`}
+	if !slices.Equal(sent, wantSent) {
+		t.Errorf("If-None-Match sent %q, want %q", sent, wantSent)
+	}
+}
+
+func TestETagCache(t *testing.T) {
+	cache := newETagCache()
+	cache.put("/a", `"1"`, []byte("a"))
+	if entry, ok := cache.get("/a"); !ok || entry.etag != `"1"` || string(entry.body) != "a" {
+		t.Errorf("get(/a) = %+v, %v; want the stored entry", entry, ok)
+	}
+
+	// A response without an ETag drops the entry
+	cache.put("/a", "", []byte("a2"))
+	if _, ok := cache.get("/a"); ok {
+		t.Error("entry kept after a response without an ETag")
+	}
+
+	for i := range maxCachedResponses + 10 {
+		cache.put(fmt.Sprintf("/%d", i), `"x"`, nil)
+	}
+	if len(cache.entries) != maxCachedResponses {
+		t.Errorf("cache holds %d entries, want at most %d", len(cache.entries), maxCachedResponses)
+	}
+	if _, ok := cache.get(fmt.Sprintf("/%d", maxCachedResponses+9)); !ok {
+		t.Error("the newest entry was evicted")
+	}
+}
@@ -1,8 +1,13 @@
 package client
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"link-mgmt/pkg/models"
 
@@ -11,8 +16,99 @@ import (
 
 // ListLinks retrieves all links for the authenticated user
 func (c *Client) ListLinks() ([]models.Link, error) {
+	return c.ListLinksWithFilter(models.LinkFilter{})
+}
+
+// ListLinksWithFilter retrieves the authenticated user's links matching the filter
+func (c *Client) ListLinksWithFilter(filter models.LinkFilter) ([]models.Link, error) {
 	var links []models.Link
-	if err := c.doGetRequest("/api/v1/links", &links); err != nil {
+	if err := c.doGetRequest(linksPath(filter, models.ListOptions{}), &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// LinkPage is one page of a paged link listing
+type LinkPage struct {
+	Links  []models.Link
+	Total  int64 // links matching the filter across all pages (X-Total-Count)
+	Limit  int
+	Offset int
+}
+
+// HasNext reports whether more links follow this page
+func (p *LinkPage) HasNext() bool {
+	return p.Limit > 0 && int64(p.Offset+len(p.Links)) < p.Total
+}
+
+// ListLinksPage retrieves one page of the authenticated user's links matching
+// the filter, ordered and paged by opts
+func (c *Client) ListLinksPage(filter models.LinkFilter, opts models.ListOptions) (*LinkPage, error) {
+	page := &LinkPage{Limit: opts.Limit, Offset: opts.Offset}
+	header, err := c.doGetRequestWithHeader(linksPath(filter, opts), &page.Links)
+	if err != nil {
+		return nil, err
+	}
+
+	page.Total = int64(len(page.Links))
+	if total := header.Get("X-Total-Count"); total != "" {
+		if page.Total, err = strconv.ParseInt(total, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid X-Total-Count header %q: %w", total, err)
+		}
+	}
+	return page, nil
+}
+
+// linksPath builds the list links path with query parameters for the filter and options
+func linksPath(filter models.LinkFilter, opts models.ListOptions) string {
+	query := url.Values{}
+	if filter.FavoritesOnly {
+		query.Set("favorites", "true")
+	}
+	if filter.UntitledOnly {
+		query.Set("untitled", "true")
+	}
+	if filter.UnreadOnly {
+		query.Set("unread", "true")
+	}
+	if filter.CreatedAfter != nil {
+		query.Set("created_after", filter.CreatedAfter.Format(models.LinkFilterDateLayout))
+	}
+	if filter.CreatedBefore != nil {
+		query.Set("created_before", filter.CreatedBefore.Format(models.LinkFilterDateLayout))
+	}
+	if filter.StaleDays != nil {
+		query.Set("stale_days", strconv.Itoa(*filter.StaleDays))
+	}
+	if filter.Search != "" {
+		query.Set("q", filter.Search)
+	}
+	if opts.SortBy != "" {
+		query.Set("sort", opts.SortBy)
+	}
+	if opts.Order != "" {
+		query.Set("order", opts.Order)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		query.Set("offset", strconv.Itoa(opts.Offset))
+	}
+
+	path := "/api/v1/links"
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	return path
+}
+
+// GetRecentLinks retrieves the authenticated user's links created in the
+// last days days, newest first
+func (c *Client) GetRecentLinks(days int) ([]models.Link, error) {
+	var links []models.Link
+	path := fmt.Sprintf("/api/v1/links/recent?days=%d", days)
+	if err := c.doGetRequest(path, &links); err != nil {
 		return nil, err
 	}
 	return links, nil
@@ -28,13 +124,69 @@ func (c *Client) GetLink(id uuid.UUID) (*models.Link, error) {
 	return &link, nil
 }
 
-// CreateLink creates a new link
-func (c *Client) CreateLink(link models.LinkCreate) (*models.Link, error) {
+// GetSimilar retrieves up to limit of the user's other links that share terms
+// with a link, most similar first; limit <= 0 uses the server default
+func (c *Client) GetSimilar(id uuid.UUID, limit int) ([]models.Link, error) {
+	var links []models.Link
+	path := fmt.Sprintf("/api/v1/links/%s/similar", id.String())
+	if limit > 0 {
+		path += fmt.Sprintf("?limit=%d", limit)
+	}
+	if err := c.doGetRequest(path, &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// CreateLink creates a new link. The request carries a fresh Idempotency-Key
+// and is retried once with the same key if no response arrives, so a create
+// that reached the server before the connection failed isn't made twice.
+// With verify set, the API first checks that the URL is reachable.
+func (c *Client) CreateLink(link models.LinkCreate, verify bool) (*models.Link, error) {
+	jsonData, err := json.Marshal(link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	key := uuid.NewString()
+
 	var created models.Link
-	if err := c.doJSONRequest(http.MethodPost, "/api/v1/links", link, &created); err != nil {
+	for attempt := 1; ; attempt++ {
+		req, err := c.buildRequest(http.MethodPost, verifyPath("/api/v1/links", verify), bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Idempotency-Key", key)
+
+		err = c.doRequest(req, &created)
+		if err == nil {
+			return &created, nil
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) || attempt == 2 {
+			return nil, err
+		}
+	}
+}
+
+// verifyPath adds ?verify=true to a create path when verify is set, asking
+// the API to check that the URL is reachable before saving it
+func verifyPath(path string, verify bool) string {
+	if !verify {
+		return path
+	}
+	return path + "?verify=true"
+}
+
+// CreateLinks creates many links in one request. The results are in input
+// order; items that failed (e.g. duplicate URLs) carry an Error instead of a Link.
+func (c *Client) CreateLinks(links []models.LinkCreate) ([]models.LinkBatchResult, error) {
+	var response struct {
+		Results []models.LinkBatchResult `json:"results"`
+	}
+	if err := c.doJSONRequest(http.MethodPost, "/api/v1/links/batch", links, &response); err != nil {
 		return nil, err
 	}
-	return &created, nil
+	return response.Results, nil
 }
 
 // UpdateLink updates an existing link
@@ -53,12 +205,88 @@ func (c *Client) DeleteLink(id uuid.UUID) error {
 	return c.doDeleteRequest(path)
 }
 
-// CreateLinkWithScraping creates a link and enriches it with scraped content
+// ToggleFavorite flips the favorite flag on a link and returns the updated link
+func (c *Client) ToggleFavorite(id uuid.UUID) (*models.Link, error) {
+	var link models.Link
+	path := fmt.Sprintf("/api/v1/links/%s/favorite", id.String())
+	if err := c.doJSONRequest(http.MethodPost, path, nil, &link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// RecordVisit counts an opening of a link and returns its new visit count
+func (c *Client) RecordVisit(id uuid.UUID) (int, error) {
+	var result struct {
+		VisitCount int `json:"visit_count"`
+	}
+	path := fmt.Sprintf("/api/v1/links/%s/visit", id.String())
+	if err := c.doJSONRequest(http.MethodPost, path, nil, &result); err != nil {
+		return 0, err
+	}
+	return result.VisitCount, nil
+}
+
+// MarkRead marks a link read or unread and returns the updated link
+func (c *Client) MarkRead(id uuid.UUID, read bool) (*models.Link, error) {
+	payload := struct {
+		Read bool `json:"read"`
+	}{Read: read}
+
+	var link models.Link
+	path := fmt.Sprintf("/api/v1/links/%s/read", id.String())
+	if err := c.doJSONRequest(http.MethodPost, path, payload, &link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// DeleteLinks deletes multiple links by ID and returns the number deleted
+func (c *Client) DeleteLinks(ids []uuid.UUID) (int64, error) {
+	var result struct {
+		Deleted int64 `json:"deleted"`
+	}
+	if err := c.doJSONRequest(http.MethodDelete, "/api/v1/links", ids, &result); err != nil {
+		return 0, err
+	}
+	return result.Deleted, nil
+}
+
+// TransferLinks moves links to the account that targetAPIKey belongs to and
+// returns the number moved
+func (c *Client) TransferLinks(ids []uuid.UUID, targetAPIKey string) (int64, error) {
+	payload := struct {
+		IDs          []uuid.UUID `json:"ids"`
+		TargetAPIKey string      `json:"target_api_key"`
+	}{IDs: ids, TargetAPIKey: targetAPIKey}
+
+	var result struct {
+		Transferred int64 `json:"transferred"`
+	}
+	if err := c.doJSONRequest(http.MethodPost, "/api/v1/links/transfer", payload, &result); err != nil {
+		return 0, err
+	}
+	return result.Transferred, nil
+}
+
+// ReorderLinks sets the manual link ordering used by sort=position. The given
+// links come first, in order; the user's other links follow them.
+func (c *Client) ReorderLinks(ids []uuid.UUID) error {
+	payload := struct {
+		IDs []uuid.UUID `json:"ids"`
+	}{IDs: ids}
+
+	return c.doJSONRequest(http.MethodPut, "/api/v1/links/reorder", payload, nil)
+}
+
+// CreateLinkWithScraping creates a link and enriches it with scraped content.
+// With verify set, the API first checks that the URL is reachable.
 func (c *Client) CreateLinkWithScraping(
 	linkCreate models.LinkCreate,
 	scrapeEnabled bool,
 	scrapeTimeout int,
 	onlyFillEmpty bool,
+	verify bool,
 ) (*models.Link, error) {
 	var req struct {
 		models.LinkCreate
@@ -83,7 +311,7 @@ func (c *Client) CreateLinkWithScraping(
 	}
 
 	var link models.Link
-	err := c.doJSONRequest(http.MethodPost, "/api/v1/links/with-scraping", req, &link)
+	err := c.doJSONRequest(http.MethodPost, verifyPath("/api/v1/links/with-scraping", verify), req, &link)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+func TestGetLink(t *testing.T) {
+	id := uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000001")
+	var gotMethod, gotPath string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		respondJSON(http.StatusOK, `{
+			"id": "3f2a9c1e-0000-4000-8000-000000000001",
+			"url": "https://example.com/article",
+			"title": "Fresh Title",
+			"is_read": true
+		}`)(w, r)
+	})
+
+	link, err := c.GetLink(id)
+	if err != nil {
+		t.Fatalf("GetLink: %v", err)
+	}
+	if gotMethod != http.MethodGet || gotPath != "/api/v1/links/"+id.String() {
+		t.Errorf("request = %s %s, want GET /api/v1/links/%s", gotMethod, gotPath, id)
+	}
+	if link.ID != id || link.URL != "https://example.com/article" || link.Title == nil || *link.Title != "Fresh Title" || !link.IsRead {
+		t.Errorf("link = %+v, want the decoded response", link)
+	}
+}
+
+func TestGetLinkNotFound(t *testing.T) {
+	c := newTestClient(t, respondJSON(http.StatusNotFound, `{"error": "link not found"}`))
+
+	link, err := c.GetLink(uuid.New())
+	if link != nil {
+		t.Errorf("link = %+v, want nil", link)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("err = %v, want a 404 *APIError", err)
+	}
+}
+
+func TestCreateLinks(t *testing.T) {
+	var gotMethod, gotPath string
+	var sent []models.LinkCreate
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&sent)
+		respondJSON(http.StatusOK, `{"results": [
+			{"index": 0, "link": {"id": "3f2a9c1e-0000-4000-8000-000000000001", "url": "https://example.com/a"}},
+			{"index": 1, "error": "link with this URL already exists"}
+		]}`)(w, r)
+	})
+
+	results, err := c.CreateLinks([]models.LinkCreate{{URL: "https://example.com/a"}, {URL: "https://example.com/b"}})
+	if err != nil {
+		t.Fatalf("CreateLinks: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/api/v1/links/batch" {
+		t.Errorf("request = %s %s, want POST /api/v1/links/batch", gotMethod, gotPath)
+	}
+	if len(sent) != 2 || sent[1].URL != "https://example.com/b" {
+		t.Errorf("sent %+v, want both links", sent)
+	}
+	if len(results) != 2 || results[0].Link == nil || results[0].Link.URL != "https://example.com/a" ||
+		results[1].Link != nil || results[1].Error == "" {
+		t.Errorf("results = %+v, want one created link and one per-item error", results)
+	}
+}
+
+func TestLinksPathUntitled(t *testing.T) {
+	tests := []struct {
+		filter models.LinkFilter
+		want   string
+	}{
+		{models.LinkFilter{}, "/api/v1/links"},
+		{models.LinkFilter{UntitledOnly: true}, "/api/v1/links?untitled=true"},
+		{models.LinkFilter{UntitledOnly: true, FavoritesOnly: true, Search: "go"}, "/api/v1/links?favorites=true&q=go&untitled=true"},
+		{models.LinkFilter{UnreadOnly: true}, "/api/v1/links?unread=true"},
+	}
+	for _, tt := range tests {
+		if got := linksPath(tt.filter, models.ListOptions{}); got != tt.want {
+			t.Errorf("linksPath(%+v) = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestListLinksPage(t *testing.T) {
+	var gotQuery string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("X-Total-Count", "5")
+		respondJSON(http.StatusOK, `[{"id": "3f2a9c1e-0000-4000-8000-000000000001", "url": "https://example.com/2"},
+			{"id": "3f2a9c1e-0000-4000-8000-000000000002", "url": "https://example.com/3"}]`)(w, r)
+	})
+
+	page, err := c.ListLinksPage(models.LinkFilter{}, models.ListOptions{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("ListLinksPage: %v", err)
+	}
+	if gotQuery != "limit=2&offset=2" {
+		t.Errorf("query = %q, want limit=2&offset=2", gotQuery)
+	}
+	if len(page.Links) != 2 || page.Total != 5 || !page.HasNext() {
+		t.Errorf("page = %d links of %d, HasNext %v; want 2 of 5 with more to come", len(page.Links), page.Total, page.HasNext())
+	}
+	if last := (&LinkPage{Links: page.Links, Total: 5, Limit: 2, Offset: 3}); last.HasNext() {
+		t.Error("HasNext() = true on the last page")
+	}
+}
+
+func TestListLinksPageTotalHeader(t *testing.T) {
+	t.Run("missing header counts the page", func(t *testing.T) {
+		c := newTestClient(t, respondJSON(http.StatusOK, `[{"id": "3f2a9c1e-0000-4000-8000-000000000001", "url": "https://example.com"}]`))
+		page, err := c.ListLinksPage(models.LinkFilter{}, models.ListOptions{})
+		if err != nil || page.Total != 1 {
+			t.Errorf("ListLinksPage = %+v, %v; want a total of 1", page, err)
+		}
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Total-Count", "lots")
+			respondJSON(http.StatusOK, `[]`)(w, r)
+		})
+		if _, err := c.ListLinksPage(models.LinkFilter{}, models.ListOptions{Limit: 2}); err == nil {
+			t.Error("ListLinksPage with an invalid X-Total-Count succeeded, want an error")
+		}
+	})
+}
+
+func TestGetRecentLinks(t *testing.T) {
+	var gotRequest string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotRequest = r.Method + " " + r.URL.RequestURI()
+		respondJSON(http.StatusOK, `[{"id": "3f2a9c1e-0000-4000-8000-000000000001", "url": "https://example.com/new"}]`)(w, r)
+	})
+
+	links, err := c.GetRecentLinks(14)
+	if err != nil {
+		t.Fatalf("GetRecentLinks: %v", err)
+	}
+	if gotRequest != "GET /api/v1/links/recent?days=14" {
+		t.Errorf("request = %q, want GET /api/v1/links/recent?days=14", gotRequest)
+	}
+	if len(links) != 1 || links[0].URL != "https://example.com/new" {
+		t.Errorf("links = %+v, want the decoded response", links)
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	id := uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000002")
+	for _, read := range []bool{true, false} {
+		var gotMethod, gotPath string
+		var gotBody map[string]interface{}
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotMethod, gotPath = r.Method, r.URL.Path
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			respondJSON(http.StatusOK, fmt.Sprintf(`{"id": "%s", "url": "https://example.com", "is_read": %v}`, id, read))(w, r)
+		})
+
+		link, err := c.MarkRead(id, read)
+		if err != nil {
+			t.Fatalf("MarkRead(%v): %v", read, err)
+		}
+		if gotMethod != http.MethodPost || gotPath != "/api/v1/links/"+id.String()+"/read" {
+			t.Errorf("request = %s %s, want POST /api/v1/links/%s/read", gotMethod, gotPath, id)
+		}
+		// An explicit false must be sent, or the server would toggle instead
+		if got, ok := gotBody["read"]; !ok || got != read {
+			t.Errorf("MarkRead(%v) sent %v, want {\"read\": %v}", read, gotBody, read)
+		}
+		if link.IsRead != read {
+			t.Errorf("MarkRead(%v): IsRead = %v", read, link.IsRead)
+		}
+	}
+}
+
+func TestRecordVisit(t *testing.T) {
+	id := uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000002")
+	var gotMethod, gotPath string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		respondJSON(http.StatusOK, `{"visit_count": 4}`)(w, r)
+	})
+
+	count, err := c.RecordVisit(id)
+	if err != nil {
+		t.Fatalf("RecordVisit: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/api/v1/links/"+id.String()+"/visit" {
+		t.Errorf("request = %s %s, want POST /api/v1/links/%s/visit", gotMethod, gotPath, id)
+	}
+	if count != 4 {
+		t.Errorf("RecordVisit = %d, want 4", count)
+	}
+}
+
+func TestGetSimilar(t *testing.T) {
+	id := uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000003")
+	tests := []struct {
+		limit     int
+		wantQuery string
+	}{
+		{0, ""},
+		{5, "limit=5"},
+	}
+	for _, tt := range tests {
+		var gotPath, gotQuery string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+			respondJSON(http.StatusOK, `[{"id": "3f2a9c1e-0000-4000-8000-000000000004", "url": "https://example.com/related"}]`)(w, r)
+		})
+
+		links, err := c.GetSimilar(id, tt.limit)
+		if err != nil {
+			t.Fatalf("GetSimilar(%d): %v", tt.limit, err)
+		}
+		if gotPath != "/api/v1/links/"+id.String()+"/similar" || gotQuery != tt.wantQuery {
+			t.Errorf("GetSimilar(%d) requested %s?%s, want /api/v1/links/%s/similar?%s", tt.limit, gotPath, gotQuery, id, tt.wantQuery)
+		}
+		if len(links) != 1 || links[0].URL != "https://example.com/related" {
+			t.Errorf("GetSimilar(%d) = %+v, want the decoded links", tt.limit, links)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/scraper"
+
+	"github.com/google/uuid"
+)
+
+// EnrichLinkStream enriches a link like EnrichLink, but consumes the
+// Server-Sent Events stream so scrape stages are reported to onProgress
+// (may be nil) as the server works. The returned bool is false when the
+// server found the content unchanged since the last scrape.
+func (c *Client) EnrichLinkStream(
+	linkID uuid.UUID,
+	timeout int,
+	onlyFillEmpty bool,
+	onProgress scraper.ProgressCallback,
+) (*models.Link, bool, error) {
+	query := url.Values{}
+	query.Set("only_fill_empty", strconv.FormatBool(onlyFillEmpty))
+	if timeout > 0 {
+		query.Set("timeout", strconv.Itoa(timeout))
+	}
+	path := fmt.Sprintf("/api/v1/links/%s/enrich/stream?%s", linkID, query.Encode())
+
+	req, err := c.buildRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// The regular client timeout covers reading the whole body, which may
+	// legitimately take as long as the scrape; bound the stream by the scrape
+	// timeout plus the usual request allowance instead
+	streamTimeout := c.httpClient.Timeout + time.Duration(timeout)*time.Second
+	ctx, cancel := context.WithTimeout(req.Context(), streamTimeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read response: %w", err)
+		}
+		return nil, false, newAPIError(resp, body)
+	}
+
+	var link *models.Link
+	changed := true
+	err = readEvents(resp.Body, func(event, data string) (bool, error) {
+		switch event {
+		case "progress":
+			var progress struct {
+				Stage   scraper.ScrapeStage `json:"stage"`
+				Message string              `json:"message"`
+			}
+			if err := json.Unmarshal([]byte(data), &progress); err != nil {
+				return false, fmt.Errorf("failed to parse progress event: %w", err)
+			}
+			if onProgress != nil {
+				onProgress(progress.Stage, progress.Message)
+			}
+			return true, nil
+		case "unchanged":
+			changed = false
+			return true, nil
+		case "link":
+			link = &models.Link{}
+			if err := json.Unmarshal([]byte(data), link); err != nil {
+				return false, fmt.Errorf("failed to parse response: %w", err)
+			}
+			return false, nil
+		case "error":
+			var errorEvent struct {
+				Error  string `json:"error"`
+				Status int    `json:"status"`
+			}
+			if err := json.Unmarshal([]byte(data), &errorEvent); err != nil {
+				return false, fmt.Errorf("failed to parse error event: %w", err)
+			}
+			return false, &APIError{StatusCode: errorEvent.Status, Message: errorEvent.Error}
+		}
+		// Ignore unknown events for forward compatibility
+		return true, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if link == nil {
+		return nil, false, fmt.Errorf("enrich stream ended without a result")
+	}
+	return link, changed, nil
+}
+
+// readEvents parses a Server-Sent Events stream, calling handle for each
+// event until it returns false, an error, or the stream ends
+func readEvents(r io.Reader, handle func(event, data string) (bool, error)) error {
+	scanner := bufio.NewScanner(r)
+	// Link payloads can carry large scraped text
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	event := "message"
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// Blank line dispatches the buffered event
+			if len(data) > 0 {
+				more, err := handle(event, strings.Join(data, "\n"))
+				if err != nil || !more {
+					return err
+				}
+			}
+			event = "message"
+			data = nil
+		case strings.HasPrefix(line, ":"):
+			// Comment / keep-alive
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read event stream: %w", err)
+	}
+	return nil
+}
@@ -21,3 +21,45 @@ func (c *Client) CreateUser(email string) (*models.User, error) {
 	}
 	return &user, nil
 }
+
+// RotateAPIKeyRequest represents the request payload for rotating an API key
+type RotateAPIKeyRequest struct {
+	ExpiresInDays *int `json:"expires_in_days,omitempty"`
+}
+
+// RotateAPIKey replaces the client's API key and returns the user with the new key.
+// A nil expiresInDays uses the server's default expiry; 0 means never expire.
+func (c *Client) RotateAPIKey(expiresInDays *int) (*models.User, error) {
+	var user models.User
+	payload := RotateAPIKeyRequest{ExpiresInDays: expiresInDays}
+	if err := c.doJSONRequest(http.MethodPost, "/api/v1/users/me/rotate-key", payload, &user); err != nil {
+		return nil, fmt.Errorf("failed to rotate API key: %w", err)
+	}
+	return &user, nil
+}
+
+// CreateReadOnlyAPIKey creates an additional read-only API key for the client's user.
+// A nil expiresInDays uses the server's default expiry; 0 means never expire.
+func (c *Client) CreateReadOnlyAPIKey(expiresInDays *int) (*models.APIKey, error) {
+	var key models.APIKey
+	payload := RotateAPIKeyRequest{ExpiresInDays: expiresInDays}
+	if err := c.doJSONRequest(http.MethodPost, "/api/v1/users/me/keys", payload, &key); err != nil {
+		return nil, fmt.Errorf("failed to create read-only API key: %w", err)
+	}
+	return &key, nil
+}
+
+// GetCurrentUser retrieves the user that owns the client's API key
+func (c *Client) GetCurrentUser() (*models.User, error) {
+	var user models.User
+	if err := c.doGetRequest("/api/v1/users/me", &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// DeleteCurrentUser deletes the account that owns the client's API key,
+// including all of its links. The API key stops working afterwards.
+func (c *Client) DeleteCurrentUser() error {
+	return c.doDeleteRequest("/api/v1/users/me")
+}
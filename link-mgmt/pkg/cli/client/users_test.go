@@ -0,0 +1,47 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetCurrentUser(t *testing.T) {
+	var gotMethod, gotPath, gotAuth string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotAuth = r.Method, r.URL.Path, r.Header.Get("Authorization")
+		respondJSON(http.StatusOK, `{
+			"id": "3f2a9c1e-0000-4000-8000-000000000001",
+			"email": "me@example.com",
+			"api_key": "test-key",
+			"is_readonly": true
+		}`)(w, r)
+	})
+
+	user, err := c.GetCurrentUser()
+	if err != nil {
+		t.Fatalf("GetCurrentUser: %v", err)
+	}
+	if gotMethod != http.MethodGet || gotPath != "/api/v1/users/me" {
+		t.Errorf("request = %s %s, want GET /api/v1/users/me", gotMethod, gotPath)
+	}
+	if gotAuth != "Bearer "+testAPIKey {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer "+testAPIKey)
+	}
+	if user.Email != "me@example.com" || user.ID.String() != "3f2a9c1e-0000-4000-8000-000000000001" || !user.IsReadOnly {
+		t.Errorf("user = %+v, want the decoded response", user)
+	}
+}
+
+func TestGetCurrentUserUnauthorized(t *testing.T) {
+	c := newTestClient(t, respondJSON(http.StatusUnauthorized, `{"error": "invalid API key"}`))
+
+	_, err := c.GetCurrentUser()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "invalid API key" {
+		t.Errorf("APIError = %d %q, want 401 %q", apiErr.StatusCode, apiErr.Message, "invalid API key")
+	}
+}
@@ -0,0 +1,66 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned when no clipboard tool is installed
+var ErrUnavailable = errors.New("clipboard not available")
+
+// Writer copies text to the clipboard
+type Writer interface {
+	Write(text string) error
+}
+
+// System copies text with the operating system's clipboard tool
+type System struct{}
+
+// Write pipes text into the platform clipboard tool (pbcopy, clip, wl-copy,
+// xclip, or xsel). Returns an error wrapping ErrUnavailable if none is installed.
+func (System) Write(text string) error {
+	cmd, err := command(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "", exec.LookPath)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w (%s)", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// command builds the clipboard command for the given GOOS, using the first
+// tool that lookPath finds
+func command(goos string, wayland bool, lookPath func(string) (string, error)) (*exec.Cmd, error) {
+	var candidates [][]string
+	switch goos {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if wayland {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		candidates = append(candidates,
+			[]string{"xclip", "-selection", "clipboard"},
+			[]string{"xsel", "--clipboard", "--input"},
+		)
+	}
+
+	for _, candidate := range candidates {
+		if path, err := lookPath(candidate[0]); err == nil {
+			return exec.Command(path, candidate[1:]...), nil
+		}
+	}
+
+	if goos == "darwin" || goos == "windows" {
+		return nil, ErrUnavailable
+	}
+	return nil, fmt.Errorf("%w (install wl-clipboard, xclip, or xsel)", ErrUnavailable)
+}
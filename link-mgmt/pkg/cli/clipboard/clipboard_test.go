@@ -0,0 +1,56 @@
+package clipboard
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// installed returns a lookPath that finds only the given tools
+func installed(tools ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		if slices.Contains(tools, name) {
+			return "/usr/bin/" + name, nil
+		}
+		return "", exec.ErrNotFound
+	}
+}
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		goos    string
+		wayland bool
+		tools   []string
+		want    []string // nil: unavailable
+	}{
+		{"macOS", "darwin", false, []string{"pbcopy"}, []string{"pbcopy"}},
+		{"windows", "windows", false, []string{"clip"}, []string{"clip"}},
+		{"wayland prefers wl-copy", "linux", true, []string{"wl-copy", "xclip"}, []string{"wl-copy"}},
+		{"wayland falls back to X11 tools", "linux", true, []string{"xclip"}, []string{"xclip", "-selection", "clipboard"}},
+		{"X11 skips wl-copy", "linux", false, []string{"wl-copy", "xsel"}, []string{"xsel", "--clipboard", "--input"}},
+		{"xclip before xsel", "freebsd", false, []string{"xsel", "xclip"}, []string{"xclip", "-selection", "clipboard"}},
+		{"nothing installed", "linux", false, nil, nil},
+		{"macOS without pbcopy", "darwin", false, []string{"xclip"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := command(tt.goos, tt.wayland, installed(tt.tools...))
+			if tt.want == nil {
+				if !errors.Is(err, ErrUnavailable) {
+					t.Errorf("command() error = %v, want ErrUnavailable", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("command(): %v", err)
+			}
+			got := append([]string{filepath.Base(cmd.Path)}, cmd.Args[1:]...)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("command() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,32 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"link-mgmt/pkg/cli/logger"
+	"link-mgmt/pkg/cli/tui"
 	"link-mgmt/pkg/config"
+	"link-mgmt/pkg/scraper"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pelletier/go-toml/v2"
 )
 
-// ShowConfig displays the current configuration
-func (a *App) ShowConfig() {
-	data, err := toml.Marshal(a.cfg)
+// ShowConfig displays the current configuration. Unless showSecrets is set,
+// the API key and the database password are masked so the output is safe to share.
+func (a *App) ShowConfig(showSecrets bool) {
+	shown := *a.cfg
+	if !showSecrets {
+		shown.CLI.APIKey = maskAPIKey(shown.CLI.APIKey)
+		shown.Database.URL = redactDatabaseURL(shown.Database.URL)
+	}
+
+	data, err := toml.Marshal(&shown)
 	if err != nil {
 		fmt.Printf("Error marshaling config: %v\n", err)
 		return
@@ -19,63 +34,374 @@ func (a *App) ShowConfig() {
 	fmt.Println(string(data))
 }
 
+// dsnPassword matches the password in a key=value connection string
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+// redactDatabaseURL hides the password in a postgres:// URL or a key=value
+// connection string
+func redactDatabaseURL(dbURL string) string {
+	if u, err := url.Parse(dbURL); err == nil && u.Scheme != "" {
+		return u.Redacted()
+	}
+	return dsnPassword.ReplaceAllString(dbURL, "${1}xxxxx")
+}
+
+// InitConfig writes a commented default config file
+func (a *App) InitConfig(overwrite bool) error {
+	path, err := config.Init(overwrite)
+	if errors.Is(err, config.ErrConfigExists) {
+		return fmt.Errorf("%w (use --force to overwrite)", err)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Wrote default config to %s\n", path)
+	return nil
+}
+
+// splitConfigKey splits a "section.key" path
+func splitConfigKey(keyStr string) (section, key string, err error) {
+	keyPath := strings.Split(keyStr, ".")
+	if len(keyPath) != 2 {
+		return "", "", fmt.Errorf("invalid key format: expected 'section.key'")
+	}
+	return keyPath[0], keyPath[1], nil
+}
+
+// configKeys lists every key GetConfig and SetConfig accept, in the order
+// the config editor shows them
+var configKeys = []string{
+	"cli.base_url",
+	"cli.api_prefix",
+	"cli.api_key",
+	"cli.scrape_timeout",
+	"cli.preview_length",
+	"cli.url_display_width",
+	"cli.log_level",
+	"cli.log_sinks",
+	"cli.bulk_confirm_threshold",
+	"api.host",
+	"api.port",
+	"api.rate_limit_per_minute",
+	"api.log_format",
+	"api.key_expiry_days",
+	"api.max_text_length",
+	"database.url",
+	"database.query_timeout",
+	"database.max_conns",
+	"database.min_conns",
+	"database.max_conn_idle_time",
+	"scraper.base_url",
+	"scraper.adapter",
+	"scraper.cache_ttl",
+	"scraper.max_concurrent",
+	"scraper.user_agent",
+}
+
+// EditConfig opens the config editor on its own
+func (a *App) EditConfig() error {
+	_, err := tea.NewProgram(a.newConfigEditor(false)).Run()
+	return err
+}
+
+// newConfigEditor builds the TUI config editor over the current values
+func (a *App) newConfigEditor(fromMenu bool) tea.Model {
+	fields := make([]tui.ConfigField, 0, len(configKeys))
+	for _, key := range configKeys {
+		value, _ := a.GetConfig(key) // every key in configKeys is known to GetConfig
+		fields = append(fields, tui.ConfigField{Key: key, Value: value, Secret: key == "cli.api_key"})
+	}
+	return tui.NewConfigEditorModel(fields, a.saveConfigValues, fromMenu)
+}
+
+// saveConfigValues validates the changed values with SetConfig's rules and
+// saves them together: if any is invalid, nothing is saved
+func (a *App) saveConfigValues(values map[string]string) error {
+	if err := config.EnsureExists(); err != nil {
+		return err
+	}
+
+	// Edit a copy so a rejected value leaves the loaded config untouched
+	updated := *a.cfg
+	for _, key := range configKeys {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		if current, _ := a.GetConfig(key); value == current {
+			continue
+		}
+		if err := setConfigValue(&updated, key, value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if err := config.Save(&updated); err != nil {
+		return err
+	}
+	*a.cfg = updated
+	return nil
+}
+
+// GetConfig returns a single configuration value as plain text, for scripts.
+// Format: section.key (e.g., "cli.base_url"); the keys are those SetConfig
+// accepts, and lists are comma-separated as SetConfig takes them.
+func (a *App) GetConfig(keyStr string) (string, error) {
+	section, key, err := splitConfigKey(keyStr)
+	if err != nil {
+		return "", err
+	}
+
+	switch section {
+	case "database":
+		switch key {
+		case "url":
+			return a.cfg.Database.URL, nil
+		case "query_timeout":
+			return strconv.Itoa(a.cfg.Database.QueryTimeout), nil
+		case "max_conns":
+			return strconv.Itoa(a.cfg.Database.MaxConns), nil
+		case "min_conns":
+			return strconv.Itoa(a.cfg.Database.MinConns), nil
+		case "max_conn_idle_time":
+			return strconv.Itoa(a.cfg.Database.MaxConnIdleTime), nil
+		default:
+			return "", fmt.Errorf("unknown database key: %s", key)
+		}
+	case "api":
+		switch key {
+		case "host":
+			return a.cfg.API.Host, nil
+		case "port":
+			return strconv.Itoa(a.cfg.API.Port), nil
+		case "rate_limit_per_minute":
+			return strconv.Itoa(a.cfg.API.RateLimitPerMinute), nil
+		case "log_format":
+			return a.cfg.API.LogFormat, nil
+		case "key_expiry_days":
+			return strconv.Itoa(a.cfg.API.KeyExpiryDays), nil
+		case "max_text_length":
+			return strconv.Itoa(a.cfg.API.MaxTextLength), nil
+		default:
+			return "", fmt.Errorf("unknown api key: %s", key)
+		}
+	case "cli":
+		switch key {
+		case "base_url":
+			return a.cfg.CLI.BaseURL, nil
+		case "api_prefix":
+			return a.cfg.CLI.APIPrefix, nil
+		case "api_key":
+			return a.cfg.CLI.APIKey, nil
+		case "scrape_timeout":
+			return strconv.Itoa(a.cfg.CLI.ScrapeTimeout), nil
+		case "preview_length":
+			return strconv.Itoa(a.cfg.CLI.PreviewLength), nil
+		case "url_display_width":
+			return strconv.Itoa(a.cfg.CLI.URLDisplayWidth), nil
+		case "log_level":
+			return a.cfg.CLI.LogLevel, nil
+		case "log_sinks":
+			return strings.Join(a.cfg.CLI.LogSinks, ","), nil
+		case "bulk_confirm_threshold":
+			return strconv.Itoa(a.cfg.CLI.BulkConfirmThreshold), nil
+		default:
+			return "", fmt.Errorf("unknown cli key: %s", key)
+		}
+	case "scraper":
+		switch key {
+		case "base_url":
+			return a.cfg.Scraper.BaseURL, nil
+		case "adapter":
+			return a.cfg.Scraper.Adapter, nil
+		case "cache_ttl":
+			return strconv.Itoa(a.cfg.Scraper.CacheTTL), nil
+		case "max_concurrent":
+			return strconv.Itoa(a.cfg.Scraper.MaxConcurrent), nil
+		case "user_agent":
+			return a.cfg.Scraper.UserAgent, nil
+		default:
+			return "", fmt.Errorf("unknown scraper key: %s", key)
+		}
+	default:
+		return "", fmt.Errorf("unknown section: %s", section)
+	}
+}
+
 // SetConfig sets a configuration value
 // Format: section.key=value (e.g., "database.url=postgres://...")
 func (a *App) SetConfig(setStr string) error {
+	// Make sure there is a file to update, even on first use
+	if err := config.EnsureExists(); err != nil {
+		return err
+	}
+
 	parts := strings.SplitN(setStr, "=", 2)
 	if len(parts) != 2 {
 		return fmt.Errorf("invalid format: expected 'section.key=value'")
 	}
 
-	keyPath := strings.Split(parts[0], ".")
-	value := parts[1]
-
-	if len(keyPath) != 2 {
-		return fmt.Errorf("invalid key format: expected 'section.key'")
+	if err := setConfigValue(a.cfg, parts[0], parts[1]); err != nil {
+		return err
 	}
+	return config.Save(a.cfg)
+}
 
-	section := keyPath[0]
-	key := keyPath[1]
+// setConfigValue validates value for the "section.key" path keyStr and
+// stores it in cfg, without saving
+func setConfigValue(cfg *config.Config, keyStr, value string) error {
+	section, key, err := splitConfigKey(keyStr)
+	if err != nil {
+		return err
+	}
 
 	switch section {
 	case "database":
 		switch key {
 		case "url":
-			a.cfg.Database.URL = value
+			cfg.Database.URL = value
+		case "query_timeout":
+			var timeout int
+			if _, err := fmt.Sscanf(value, "%d", &timeout); err != nil {
+				return fmt.Errorf("invalid query_timeout value: %s", value)
+			}
+			cfg.Database.QueryTimeout = timeout
+		case "max_conns":
+			var conns int
+			if _, err := fmt.Sscanf(value, "%d", &conns); err != nil || conns <= 0 {
+				return fmt.Errorf("invalid max_conns value: %s", value)
+			}
+			cfg.Database.MaxConns = conns
+		case "min_conns":
+			var conns int
+			if _, err := fmt.Sscanf(value, "%d", &conns); err != nil || conns < 0 {
+				return fmt.Errorf("invalid min_conns value: %s", value)
+			}
+			cfg.Database.MinConns = conns
+		case "max_conn_idle_time":
+			var seconds int
+			if _, err := fmt.Sscanf(value, "%d", &seconds); err != nil || seconds <= 0 {
+				return fmt.Errorf("invalid max_conn_idle_time value: %s", value)
+			}
+			cfg.Database.MaxConnIdleTime = seconds
 		default:
 			return fmt.Errorf("unknown database key: %s", key)
 		}
 	case "api":
 		switch key {
 		case "host":
-			a.cfg.API.Host = value
+			cfg.API.Host = value
 		case "port":
 			var port int
 			if _, err := fmt.Sscanf(value, "%d", &port); err != nil {
 				return fmt.Errorf("invalid port value: %s", value)
 			}
-			a.cfg.API.Port = port
+			cfg.API.Port = port
+		case "rate_limit_per_minute":
+			var limit int
+			if _, err := fmt.Sscanf(value, "%d", &limit); err != nil {
+				return fmt.Errorf("invalid rate_limit_per_minute value: %s", value)
+			}
+			cfg.API.RateLimitPerMinute = limit
+		case "log_format":
+			if value != "text" && value != "json" {
+				return fmt.Errorf("invalid log_format value: %s (expected text or json)", value)
+			}
+			cfg.API.LogFormat = value
+		case "key_expiry_days":
+			var days int
+			if _, err := fmt.Sscanf(value, "%d", &days); err != nil || days < 0 {
+				return fmt.Errorf("invalid key_expiry_days value: %s", value)
+			}
+			cfg.API.KeyExpiryDays = days
+		case "max_text_length":
+			var length int
+			if _, err := fmt.Sscanf(value, "%d", &length); err != nil || length <= 0 {
+				return fmt.Errorf("invalid max_text_length value: %s (must be greater than 0)", value)
+			}
+			cfg.API.MaxTextLength = length
 		default:
 			return fmt.Errorf("unknown api key: %s", key)
 		}
 	case "cli":
 		switch key {
 		case "base_url":
-			a.cfg.CLI.BaseURL = value
+			cfg.CLI.BaseURL = value
+		case "api_prefix":
+			cfg.CLI.APIPrefix = value
 		case "api_key":
-			a.cfg.CLI.APIKey = value
+			cfg.CLI.APIKey = value
 		case "scrape_timeout":
 			var timeout int
 			if _, err := fmt.Sscanf(value, "%d", &timeout); err != nil {
 				return fmt.Errorf("invalid scrape_timeout value: %s", value)
 			}
-			a.cfg.CLI.ScrapeTimeout = timeout
+			cfg.CLI.ScrapeTimeout = timeout
+		case "preview_length":
+			var length int
+			if _, err := fmt.Sscanf(value, "%d", &length); err != nil || length <= 0 {
+				return fmt.Errorf("invalid preview_length value: %s", value)
+			}
+			cfg.CLI.PreviewLength = length
+		case "url_display_width":
+			var width int
+			if _, err := fmt.Sscanf(value, "%d", &width); err != nil || width <= 0 {
+				return fmt.Errorf("invalid url_display_width value: %s", value)
+			}
+			cfg.CLI.URLDisplayWidth = width
+		case "log_level":
+			if _, err := logger.ParseLevel(value); err != nil {
+				return err
+			}
+			cfg.CLI.LogLevel = value
+		case "log_sinks":
+			// Comma-separated list, e.g. "file,stderr"
+			var sinks []string
+			for _, name := range strings.Split(value, ",") {
+				if _, err := logger.ParseSink(name); err != nil {
+					return err
+				}
+				sinks = append(sinks, strings.TrimSpace(name))
+			}
+			cfg.CLI.LogSinks = sinks
+		case "bulk_confirm_threshold":
+			var threshold int
+			if _, err := fmt.Sscanf(value, "%d", &threshold); err != nil || threshold == 0 {
+				return fmt.Errorf("invalid bulk_confirm_threshold value: %s (use a positive number, or negative to always ask y/N)", value)
+			}
+			cfg.CLI.BulkConfirmThreshold = threshold
 		default:
 			return fmt.Errorf("unknown cli key: %s", key)
 		}
+	case "scraper":
+		switch key {
+		case "base_url":
+			cfg.Scraper.BaseURL = value
+		case "adapter":
+			if _, err := scraper.AdapterByName(value); err != nil {
+				return err
+			}
+			cfg.Scraper.Adapter = value
+		case "cache_ttl":
+			var ttl int
+			if _, err := fmt.Sscanf(value, "%d", &ttl); err != nil {
+				return fmt.Errorf("invalid cache_ttl value: %s", value)
+			}
+			cfg.Scraper.CacheTTL = ttl
+		case "max_concurrent":
+			var limit int
+			if _, err := fmt.Sscanf(value, "%d", &limit); err != nil {
+				return fmt.Errorf("invalid max_concurrent value: %s", value)
+			}
+			cfg.Scraper.MaxConcurrent = limit
+		case "user_agent":
+			cfg.Scraper.UserAgent = value
+		default:
+			return fmt.Errorf("unknown scraper key: %s", key)
+		}
 	default:
 		return fmt.Errorf("unknown section: %s", section)
 	}
 
-	return config.Save(a.cfg)
+	return nil
 }
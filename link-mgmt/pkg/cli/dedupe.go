@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+
+	"link-mgmt/pkg/cli/links"
+
+	"github.com/google/uuid"
+)
+
+// Dedupe lists groups of links whose URLs point at the same page. With prune,
+// it deletes all but one link per group, chosen by the keep strategy (see
+// links.PickKeeper), after asking for confirmation unless skipConfirm is set.
+func (a *App) Dedupe(prune bool, keep string, skipConfirm bool) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if err := links.ValidateKeepStrategy(keep); err != nil {
+		return err
+	}
+
+	all, err := apiClient.ListLinks()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+
+	groups := links.FindDuplicates(all)
+	if len(groups) == 0 {
+		links.WriteToStdout(links.FormatEmptyState("No duplicate links found."))
+		return nil
+	}
+
+	var toDelete []uuid.UUID
+	for i, group := range groups {
+		keeper := links.PickKeeper(group, keep)
+
+		fmt.Printf("Group %d (%d links):\n", i+1, len(group))
+		for j, link := range group {
+			marker := "  "
+			if j == keeper {
+				marker = "✓ "
+			} else {
+				toDelete = append(toDelete, link.ID)
+			}
+			fmt.Printf("  %s%s  %s  %s  %s\n",
+				marker,
+				links.ShortenID(link.ID),
+				links.FormatDate(link.CreatedAt),
+				link.URL,
+				links.GetTitle(link),
+			)
+		}
+		fmt.Println()
+	}
+
+	if !prune {
+		fmt.Printf("%d duplicate link(s) in %d group(s); run with --prune to delete all but the ✓ link in each group\n", len(toDelete), len(groups))
+		return nil
+	}
+
+	if !skipConfirm {
+		ok, err := a.confirmDelete(fmt.Sprintf("Delete %d duplicate link(s)?", len(toDelete)), len(toDelete))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	deleted, err := apiClient.DeleteLinks(toDelete)
+	if err != nil {
+		return fmt.Errorf("failed to delete duplicates: %w", err)
+	}
+
+	fmt.Printf("✓ Deleted %d duplicate link(s)\n", deleted)
+	return nil
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"link-mgmt/pkg/cli/client"
+)
+
+// doctorScraperTimeout bounds the scraper health check in --doctor
+const doctorScraperTimeout = 5 * time.Second
+
+// doctorCheck is one step of the --doctor checklist
+type doctorCheck struct {
+	name string
+	// critical failures make Doctor return an error (nonzero exit)
+	critical bool
+	// needs names earlier checks that must pass for this one to run
+	needs []string
+	// run performs the check; detail is shown next to a passing check
+	run func() (detail string, err error)
+	// hint suggests a fix for a failed check
+	hint func(err error) string
+}
+
+// Doctor checks config, API readiness, authentication, and the scraper in
+// order, printing a ✓/✗ checklist with hints for anything that fails.
+// Returns an error if any critical check failed.
+func (a *App) Doctor() error {
+	return runDoctorChecks(a.doctorChecks())
+}
+
+// runDoctorChecks runs checks in order and prints the checklist
+func runDoctorChecks(checks []doctorCheck) error {
+	failed := 0
+	passed := make(map[string]bool)
+	shownHints := make(map[string]bool)
+	for _, check := range checks {
+		if missing := firstMissing(check.needs, passed); missing != "" {
+			fmt.Printf("- %s: skipped (needs %s)\n", check.name, missing)
+			if check.critical {
+				failed++
+			}
+			continue
+		}
+
+		detail, err := check.run()
+		if err == nil {
+			passed[check.name] = true
+			if detail != "" {
+				fmt.Printf("✓ %s (%s)\n", check.name, detail)
+			} else {
+				fmt.Printf("✓ %s\n", check.name)
+			}
+			continue
+		}
+
+		fmt.Printf("✗ %s: %v\n", check.name, err)
+		if hint := check.hint(err); hint != "" && shownHints[hint] {
+			fmt.Println("    (see the hint above)")
+		} else if hint != "" {
+			shownHints[hint] = true
+			for _, line := range strings.Split(hint, "\n") {
+				if line == "" {
+					fmt.Println()
+					continue
+				}
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		if check.critical {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d critical check(s) failed", failed)
+	}
+	fmt.Println("\nAll critical checks passed.")
+	return nil
+}
+
+// firstMissing returns the first of names that hasn't passed, or ""
+func firstMissing(names []string, passed map[string]bool) string {
+	for _, name := range names {
+		if !passed[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+// doctorChecks returns the checks run by Doctor, in order
+func (a *App) doctorChecks() []doctorCheck {
+	return []doctorCheck{
+		{
+			name:     "Config",
+			critical: true,
+			run:      a.checkConfig,
+			hint: func(err error) string {
+				return "Fix it with --config-set <section.key=value>, register with --register <email>,\n" +
+					"or write a fresh commented config with --config-init --force"
+			},
+		},
+		{
+			name:     "API ready",
+			critical: true,
+			needs:    []string{"Config"},
+			run: func() (string, error) {
+				apiClient, err := a.getClient()
+				if err != nil {
+					return "", err
+				}
+				return a.cfg.CLI.BaseURL, apiClient.CheckReady()
+			},
+			hint: func(err error) string {
+				var apiErr *client.APIError
+				switch {
+				case isConnectionError(err):
+					return startServicesHint
+				case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable:
+					return "The API is up but can't reach the database. Check database.url, start PostgreSQL\n" +
+						"(make postgres-up), and run migrations (--migrate or make migrate)"
+				case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+					return "cli.base_url may point at the wrong service, or the API is an older version"
+				default:
+					return "Check cli.base_url and the API logs: docker compose logs api-dev"
+				}
+			},
+		},
+		{
+			name:     "API authentication",
+			critical: true,
+			needs:    []string{"Config", "API ready"},
+			run: func() (string, error) {
+				apiClient, err := a.getClient()
+				if err != nil {
+					return "", err
+				}
+				user, err := apiClient.GetCurrentUser()
+				if err != nil {
+					return "", err
+				}
+				if user.IsReadOnly {
+					return user.Email + ", read-only key", nil
+				}
+				return user.Email, nil
+			},
+			hint: func(err error) string {
+				var apiErr *client.APIError
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+					return "Register with --register <email> or set a valid key with: --config-set cli.api_key=<key>"
+				}
+				return ""
+			},
+		},
+		{
+			// Not critical: links can still be saved without scraping
+			name:  "Scraper",
+			needs: []string{"Config"},
+			run: func() (string, error) {
+				scraperService, err := a.getScraperService()
+				if err != nil {
+					return "", err
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), doctorScraperTimeout)
+				defer cancel()
+				return a.cfg.ScraperBaseURL(), scraperService.CheckHealthWithContext(ctx)
+			},
+			hint: func(err error) string {
+				if isConnectionError(err) {
+					return startServicesHint
+				}
+				return "Check scraper.base_url (or cli.base_url) and the scraper logs: docker compose logs scraper-dev\n" +
+					"Links can still be saved without scraping"
+			},
+		},
+	}
+}
+
+// checkConfig validates the settings the other checks depend on
+func (a *App) checkConfig() (string, error) {
+	if err := validateServiceURL("cli.base_url", a.cfg.CLI.BaseURL); err != nil {
+		return "", err
+	}
+	if a.cfg.Scraper.BaseURL != "" {
+		if err := validateServiceURL("scraper.base_url", a.cfg.Scraper.BaseURL); err != nil {
+			return "", err
+		}
+	}
+	if a.cfg.CLI.APIKey == "" {
+		return "", errors.New("cli.api_key is not set")
+	}
+	return "", nil
+}
+
+// validateServiceURL checks that a config value is an absolute http(s) URL
+func validateServiceURL(key, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s is not set", key)
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s %q is not an http(s) URL", key, value)
+	}
+	return nil
+}
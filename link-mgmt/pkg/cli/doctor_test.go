@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// doctorAPI registers the endpoints --doctor checks, answering with the given statuses
+func doctorAPI(api *testAPI, ready, me, scraper int) {
+	api.Handle("GET /health/ready", respondJSON(ready, map[string]string{"status": "ready"}))
+	api.Handle("GET /api/v1/users/me", respondJSON(me, map[string]interface{}{
+		"id":    "3f2a9c1e-0000-4000-8000-000000000001",
+		"email": "me@example.com",
+		"error": "invalid API key",
+	}))
+	api.Handle("GET /scraper/health", respondJSON(scraper, map[string]string{"status": "ok"}))
+}
+
+func TestDoctor(t *testing.T) {
+	tests := []struct {
+		name               string
+		apiKey             string
+		ready, me, scraper int
+		wantErr            bool
+		want               []string // lines or fragments expected in the output
+	}{
+		{
+			name: "all pass", apiKey: "test-key",
+			ready: http.StatusOK, me: http.StatusOK, scraper: http.StatusOK,
+			want: []string{"✓ Config", "✓ API ready", "✓ API authentication (me@example.com)", "✓ Scraper", "All critical checks passed"},
+		},
+		{
+			name: "no API key", apiKey: "",
+			ready: http.StatusOK, me: http.StatusOK, scraper: http.StatusOK, wantErr: true,
+			want: []string{"✗ Config: cli.api_key is not set", "--register <email>", "- API ready: skipped (needs Config)", "- API authentication: skipped (needs Config)"},
+		},
+		{
+			name: "database down", apiKey: "test-key",
+			ready: http.StatusServiceUnavailable, me: http.StatusOK, scraper: http.StatusOK, wantErr: true,
+			want: []string{"✓ Config", "✗ API ready", "can't reach the database", "- API authentication: skipped (needs API ready)", "✓ Scraper"},
+		},
+		{
+			name: "bad key", apiKey: "test-key",
+			ready: http.StatusOK, me: http.StatusUnauthorized, scraper: http.StatusOK, wantErr: true,
+			want: []string{"✓ API ready", "✗ API authentication", "--config-set cli.api_key=<key>"},
+		},
+		{
+			name: "scraper down isn't critical", apiKey: "test-key",
+			ready: http.StatusOK, me: http.StatusOK, scraper: http.StatusInternalServerError,
+			want: []string{"✓ API authentication", "✗ Scraper", "docker compose logs scraper-dev", "All critical checks passed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, app := newTestAPI(t)
+			app.cfg.CLI.APIKey = tt.apiKey
+			doctorAPI(api, tt.ready, tt.me, tt.scraper)
+
+			var err error
+			out := captureStdout(t, func() { err = app.Doctor() })
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Doctor() = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output lacks %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestDoctorServicesDown(t *testing.T) {
+	_, app := newTestAPI(t)
+	app.cfg.CLI.BaseURL = "http://127.0.0.1:1"
+
+	var err error
+	out := captureStdout(t, func() { err = app.Doctor() })
+	if err == nil {
+		t.Error("Doctor() = nil, want an error with the API down")
+	}
+	// The start-services hint is shown once, for the API and scraper alike
+	if got := strings.Count(out, "make dev-upd"); got != 1 {
+		t.Errorf("start-services hint shown %d times, want 1:\n%s", got, out)
+	}
+	if !strings.Contains(out, "(see the hint above)") {
+		t.Errorf("output doesn't refer back to the hint:\n%s", out)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseURL    string
+		scraperURL string
+		apiKey     string
+		wantErr    string
+	}{
+		{"valid", "http://localhost", "", "key", ""},
+		{"valid with scraper URL", "https://links.example.com", "http://scraper:8000", "key", ""},
+		{"no base URL", "", "", "key", "cli.base_url is not set"},
+		{"base URL without scheme", "localhost:8080", "", "key", "not an http(s) URL"},
+		{"bad scraper URL", "http://localhost", "ftp://scraper", "key", "scraper.base_url"},
+		{"no API key", "http://localhost", "", "", "cli.api_key is not set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, app := newTestAPI(t)
+			app.cfg.CLI.BaseURL = tt.baseURL
+			app.cfg.Scraper.BaseURL = tt.scraperURL
+			app.cfg.CLI.APIKey = tt.apiKey
+
+			_, err := app.checkConfig()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkConfig() = %v, want nil", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkConfig() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunDoctorChecksCountsSkippedCriticalChecks(t *testing.T) {
+	pass := func() (string, error) { return "", nil }
+	fail := func() (string, error) { return "", errors.New("broken") }
+	noHint := func(error) string { return "" }
+
+	var err error
+	out := captureStdout(t, func() {
+		err = runDoctorChecks([]doctorCheck{
+			{name: "A", critical: true, run: fail, hint: noHint},
+			{name: "B", critical: true, needs: []string{"A"}, run: pass, hint: noHint},
+			{name: "C", needs: []string{"A"}, run: pass, hint: noHint},
+			{name: "D", run: pass, hint: noHint},
+		})
+	})
+	if err == nil || !strings.Contains(err.Error(), "2 critical check(s) failed") {
+		t.Errorf("runDoctorChecks() = %v, want 2 critical failures (one failed, one skipped)", err)
+	}
+	for _, want := range []string{"✗ A: broken", "- B: skipped (needs A)", "- C: skipped (needs A)", "✓ D"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+}
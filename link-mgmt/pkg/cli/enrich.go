@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"link-mgmt/pkg/cli/links"
+	"link-mgmt/pkg/models"
+)
+
+// enrichAllConcurrency is how many links EnrichAll scrapes at once
+const enrichAllConcurrency = 4
+
+// EnrichAll re-scrapes every link missing a title or text, plus, when
+// staleDays is set, every link never scraped or last scraped more than that
+// many days ago. Only empty fields are filled. A link that fails is reported
+// and skipped; the error returned afterwards counts the failures.
+func (a *App) EnrichAll(staleDays *int) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	all, err := apiClient.ListLinks()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+
+	now := time.Now()
+	var pending []models.Link
+	for _, link := range all {
+		if needsEnrichment(link, staleDays, now) {
+			pending = append(pending, link)
+		}
+	}
+	if len(pending) == 0 {
+		links.WriteToStdout(links.FormatEmptyState("No links need enriching."))
+		return nil
+	}
+
+	fmt.Printf("⏳ Enriching %d link(s), %d at a time...\n", len(pending), min(enrichAllConcurrency, len(pending)))
+
+	var (
+		mu       sync.Mutex
+		done     int
+		failed   int
+		wg       sync.WaitGroup
+		slots    = make(chan struct{}, enrichAllConcurrency)
+		timeout  = a.cfg.CLI.ScrapeTimeout
+		progress = func(link models.Link, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if err != nil {
+				failed++
+				fmt.Printf("[%d/%d] ✗ %s: %v\n", done, len(pending), link.URL, err)
+				return
+			}
+			fmt.Printf("[%d/%d] ✓ %s\n", done, len(pending), link.URL)
+		}
+	)
+	for _, link := range pending {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(link models.Link) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			_, err := apiClient.EnrichLink(link.ID, timeout, true) // only fill empty
+			progress(link, err)
+		}(link)
+	}
+	wg.Wait()
+
+	fmt.Printf("\n✓ Enriched %d of %d link(s)\n", len(pending)-failed, len(pending))
+	if failed > 0 {
+		return fmt.Errorf("%d link(s) could not be enriched", failed)
+	}
+	return nil
+}
+
+// needsEnrichment reports whether a link is missing its title or text, or,
+// with staleDays set, was never scraped or last scraped more than that many
+// days before now
+func needsEnrichment(link models.Link, staleDays *int, now time.Time) bool {
+	if link.Title == nil || strings.TrimSpace(*link.Title) == "" {
+		return true
+	}
+	if link.Text == nil || strings.TrimSpace(*link.Text) == "" {
+		return true
+	}
+	if staleDays == nil {
+		return false
+	}
+	return link.LastScrapedAt == nil || link.LastScrapedAt.Before(now.AddDate(0, 0, -*staleDays))
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+func TestNeedsEnrichment(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	title, text, blank := "Title", "Body text", "  "
+	recent := now.AddDate(0, 0, -2)
+	old := now.AddDate(0, 0, -40)
+	days := func(n int) *int { return &n }
+
+	tests := []struct {
+		name      string
+		link      models.Link
+		staleDays *int
+		want      bool
+	}{
+		{"missing title", models.Link{Text: &text}, nil, true},
+		{"blank title", models.Link{Title: &blank, Text: &text}, nil, true},
+		{"missing text", models.Link{Title: &title}, nil, true},
+		{"never scraped, staleness unchecked", models.Link{Title: &title, Text: &text}, nil, false},
+		{"never scraped", models.Link{Title: &title, Text: &text}, days(30), true},
+		{"scraped recently", models.Link{Title: &title, Text: &text, LastScrapedAt: &recent}, days(30), false},
+		{"scraped long ago", models.Link{Title: &title, Text: &text, LastScrapedAt: &old}, days(30), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsEnrichment(tt.link, tt.staleDays, now); got != tt.want {
+				t.Errorf("needsEnrichment = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichAll(t *testing.T) {
+	title, text := "Title", "Body text"
+	recent := time.Now().Add(-time.Hour)
+	old := time.Now().AddDate(0, 0, -60)
+	complete := models.Link{ID: uuid.New(), URL: "https://example.com/complete", Title: &title, Text: &text, LastScrapedAt: &recent}
+	untitled := models.Link{ID: uuid.New(), URL: "https://example.com/untitled", Text: &text}
+	broken := models.Link{ID: uuid.New(), URL: "https://example.com/broken", Title: &title}
+	stale := models.Link{ID: uuid.New(), URL: "https://example.com/stale", Title: &title, Text: &text, LastScrapedAt: &old}
+
+	var mu sync.Mutex
+	var enriched []uuid.UUID
+	api, app := newTestAPI(t)
+	api.Handle("GET /api/v1/links", respondJSON(http.StatusOK, []models.Link{complete, untitled, broken, stale}))
+	api.HandleFunc("POST /api/v1/links/{id}/enrich", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			OnlyFillEmpty bool `json:"only_fill_empty"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.OnlyFillEmpty {
+			t.Errorf("enrich %s without only_fill_empty", r.PathValue("id"))
+		}
+		id := uuid.MustParse(r.PathValue("id"))
+		if id == broken.ID {
+			respondJSON(http.StatusBadGateway, map[string]string{"error": "scraper unavailable"})(w, r)
+			return
+		}
+		mu.Lock()
+		enriched = append(enriched, id)
+		mu.Unlock()
+		respondJSON(http.StatusOK, models.Link{ID: id})(w, r)
+	})
+
+	staleDays := 30
+	var err error
+	out := captureStdout(t, func() { err = app.EnrichAll(&staleDays) })
+
+	// The failure is reported, but doesn't stop the others
+	if err == nil || !strings.Contains(err.Error(), "1 link(s) could not be enriched") {
+		t.Errorf("EnrichAll error = %v, want one failure counted", err)
+	}
+	slices.SortFunc(enriched, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	want := []uuid.UUID{untitled.ID, stale.ID}
+	slices.SortFunc(want, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	if !slices.Equal(enriched, want) {
+		t.Errorf("enriched %v, want the untitled and stale links %v", enriched, want)
+	}
+	for _, line := range []string{
+		"Enriching 3 link(s)",
+		"✓ " + untitled.URL,
+		"✓ " + stale.URL,
+		"✗ " + broken.URL,
+		"Enriched 2 of 3 link(s)",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("output doesn't contain %q:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, complete.URL) {
+		t.Errorf("output mentions the complete link:\n%s", out)
+	}
+}
+
+func TestEnrichAllNothingToDo(t *testing.T) {
+	title, text := "Title", "Body text"
+	api, app := newTestAPI(t)
+	api.Handle("GET /api/v1/links", respondJSON(http.StatusOK, []models.Link{{ID: uuid.New(), URL: "https://example.com", Title: &title, Text: &text}}))
+
+	var err error
+	out := captureStdout(t, func() { err = app.EnrichAll(nil) })
+	if err != nil {
+		t.Fatalf("EnrichAll: %v", err)
+	}
+	if !strings.Contains(out, "No links need enriching") {
+		t.Errorf("output = %q, want the empty state", out)
+	}
+	assertCalls(t, api, "GET /api/v1/links")
+}
@@ -3,17 +3,92 @@ package links
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	"link-mgmt/pkg/models"
 )
 
-// FormatTableOutput formats links as a polished table for CLI output
-func FormatTableOutput(links []models.Link) string {
+// DefaultURLDisplayWidth is the URL column width used when none is configured
+const DefaultURLDisplayWidth = 50
+
+// TableFields are the columns FormatTableOutput can show, selected with --fields
+var TableFields = []string{"id", "url", "title", "site", "visits", "created", "updated"}
+
+// DefaultTableFields are the columns shown when no fields are selected
+var DefaultTableFields = []string{"id", "url", "title", "created"}
+
+// ParseTableFields parses a comma-separated list of TableFields, in the order
+// the columns should appear. Empty selects DefaultTableFields.
+func ParseTableFields(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultTableFields, nil
+	}
+	var fields []string
+	for _, field := range strings.Split(s, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(TableFields, field) {
+			return nil, fmt.Errorf("invalid field: %q (expected one of %s)", field, strings.Join(TableFields, ", "))
+		}
+		if slices.Contains(fields, field) {
+			return nil, fmt.Errorf("field %q is listed twice", field)
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required (expected some of %s)", strings.Join(TableFields, ", "))
+	}
+	return fields, nil
+}
+
+// tableColumn is one column of FormatTableOutput
+type tableColumn struct {
+	header string
+	rule   int // width of the rule under the header
+	value  func(link models.Link) string
+}
+
+// tableColumns returns the column for each of TableFields, with URLs
+// truncated to urlWidth characters
+func tableColumns(urlWidth int) map[string]tableColumn {
+	return map[string]tableColumn{
+		"id":  {"ID", 8, func(link models.Link) string { return ShortenID(link.ID) }},
+		"url": {"URL", urlWidth, func(link models.Link) string { return TruncateURL(link.URL, urlWidth) }},
+		"title": {"Title", 40, func(link models.Link) string {
+			title := GetTitle(link)
+			if link.IsFavorite {
+				title = "★ " + title
+			}
+			if link.IsRead {
+				title = "✓ " + title
+			}
+			return title
+		}},
+		"site": {"Site", 20, func(link models.Link) string {
+			if link.SiteName == nil || *link.SiteName == "" {
+				return "-"
+			}
+			return *link.SiteName
+		}},
+		"visits":  {"Visits", 6, func(link models.Link) string { return strconv.Itoa(link.VisitCount) }},
+		"created": {"Created", 16, func(link models.Link) string { return FormatDate(link.CreatedAt) }},
+		"updated": {"Updated", 16, func(link models.Link) string { return FormatDate(link.UpdatedAt) }},
+	}
+}
+
+// FormatTableOutput formats links as a polished table for CLI output, with
+// the given columns (from ParseTableFields; empty uses DefaultTableFields)
+// and URLs truncated to urlWidth characters (<= 0 uses DefaultURLDisplayWidth)
+func FormatTableOutput(links []models.Link, urlWidth int, fields []string) string {
 	if len(links) == 0 {
 		return "No links found."
 	}
+	columns := selectColumns(urlWidth, fields)
 
 	var b strings.Builder
 
@@ -22,32 +97,82 @@ func FormatTableOutput(links []models.Link) string {
 	b.WriteString(renderHeader())
 	b.WriteString("\n")
 
-	// Table
-	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tURL\tTitle\tCreated")
-	fmt.Fprintln(w, strings.Repeat("─", 8)+"\t"+strings.Repeat("─", 50)+"\t"+strings.Repeat("─", 40)+"\t"+strings.Repeat("─", 16))
+	writeTable(&b, links, columns)
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Total: %d link(s)\n", len(links)))
 
-	for _, link := range links {
-		title := GetTitle(link)
-		url := TruncateURL(link.URL, 50)
-		idShort := ShortenID(link.ID)
-		created := FormatDate(link.CreatedAt)
+	return b.String()
+}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			idShort,
-			url,
-			title,
-			created,
-		)
+// FormatGroupedTableOutput formats links grouped by domain (see
+// GroupLinksByDomain), each group under its domain and link count, with columns
+// and URL width as for FormatTableOutput
+func FormatGroupedTableOutput(groups []DomainGroup, urlWidth int, fields []string) string {
+	if len(groups) == 0 {
+		return "No links found."
 	}
+	columns := selectColumns(urlWidth, fields)
 
-	w.Flush()
+	var b strings.Builder
+
+	// Header
 	b.WriteString("\n")
-	b.WriteString(fmt.Sprintf("Total: %d link(s)\n", len(links)))
+	b.WriteString(renderHeader())
+	b.WriteString("\n")
+
+	total := 0
+	for _, group := range groups {
+		b.WriteString(fmt.Sprintf("\n%s (%d)\n", DomainLabel(group.Domain), len(group.Links)))
+		writeTable(&b, group.Links, columns)
+		total += len(group.Links)
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Total: %d link(s) across %d domain(s)\n", total, len(groups)))
 
 	return b.String()
 }
 
+// selectColumns returns the columns for fields (DefaultTableFields if empty),
+// with URLs truncated to urlWidth characters (<= 0 uses DefaultURLDisplayWidth)
+func selectColumns(urlWidth int, fields []string) []tableColumn {
+	if urlWidth <= 0 {
+		urlWidth = DefaultURLDisplayWidth
+	}
+	if len(fields) == 0 {
+		fields = DefaultTableFields
+	}
+
+	all := tableColumns(urlWidth)
+	columns := make([]tableColumn, len(fields))
+	for i, field := range fields {
+		columns[i] = all[field]
+	}
+	return columns
+}
+
+// writeTable writes links as an aligned table of columns, with a header row
+// and a rule under it
+func writeTable(b *strings.Builder, links []models.Link, columns []tableColumn) {
+	w := tabwriter.NewWriter(b, 0, 0, 3, ' ', 0)
+	headers := make([]string, len(columns))
+	rules := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+		rules[i] = strings.Repeat("─", column.rule)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	fmt.Fprintln(w, strings.Join(rules, "\t"))
+
+	values := make([]string, len(columns))
+	for _, link := range links {
+		for i, column := range columns {
+			values[i] = column.value(link)
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	w.Flush()
+}
+
 // FormatSuccessMessage formats a success message for link creation
 func FormatSuccessMessage(link *models.Link) string {
 	var b strings.Builder
@@ -68,6 +193,49 @@ func FormatSuccessMessage(link *models.Link) string {
 	return b.String()
 }
 
+// FormatLinkDetails formats every field of a link for CLI output
+func FormatLinkDetails(link *models.Link) string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  ID:          %s\n", link.ID.String()))
+	b.WriteString(fmt.Sprintf("  URL:         %s\n", link.URL))
+	b.WriteString(fmt.Sprintf("  Title:       %s\n", GetTitle(*link)))
+	if link.SiteName != nil && *link.SiteName != "" {
+		b.WriteString(fmt.Sprintf("  Site:        %s\n", *link.SiteName))
+	}
+	if link.IsFavorite {
+		b.WriteString("  Favorite:    ★\n")
+	}
+	if link.IsRead {
+		b.WriteString("  Read:        ✓\n")
+	}
+	if link.Description != nil && *link.Description != "" {
+		b.WriteString(fmt.Sprintf("  Description: %s\n", *link.Description))
+	}
+	if link.Notes != nil && *link.Notes != "" {
+		b.WriteString(fmt.Sprintf("  Notes:       %s\n", *link.Notes))
+	}
+	b.WriteString(fmt.Sprintf("  Created:     %s\n", FormatDate(link.CreatedAt)))
+	b.WriteString(fmt.Sprintf("  Updated:     %s\n", FormatDate(link.UpdatedAt)))
+	if link.LastScrapedAt != nil {
+		b.WriteString(fmt.Sprintf("  Scraped:     %s\n", FormatDate(*link.LastScrapedAt)))
+	} else {
+		b.WriteString("  Scraped:     never\n")
+	}
+	if link.VisitCount > 0 {
+		b.WriteString(fmt.Sprintf("  Visits:      %d\n", link.VisitCount))
+	}
+	if link.Text != nil && *link.Text != "" {
+		b.WriteString("\n")
+		b.WriteString(*link.Text)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
 // FormatErrorMessage formats an error message consistently
 func FormatErrorMessage(err error) string {
 	return fmt.Sprintf("❌ Error: %v\n", err)
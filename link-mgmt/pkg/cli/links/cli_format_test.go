@@ -0,0 +1,122 @@
+package links
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"link-mgmt/pkg/models"
+)
+
+// ruleWidths returns the widths of the ─ rules under a table's header
+func ruleWidths(table string) []int {
+	for _, line := range strings.Split(table, "\n") {
+		if strings.HasPrefix(line, "─") {
+			var widths []int
+			for _, rule := range strings.Fields(line) {
+				widths = append(widths, utf8.RuneCountInString(rule))
+			}
+			return widths
+		}
+	}
+	return nil
+}
+
+func TestFormatTableOutputURLWidth(t *testing.T) {
+	url := "https://example.com/" + strings.Repeat("a", 80) // 100 characters
+	links := []models.Link{{URL: url}}
+
+	tests := []struct {
+		name     string
+		urlWidth int
+		wantURL  string
+	}{
+		{"default width", 0, TruncateURL(url, DefaultURLDisplayWidth)},
+		{"narrow", 30, TruncateURL(url, 30)},
+		{"wide enough for the URL", 120, url},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := FormatTableOutput(links, tt.urlWidth, nil)
+			if !strings.Contains(out, tt.wantURL+" ") {
+				t.Errorf("output does not show URL %q:\n%s", tt.wantURL, out)
+			}
+			width := tt.urlWidth
+			if width <= 0 {
+				width = DefaultURLDisplayWidth
+			}
+			if rules := ruleWidths(out); len(rules) < 2 || rules[1] != width {
+				t.Errorf("column rules %v, want the URL column %d wide:\n%s", rules, width, out)
+			}
+		})
+	}
+}
+
+func TestParseTableFields(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr string
+	}{
+		{"", DefaultTableFields, ""},
+		{"  ", DefaultTableFields, ""},
+		{"url,title", []string{"url", "title"}, ""},
+		{"visits, ID ,url", []string{"visits", "id", "url"}, ""},
+		{"url,,title,", []string{"url", "title"}, ""},
+		{"url,tags", nil, `invalid field: "tags"`},
+		{"url,URL", nil, `field "url" is listed twice`},
+		{",,", nil, "at least one field is required"},
+	}
+	for _, tt := range tests {
+		got, err := ParseTableFields(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseTableFields(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("ParseTableFields(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormatTableOutputFields(t *testing.T) {
+	site := "Example"
+	links := []models.Link{{URL: "https://example.com/a", SiteName: &site, VisitCount: 7}}
+
+	tests := []struct {
+		fields     []string
+		wantHeader []string
+		wantRules  []int
+	}{
+		{nil, []string{"ID", "URL", "Title", "Created"}, []int{8, DefaultURLDisplayWidth, 40, 16}},
+		{[]string{"visits", "url"}, []string{"Visits", "URL"}, []int{6, DefaultURLDisplayWidth}},
+		{[]string{"site"}, []string{"Site"}, []int{20}},
+	}
+	for _, tt := range tests {
+		out := FormatTableOutput(links, 0, tt.fields)
+		var header []string
+		for _, line := range strings.Split(out, "\n") {
+			if strings.HasPrefix(line, tt.wantHeader[0]) {
+				header = strings.Fields(line)
+				break
+			}
+		}
+		if !slices.Equal(header, tt.wantHeader) {
+			t.Errorf("fields %v: header %v, want %v:\n%s", tt.fields, header, tt.wantHeader, out)
+		}
+		if rules := ruleWidths(out); !slices.Equal(rules, tt.wantRules) {
+			t.Errorf("fields %v: rules %v, want %v", tt.fields, rules, tt.wantRules)
+		}
+	}
+
+	out := FormatTableOutput(links, 0, []string{"visits", "site"})
+	if !strings.Contains(out, "7") || !strings.Contains(out, "Example") {
+		t.Errorf("selected columns don't show their values:\n%s", out)
+	}
+	if strings.Contains(out, "https://example.com/a") {
+		t.Errorf("unselected URL column shown:\n%s", out)
+	}
+}
@@ -0,0 +1,119 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/utils"
+)
+
+// Strategies for choosing which link of a duplicate group to keep
+const (
+	KeepOldest   = "oldest"
+	KeepMetadata = "metadata"
+)
+
+// FindDuplicates groups links whose URLs differ only in ways that point at the
+// same page: http vs https, host case, a default port, or a trailing slash.
+// Each group is sorted oldest first; groups are ordered by their oldest link
+// and only groups with more than one link are returned.
+func FindDuplicates(links []models.Link) [][]models.Link {
+	byKey := make(map[string][]models.Link)
+	var keys []string
+	for _, link := range links {
+		key := duplicateKey(link.URL)
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], link)
+	}
+
+	var groups [][]models.Link
+	for _, key := range keys {
+		group := byKey[key]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].CreatedAt.Before(group[j].CreatedAt)
+		})
+		groups = append(groups, group)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i][0].CreatedAt.Before(groups[j][0].CreatedAt)
+	})
+	return groups
+}
+
+// ValidateKeepStrategy checks a strategy name for PickKeeper
+func ValidateKeepStrategy(strategy string) error {
+	switch strategy {
+	case KeepOldest, KeepMetadata:
+		return nil
+	default:
+		return fmt.Errorf("invalid keep strategy %q (use %s or %s)", strategy, KeepOldest, KeepMetadata)
+	}
+}
+
+// PickKeeper returns the index of the link to keep in a non-empty group from
+// FindDuplicates: the oldest, or with KeepMetadata the one with the most
+// metadata (ties go to the oldest).
+func PickKeeper(group []models.Link, strategy string) int {
+	if strategy != KeepMetadata {
+		return 0
+	}
+	best, bestScore := 0, metadataScore(group[0])
+	for i := 1; i < len(group); i++ {
+		if score := metadataScore(group[i]); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// metadataScore counts a link's non-empty metadata fields
+func metadataScore(link models.Link) int {
+	score := 0
+	for _, field := range []*string{link.Title, link.Description, link.Text, link.Favicon, link.SiteName, link.ImageURL} {
+		if field != nil && strings.TrimSpace(*field) != "" {
+			score++
+		}
+	}
+	return score
+}
+
+// duplicateKey normalizes a URL for duplicate detection. URLs that can't be
+// parsed are compared as-is.
+func duplicateKey(raw string) string {
+	s, err := utils.ValidateURL(raw)
+	if err != nil {
+		return raw
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return s
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+	if scheme == "http" || scheme == "https" {
+		// http and https versions of a page are treated as the same link
+		scheme = "http(s)"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+
+	key := scheme + "://" + host + strings.TrimRight(u.EscapedPath(), "/")
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
@@ -0,0 +1,116 @@
+package links
+
+import (
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+func TestDuplicateKey(t *testing.T) {
+	same := [][]string{
+		{"https://example.com/page", "http://example.com/page", "https://example.com/page/", "HTTPS://Example.COM/page", "https://example.com:443/page", "http://example.com:80/page/"},
+		{"https://example.com", "https://example.com/", "http://EXAMPLE.com"},
+		{"https://example.com/a?x=1", "http://example.com/a/?x=1"},
+	}
+	for _, urls := range same {
+		want := duplicateKey(urls[0])
+		for _, url := range urls[1:] {
+			if got := duplicateKey(url); got != want {
+				t.Errorf("duplicateKey(%q) = %q, want %q like %q", url, got, want, urls[0])
+			}
+		}
+	}
+
+	different := [][2]string{
+		{"https://example.com/page", "https://example.com/Page"},
+		{"https://example.com/page", "https://www.example.com/page"},
+		{"https://example.com/a?x=1", "https://example.com/a?x=2"},
+		{"https://example.com/a", "https://example.com/a?x=1"},
+		{"https://example.com:8443/a", "https://example.com/a"},
+		{"https://example.com/a", "ftp://example.com/a"},
+	}
+	for _, pair := range different {
+		if duplicateKey(pair[0]) == duplicateKey(pair[1]) {
+			t.Errorf("duplicateKey(%q) == duplicateKey(%q), want them distinct", pair[0], pair[1])
+		}
+	}
+}
+
+// datedLink returns a link created daysAgo days before a fixed time
+func datedLink(url string, daysAgo int) models.Link {
+	return models.Link{
+		ID:        uuid.New(),
+		URL:       url,
+		CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -daysAgo),
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	links := []models.Link{
+		datedLink("https://example.com/page/", 1),
+		datedLink("https://unique.example.org", 9),
+		datedLink("http://blog.example.net/post", 2),
+		datedLink("https://example.com/page", 5),
+		datedLink("https://blog.example.net/post/", 8),
+		datedLink("HTTP://EXAMPLE.COM/page", 3),
+	}
+
+	groups := FindDuplicates(links)
+	want := [][]string{
+		// Groups are ordered by their oldest link, and each group oldest first
+		{"https://blog.example.net/post/", "http://blog.example.net/post"},
+		{"https://example.com/page", "HTTP://EXAMPLE.COM/page", "https://example.com/page/"},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d: %v", len(groups), len(want), groups)
+	}
+	for i := range want {
+		got := make([]string, len(groups[i]))
+		for j, link := range groups[i] {
+			got[j] = link.URL
+		}
+		if len(got) != len(want[i]) {
+			t.Fatalf("group %d = %q, want %q", i, got, want[i])
+		}
+		for j := range got {
+			if got[j] != want[i][j] {
+				t.Fatalf("group %d = %q, want %q", i, got, want[i])
+			}
+		}
+	}
+
+	if groups := FindDuplicates(links[1:3]); len(groups) != 0 {
+		t.Errorf("FindDuplicates without duplicates = %v, want none", groups)
+	}
+}
+
+func TestPickKeeper(t *testing.T) {
+	title, description := "Title", "Description"
+	group := []models.Link{
+		datedLink("https://example.com/a", 3),
+		datedLink("https://example.com/a/", 2),
+		datedLink("http://example.com/a", 1),
+	}
+	group[1].Title = &title
+	group[2].Title = &title
+	group[2].Description = &description
+
+	if got := PickKeeper(group, KeepOldest); got != 0 {
+		t.Errorf("PickKeeper(oldest) = %d, want 0", got)
+	}
+	if got := PickKeeper(group, KeepMetadata); got != 2 {
+		t.Errorf("PickKeeper(metadata) = %d, want 2 (the most metadata)", got)
+	}
+	// Ties go to the oldest
+	group[2].Description = nil
+	if got := PickKeeper(group, KeepMetadata); got != 1 {
+		t.Errorf("PickKeeper(metadata) with a tie = %d, want 1 (the oldest of the tie)", got)
+	}
+
+	if err := ValidateKeepStrategy("newest"); err == nil {
+		t.Error("ValidateKeepStrategy(newest) = nil, want an error")
+	}
+}
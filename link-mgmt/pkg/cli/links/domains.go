@@ -0,0 +1,83 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"link-mgmt/pkg/models"
+)
+
+// Grouping modes for list output (--group-by)
+const (
+	GroupByNone   = ""
+	GroupByDomain = "domain"
+)
+
+// ValidateGroupBy checks a --group-by value
+func ValidateGroupBy(groupBy string) error {
+	switch groupBy {
+	case GroupByNone, GroupByDomain:
+		return nil
+	default:
+		return fmt.Errorf("invalid group-by %q (use %s)", groupBy, GroupByDomain)
+	}
+}
+
+// DomainGroup is the links sharing one domain (see LinkDomain)
+type DomainGroup struct {
+	Domain string // "" for links whose URL has no host
+	Links  []models.Link
+}
+
+// LinkDomain returns the host a link is grouped under: lowercased, without
+// a port, a trailing dot, or a leading "www.". Other subdomains are kept, so
+// www.example.com:8443 groups with example.com but blog.example.com doesn't.
+// Returns "" if the URL has no host.
+func LinkDomain(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	return strings.TrimPrefix(host, "www.")
+}
+
+// DomainLabel returns how a DomainGroup's domain is shown
+func DomainLabel(domain string) string {
+	if domain == "" {
+		return "(no domain)"
+	}
+	return domain
+}
+
+// GroupLinksByDomain groups links by LinkDomain, keeping their order within each
+// group. Groups are ordered largest first, then by domain; links without a
+// host come last.
+func GroupLinksByDomain(links []models.Link) []DomainGroup {
+	index := make(map[string]int)
+	var groups []DomainGroup
+	for _, link := range links {
+		domain := LinkDomain(link.URL)
+		i, ok := index[domain]
+		if !ok {
+			i = len(groups)
+			index[domain] = i
+			groups = append(groups, DomainGroup{Domain: domain})
+		}
+		groups[i].Links = append(groups[i].Links, link)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if (a.Domain == "") != (b.Domain == "") {
+			return b.Domain == ""
+		}
+		if len(a.Links) != len(b.Links) {
+			return len(a.Links) > len(b.Links)
+		}
+		return a.Domain < b.Domain
+	})
+	return groups
+}
@@ -1,6 +1,8 @@
 package links
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"link-mgmt/pkg/models"
@@ -16,12 +18,17 @@ func GetTitle(link models.Link) string {
 	return "(no title)"
 }
 
-// TruncateURL truncates a URL to the specified max length
+// TruncateURL truncates a URL to the specified max length in characters,
+// never splitting a multibyte character
 func TruncateURL(url string, maxLen int) string {
-	if len(url) <= maxLen {
+	runes := []rune(url)
+	if len(runes) <= maxLen {
 		return url
 	}
-	return url[:maxLen-3] + "..."
+	if maxLen < 3 {
+		return string(runes[:max(maxLen, 0)])
+	}
+	return string(runes[:maxLen-3]) + "..."
 }
 
 // ShortenID returns a shortened version of a UUID (first 8 characters + "...")
@@ -29,6 +36,32 @@ func ShortenID(id uuid.UUID) string {
 	return id.String()[:8] + "..."
 }
 
+// ResolveLinkID matches an ID prefix (such as the short ID from ShortenID, with or
+// without the trailing "...") against links. Returns an error if no link or more
+// than one link matches. A full UUID is matched exactly.
+func ResolveLinkID(prefix string, links []models.Link) (uuid.UUID, error) {
+	prefix = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(prefix), "..."))
+	if prefix == "" {
+		return uuid.Nil, fmt.Errorf("link ID is required")
+	}
+
+	var matches []uuid.UUID
+	for _, link := range links {
+		if strings.HasPrefix(link.ID.String(), prefix) {
+			matches = append(matches, link.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("no link found matching ID %q", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return uuid.Nil, fmt.Errorf("ID %q is ambiguous (matches %d links); use more characters", prefix, len(matches))
+	}
+}
+
 // FormatDate formats a time as a readable date string
 func FormatDate(t time.Time) string {
 	return t.Format("2006-01-02 15:04")
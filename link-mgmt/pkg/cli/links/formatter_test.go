@@ -0,0 +1,123 @@
+package links
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+func linksWithIDs(ids ...string) []models.Link {
+	links := make([]models.Link, len(ids))
+	for i, id := range ids {
+		links[i].ID = uuid.MustParse(id)
+	}
+	return links
+}
+
+func TestResolveLinkID(t *testing.T) {
+	links := linksWithIDs(
+		"1a2b3c4d-0000-4000-8000-000000000001",
+		"1a2b9999-0000-4000-8000-000000000002",
+		"ffee0011-0000-4000-8000-000000000003",
+	)
+
+	tests := []struct {
+		name    string
+		prefix  string
+		want    string
+		wantErr string
+	}{
+		{"unique prefix", "1a2b3c", "1a2b3c4d-0000-4000-8000-000000000001", ""},
+		{"short ID as displayed", "ffee0011...", "ffee0011-0000-4000-8000-000000000003", ""},
+		{"upper case", "FFEE", "ffee0011-0000-4000-8000-000000000003", ""},
+		{"full UUID", "1a2b9999-0000-4000-8000-000000000002", "1a2b9999-0000-4000-8000-000000000002", ""},
+		{"ambiguous prefix", "1a2b", "", "ambiguous (matches 2 links)"},
+		{"no match", "abcd", "", "no link found"},
+		{"empty", "  ", "", "required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveLinkID(tt.prefix, links)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want one containing %q", err, tt.wantErr)
+				}
+				if got != uuid.Nil {
+					t.Errorf("got %s alongside an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLinkIDAcceptsShortenID(t *testing.T) {
+	links := linksWithIDs("0badcafe-0000-4000-8000-000000000001", "0badbeef-0000-4000-8000-000000000002")
+
+	for _, link := range links {
+		got, err := ResolveLinkID(ShortenID(link.ID), links)
+		if err != nil || got != link.ID {
+			t.Errorf("ResolveLinkID(ShortenID(%s)) = %s, %v", link.ID, got, err)
+		}
+	}
+}
+
+func TestTruncateURL(t *testing.T) {
+	url := "https://example.com/articles/2024/a-long-path" // 45 characters
+	tests := []struct {
+		maxLen int
+		want   string
+	}{
+		{100, url},
+		{45, url},
+		{44, "https://example.com/articles/2024/a-long-..."},
+		{20, "https://example.c..."},
+		{3, "..."},
+		{2, "ht"},
+		{0, ""},
+	}
+	for _, tt := range tests {
+		if got := TruncateURL(url, tt.maxLen); got != tt.want {
+			t.Errorf("TruncateURL(%d) = %q, want %q", tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateURLMultibyte(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		maxLen int
+		want   string
+	}{
+		{"CJK path", "https://例え.jp/日本語のページ", 18, "https://例え.jp/日..."},
+		{"emoji path", "https://example.com/🎉🎉🎉🎉", 22, "https://example.com..."},
+		{"cut after emoji", "https://x.io/🎉🎉🎉🎉🎉", 16, "https://x.io/..."},
+		{"fits by characters, not bytes", "https://例え.jp/日本", 17, "https://例え.jp/日本"},
+		{"shorter than the ellipsis", "日本語", 2, "日本"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateURL(tt.url, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("TruncateURL(%q, %d) = %q, want %q", tt.url, tt.maxLen, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateURL(%q, %d) = %q, which splits a character", tt.url, tt.maxLen, got)
+			}
+			if n := utf8.RuneCountInString(got); n > tt.maxLen {
+				t.Errorf("TruncateURL(%q, %d) is %d characters long", tt.url, tt.maxLen, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package links
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"link-mgmt/pkg/models"
+)
+
+// markdownEscaper backslash-escapes characters that Markdown reads as inline
+// formatting anywhere in a line
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `|`, `\|`,
+)
+
+// orderedListMarker matches the start of a numbered list item, e.g. "1. " or "2)"
+var orderedListMarker = regexp.MustCompile(`^(\d+)([.)])`)
+
+// EscapeMarkdown escapes Markdown formatting in s so it renders literally,
+// including characters that only matter at the start of a line (headings,
+// list markers)
+func EscapeMarkdown(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = markdownEscaper.Replace(line)
+		trimmed := strings.TrimLeft(line, " ")
+		indent := line[:len(line)-len(trimmed)]
+		switch {
+		case strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, "-"),
+			strings.HasPrefix(trimmed, "+"), strings.HasPrefix(trimmed, "="):
+			line = indent + `\` + trimmed
+		case orderedListMarker.MatchString(trimmed):
+			line = indent + orderedListMarker.ReplaceAllString(trimmed, `$1\$2`)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatMarkdown formats a link as a Markdown snippet for notes: the title as
+// a heading, the URL as a link, the description, and the text as a blockquote
+func FormatMarkdown(link *models.Link) string {
+	var b strings.Builder
+
+	title := link.URL
+	if link.Title != nil && strings.TrimSpace(*link.Title) != "" {
+		title = strings.TrimSpace(*link.Title)
+	}
+	b.WriteString(fmt.Sprintf("## %s\n\n", EscapeMarkdown(title)))
+
+	// Angle brackets let the destination hold spaces and parentheses
+	destination := strings.NewReplacer("<", "%3C", ">", "%3E").Replace(link.URL)
+	b.WriteString(fmt.Sprintf("[%s](<%s>)\n", EscapeMarkdown(link.URL), destination))
+
+	if link.Description != nil && strings.TrimSpace(*link.Description) != "" {
+		b.WriteString("\n" + EscapeMarkdown(strings.TrimSpace(*link.Description)) + "\n")
+	}
+
+	if link.Text != nil && strings.TrimSpace(*link.Text) != "" {
+		b.WriteString("\n")
+		for _, line := range strings.Split(strings.TrimSpace(*link.Text), "\n") {
+			line = strings.TrimRight(line, " \t\r")
+			if line == "" {
+				b.WriteString(">\n")
+				continue
+			}
+			b.WriteString("> " + EscapeMarkdown(line) + "\n")
+		}
+	}
+
+	return b.String()
+}
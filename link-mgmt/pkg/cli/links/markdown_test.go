@@ -0,0 +1,67 @@
+package links
+
+import (
+	"testing"
+
+	"link-mgmt/pkg/models"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"plain", "Plain title", "Plain title"},
+		{"emphasis and code", "*bold* _it_ `code`", "\\*bold\\* \\_it\\_ \\`code\\`"},
+		{"link syntax", "[text](url)", `\[text\](url)`},
+		{"html and tables", "<b>a|b</b>", `\<b\>a\|b\</b\>`},
+		{"backslash", `C:\dir`, `C:\\dir`},
+		{"heading", "# Not a heading", `\# Not a heading`},
+		{"list markers", "- item\n+ item\n  - nested", "\\- item\n\\+ item\n  \\- nested"},
+		{"setext underline", "==", `\==`},
+		{"ordered list", "1. First\n12) Twelfth", "1\\. First\n12\\) Twelfth"},
+		{"markers mid-line are literal", "C# and 1. and a-b", "C# and 1. and a-b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeMarkdown(tt.in); got != tt.want {
+				t.Errorf("EscapeMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMarkdown(t *testing.T) {
+	title := "  # Go *generics* [deep dive]  "
+	description := "Why `T any` isn't <magic>\n1. Really"
+	text := "First paragraph with a_b.\n\n- not a list\nLast line   "
+	link := &models.Link{
+		URL:         "https://example.com/a (b)/<c>",
+		Title:       &title,
+		Description: &description,
+		Text:        &text,
+	}
+
+	want := "## \\# Go \\*generics\\* \\[deep dive\\]\n" +
+		"\n" +
+		"[https://example.com/a (b)/\\<c\\>](<https://example.com/a (b)/%3Cc%3E>)\n" +
+		"\n" +
+		"Why \\`T any\\` isn't \\<magic\\>\n1\\. Really\n" +
+		"\n" +
+		"> First paragraph with a\\_b.\n" +
+		">\n" +
+		"> \\- not a list\n" +
+		"> Last line\n"
+	if got := FormatMarkdown(link); got != want {
+		t.Errorf("FormatMarkdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatMarkdownMinimal(t *testing.T) {
+	blank := "   "
+	link := &models.Link{URL: "https://example.com/page_1", Title: &blank, Description: &blank}
+
+	want := "## https://example.com/page\\_1\n\n[https://example.com/page\\_1](<https://example.com/page_1>)\n"
+	if got := FormatMarkdown(link); got != want {
+		t.Errorf("FormatMarkdown =\n%s\nwant\n%s", got, want)
+	}
+}
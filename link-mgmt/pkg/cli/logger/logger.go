@@ -2,59 +2,191 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 )
 
-var (
-	logger  *log.Logger
-	logFile *os.File
+// Level controls which log messages are written
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelError
 )
 
-func init() {
-	// Create log directory if it doesn't exist
-	logDir := "tmp"
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		// If we can't create log dir, just use stderr
-		logger = log.New(os.Stderr, "[CLI] ", log.LstdFlags|log.Lshortfile)
-		return
+// String returns the lowercase name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel converts a level name (debug/info/error) to a Level
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "", "info":
+		return LevelInfo, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level: %s (expected debug, info, or error)", s)
 	}
+}
 
-	// Create log file with timestamp
-	logFileName := filepath.Join(logDir, fmt.Sprintf("cli-%s.log", time.Now().Format("20060102-150405")))
+// Sink identifies a log output destination
+type Sink string
+
+const (
+	SinkFile   Sink = "file"   // timestamped file under tmp/
+	SinkStderr Sink = "stderr" // standard error
+	SinkSyslog Sink = "syslog" // local syslog (picked up by journald on systemd hosts)
+)
 
-	var err error
-	logFile, err = os.OpenFile(logFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+// ParseSink converts a sink name to a Sink
+func ParseSink(s string) (Sink, error) {
+	switch sink := Sink(strings.ToLower(strings.TrimSpace(s))); sink {
+	case SinkFile, SinkStderr, SinkSyslog:
+		return sink, nil
+	default:
+		return "", fmt.Errorf("unknown log sink: %s (expected file, stderr, or syslog)", s)
+	}
+}
+
+const logPrefix = "[cli] "
+
+var (
+	mu       sync.Mutex
+	logger   *log.Logger
+	closers  []io.Closer
+	minLevel = LevelInfo
+	sinks    = []Sink{SinkFile}
+)
+
+// Configure sets the minimum level and output sinks. Sinks are opened lazily
+// on the first message that passes the level filter, so nothing is created
+// on disk when logging is effectively disabled.
+func Configure(sinkNames []string, levelName string) error {
+	lvl, err := ParseLevel(levelName)
 	if err != nil {
-		// If we can't open log file, use stderr
-		logger = log.New(os.Stderr, "[cli] ", log.LstdFlags|log.Lshortfile)
-		return
+		return err
+	}
+
+	parsed := make([]Sink, 0, len(sinkNames))
+	for _, name := range sinkNames {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		sink, err := ParseSink(name)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, sink)
+	}
+	if len(parsed) == 0 {
+		parsed = []Sink{SinkFile}
 	}
 
-	// Create logger that writes only to file
-	logger = log.New(logFile, "[cli] ", log.LstdFlags|log.Lshortfile)
+	mu.Lock()
+	defer mu.Unlock()
+	closeLocked()
+	minLevel = lvl
+	sinks = parsed
+	return nil
 }
 
-// Log writes a log message
-func Log(format string, v ...interface{}) {
-	if logger != nil {
-		logger.Printf(format, v...)
+// Debug writes a debug-level message (verbose traces such as TUI renders)
+func Debug(format string, v ...interface{}) {
+	output(LevelDebug, fmt.Sprintf(format, v...))
+}
+
+// Info writes an info-level message
+func Info(format string, v ...interface{}) {
+	output(LevelInfo, fmt.Sprintf(format, v...))
+}
+
+// Error writes an error-level message
+func Error(err error, format string, v ...interface{}) {
+	output(LevelError, fmt.Sprintf("%s: %v", fmt.Sprintf(format, v...), err))
+}
+
+// CloseLog closes any open log sinks
+func CloseLog() {
+	mu.Lock()
+	defer mu.Unlock()
+	closeLocked()
+}
+
+func output(lvl Level, msg string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if lvl < minLevel {
+		return
 	}
+	if logger == nil {
+		openLocked()
+	}
+	// calldepth 3: output -> Debug/Info/Error -> caller
+	_ = logger.Output(3, strings.ToUpper(lvl.String())+": "+msg)
 }
 
-// LogError writes an error log message
-func LogError(err error, format string, v ...interface{}) {
-	if logger != nil {
-		msg := fmt.Sprintf(format, v...)
-		logger.Printf("ERROR: %s: %v", msg, err)
+// openLocked builds the logger from the configured sinks. Sinks that fail to
+// open are skipped; if none succeed, stderr is used.
+func openLocked() {
+	var writers []io.Writer
+	for _, sink := range sinks {
+		switch sink {
+		case SinkFile:
+			if f, err := openLogFile(); err == nil {
+				writers = append(writers, f)
+				closers = append(closers, f)
+			}
+		case SinkStderr:
+			writers = append(writers, os.Stderr)
+		case SinkSyslog:
+			if w, err := openSyslog(); err == nil {
+				writers = append(writers, w)
+				closers = append(closers, w)
+			}
+		}
+	}
+	if len(writers) == 0 {
+		writers = append(writers, os.Stderr)
 	}
+
+	logger = log.New(io.MultiWriter(writers...), logPrefix, log.LstdFlags|log.Lshortfile)
 }
 
-// CloseLog closes the log file
-func CloseLog() {
-	if logFile != nil {
-		logFile.Close()
+func closeLocked() {
+	for _, c := range closers {
+		c.Close()
 	}
+	closers = nil
+	logger = nil
+}
+
+// openLogFile creates a timestamped log file in the tmp directory
+func openLogFile() (*os.File, error) {
+	logDir := "tmp"
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return nil, err
+	}
+
+	logFileName := filepath.Join(logDir, fmt.Sprintf("cli-%s.log", time.Now().Format("20060102-150405")))
+	return os.OpenFile(logFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 }
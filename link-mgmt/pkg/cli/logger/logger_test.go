@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Level
+		wantErr bool
+	}{
+		{"debug", LevelDebug, false},
+		{"info", LevelInfo, false},
+		{"error", LevelError, false},
+		{"", LevelInfo, false},
+		{" ERROR ", LevelError, false},
+		{"Debug", LevelDebug, false},
+		{"warn", LevelInfo, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseLevel(%q) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Sink
+		wantErr bool
+	}{
+		{"file", SinkFile, false},
+		{"stderr", SinkStderr, false},
+		{"syslog", SinkSyslog, false},
+		{" Stderr ", SinkStderr, false},
+		{"", "", true},
+		{"journald", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSink(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSink(%q) error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseSink(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { Configure([]string{string(SinkFile)}, "info") })
+
+	tests := []struct {
+		name      string
+		sinks     []string
+		level     string
+		wantSinks []Sink
+		wantLevel Level
+		wantErr   bool
+	}{
+		{"sinks and level", []string{"stderr", "syslog"}, "debug", []Sink{SinkStderr, SinkSyslog}, LevelDebug, false},
+		{"no sinks falls back to file", nil, "error", []Sink{SinkFile}, LevelError, false},
+		{"blank sinks fall back to file", []string{"", "  "}, "", []Sink{SinkFile}, LevelInfo, false},
+		{"blank sinks are skipped", []string{"", "stderr"}, "info", []Sink{SinkStderr}, LevelInfo, false},
+		{"unknown sink", []string{"stderr", "journald"}, "info", nil, 0, true},
+		{"unknown level", []string{"stderr"}, "verbose", nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Start from a known configuration, which an error must leave alone
+			if err := Configure([]string{"stderr"}, "error"); err != nil {
+				t.Fatal(err)
+			}
+
+			err := Configure(tt.sinks, tt.level)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Configure error = %v, want error %v", err, tt.wantErr)
+			}
+			wantSinks, wantLevel := tt.wantSinks, tt.wantLevel
+			if tt.wantErr {
+				wantSinks, wantLevel = []Sink{SinkStderr}, LevelError
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if !slices.Equal(sinks, wantSinks) || minLevel != wantLevel {
+				t.Errorf("configured sinks %v at %s, want %v at %s", sinks, minLevel, wantSinks, wantLevel)
+			}
+		})
+	}
+}
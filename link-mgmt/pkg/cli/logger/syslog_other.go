@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logger
+
+import (
+	"fmt"
+	"io"
+)
+
+// openSyslog is unavailable on this platform
+func openSyslog() (io.WriteCloser, error) {
+	return nil, fmt.Errorf("syslog sink is not supported on this platform")
+}
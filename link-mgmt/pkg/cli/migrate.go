@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"link-mgmt/migrations"
+	"link-mgmt/pkg/db"
+)
+
+// openDatabase connects to database.url directly, for commands that manage
+// the schema rather than going through the API
+func (a *App) openDatabase(ctx context.Context) (*db.DB, error) {
+	queryTimeout := time.Duration(a.cfg.Database.QueryTimeout) * time.Second
+	database, err := db.New(ctx, a.cfg.Database.URL, queryTimeout, db.PoolOptions{MaxConns: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database (check database.url): %w", err)
+	}
+	return database, nil
+}
+
+// Migrate applies the migrations bundled with the binary that the database
+// hasn't had yet
+func (a *App) Migrate() error {
+	ctx := context.Background()
+	database, err := a.openDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	applied, err := database.Migrate(ctx, migrations.FS)
+	for _, version := range applied {
+		fmt.Printf("✓ Applied %s\n", version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		fmt.Println("✓ Database is up to date")
+	} else {
+		fmt.Printf("✓ Applied %d migration(s)\n", len(applied))
+	}
+	return nil
+}
+
+// MigrateStatus lists the bundled migrations and whether each is applied
+func (a *App) MigrateStatus() error {
+	ctx := context.Background()
+	database, err := a.openDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	statuses, err := database.MigrationStatus(ctx, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+			fmt.Printf("  %-16s  %s\n", "pending", status.Version)
+			continue
+		}
+		fmt.Printf("  %-16s  %s\n", status.AppliedAt.Format("2006-01-02 15:04"), status.Version)
+	}
+	fmt.Println()
+	if pending == 0 {
+		fmt.Println("Database is up to date")
+	} else {
+		fmt.Printf("%d pending migration(s); apply them with --migrate\n", pending)
+	}
+	return nil
+}
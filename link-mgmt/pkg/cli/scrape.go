@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"link-mgmt/pkg/scraper"
+)
+
+// startServicesHint explains how to start the local services when they can't be reached
+const startServicesHint = "💡 The services are not running. To start them:\n" +
+	"   From project root: make dev-upd\n" +
+	"   Or: docker compose --profile dev up -d --build\n\n" +
+	"This will start:\n" +
+	"  - Nginx reverse proxy (port 80)\n" +
+	"  - API service (api-dev)\n" +
+	"  - Scraper service (scraper-dev)\n" +
+	"  - PostgreSQL database"
+
+// getScraperService returns a scraper client for the configured scraper URL
+// (scraper.base_url, falling back to cli.base_url)
+func (a *App) getScraperService() (*scraper.ScraperService, error) {
+	baseURL := a.cfg.ScraperBaseURL()
+	if baseURL == "" {
+		return nil, fmt.Errorf("scraper URL not configured. Set it with: --config-set scraper.base_url=<url> (or cli.base_url)")
+	}
+	adapter, err := scraper.AdapterByName(a.cfg.Scraper.Adapter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scraper.adapter: %w", err)
+	}
+
+	scraperService := scraper.NewScraperService(baseURL)
+	scraperService.SetAdapter(adapter)
+	scraperService.SetUserAgent(a.cfg.Scraper.UserAgent)
+	return scraperService, nil
+}
+
+// ScrapeURL scrapes a URL with the scraper service and prints the extracted content
+func (a *App) ScrapeURL(url string) error {
+	scraperService, err := a.getScraperService()
+	if err != nil {
+		return err
+	}
+
+	// Check health first
+	a.statusf("⏳ Checking scraper service... ")
+	if err := scraperService.CheckHealth(); err != nil {
+		a.statusf("✗\n")
+
+		// Provide helpful guidance for connection errors
+		if isConnectionError(err) {
+			return fmt.Errorf("scraper service unavailable: %w\n\n%s", err, startServicesHint)
+		}
+
+		return fmt.Errorf("scraper service unavailable: %w\n\nPlease check if the service is running", err)
+	}
+	a.statusf("✓\n")
+
+	// Scrape the URL
+	a.statusf("⏳ Scraping URL... (this may take a few seconds)\n")
+	timeout := a.cfg.CLI.ScrapeTimeout
+	if timeout <= 0 {
+		timeout = 30
+	}
+	result, err := scraperService.Scrape(url, timeout)
+	if err != nil {
+		return fmt.Errorf("scraping failed: %w", err)
+	}
+
+	if !result.Success {
+		return fmt.Errorf("scraping failed: %s", result.Error)
+	}
+
+	// Display results
+	a.statusf("\n✓ Scraping successful!\n\n")
+	fmt.Printf("URL: %s\n", result.URL)
+	if result.Title != "" {
+		fmt.Printf("Title: %s\n", result.Title)
+	} else {
+		fmt.Println("Title: (no title)")
+	}
+	if result.Description != "" {
+		fmt.Printf("Description: %s\n", result.Description)
+	}
+	if result.Text != "" {
+		previewLength := a.cfg.CLI.PreviewLength
+		if previewLength <= 0 {
+			previewLength = 500
+		}
+		truncated := truncateText(result.Text, previewLength)
+		fmt.Printf("Text: %s\n", truncated)
+		if length := utf8.RuneCountInString(result.Text); length > previewLength {
+			fmt.Printf("\n(Text truncated, full length: %d characters)\n", length)
+		}
+	} else {
+		fmt.Println("Text: (no text content)")
+	}
+	return nil
+}
+
+// isConnectionError reports whether err means nothing is listening at the
+// target address, as opposed to a service that answered with an error
+func isConnectionError(err error) bool {
+	errStr := err.Error()
+	return strings.Contains(errStr, "connection refused") || strings.Contains(errStr, "dial tcp")
+}
+
+// truncateText truncates text to a maximum length in characters, adding
+// ellipsis if truncated. Multibyte characters are never split.
+func truncateText(text string, maxLen int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+	return string(runes[:maxLen]) + "..."
+}
@@ -0,0 +1,309 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// handleScraper registers a healthy scraper that scrapes every URL to title
+func handleScraper(api *testAPI, title string) {
+	api.Handle("GET /scraper/health", respondJSON(http.StatusOK, map[string]string{"status": "ok"}))
+	api.HandleFunc("POST /scrape", func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(http.StatusOK, map[string]interface{}{"success": true, "url": "https://example.com", "title": title})(w, r)
+	})
+}
+
+func TestScrapeURLScraperBaseURL(t *testing.T) {
+	t.Run("scraper.base_url takes precedence", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		scraperAPI, scraperURL := startStubServer(t)
+		handleScraper(scraperAPI, "From Scraper Host")
+		app.cfg.Scraper.BaseURL = scraperURL
+
+		var err error
+		out := captureStdout(t, func() { err = app.ScrapeURL("https://example.com") })
+		if err != nil {
+			t.Fatalf("ScrapeURL: %v", err)
+		}
+		if !strings.Contains(out, "From Scraper Host") {
+			t.Errorf("output doesn't show the scraped title:\n%s", out)
+		}
+		assertCalls(t, scraperAPI, "GET /scraper/health", "POST /scrape")
+		assertCalls(t, api)
+	})
+
+	t.Run("falls back to cli.base_url", func(t *testing.T) {
+		api, app := newTestAPI(t)
+		handleScraper(api, "From API Host")
+		app.cfg.Scraper.BaseURL = ""
+
+		var err error
+		out := captureStdout(t, func() { err = app.ScrapeURL("https://example.com") })
+		if err != nil {
+			t.Fatalf("ScrapeURL: %v", err)
+		}
+		if !strings.Contains(out, "From API Host") {
+			t.Errorf("output doesn't show the scraped title:\n%s", out)
+		}
+		assertCalls(t, api, "GET /scraper/health", "POST /scrape")
+	})
+}
+
+func TestSetConfigValueScraperBaseURL(t *testing.T) {
+	_, app := newTestAPI(t)
+	if err := setConfigValue(app.cfg, "scraper.base_url", "http://scraper.internal:8000"); err != nil {
+		t.Fatalf("setConfigValue: %v", err)
+	}
+	if got := app.cfg.ScraperBaseURL(); got != "http://scraper.internal:8000" {
+		t.Errorf("ScraperBaseURL() = %q, want the configured scraper URL", got)
+	}
+	if got, err := app.GetConfig("scraper.base_url"); err != nil || got != "http://scraper.internal:8000" {
+		t.Errorf("GetConfig(scraper.base_url) = %q, %v; want the configured scraper URL", got, err)
+	}
+}
+
+func TestGetScraperService(t *testing.T) {
+	t.Run("no URL configured", func(t *testing.T) {
+		_, app := newTestAPI(t)
+		app.cfg.CLI.BaseURL = ""
+		app.cfg.Scraper.BaseURL = ""
+
+		service, err := app.getScraperService()
+		if service != nil || err == nil || !strings.Contains(err.Error(), "scraper.base_url") {
+			t.Errorf("getScraperService() = %v, %v; want an error naming scraper.base_url", service, err)
+		}
+		if err := app.ScrapeURL("https://example.com"); err == nil || !strings.Contains(err.Error(), "not configured") {
+			t.Errorf("ScrapeURL error = %v, want the not-configured error", err)
+		}
+	})
+
+	t.Run("unknown adapter", func(t *testing.T) {
+		_, app := newTestAPI(t)
+		app.cfg.Scraper.Adapter = "nope"
+
+		if _, err := app.getScraperService(); err == nil || !strings.Contains(err.Error(), "scraper.adapter") {
+			t.Errorf("getScraperService() error = %v, want an invalid adapter error", err)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		_, app := newTestAPI(t)
+		if service, err := app.getScraperService(); service == nil || err != nil {
+			t.Errorf("getScraperService() = %v, %v; want a service", service, err)
+		}
+	})
+}
+
+func TestTruncateText(t *testing.T) {
+	text := "The quick brown fox" // 19 characters
+	tests := []struct {
+		maxLen int
+		want   string
+	}{
+		{50, text},
+		{19, text},
+		{18, "The quick brown fo..."},
+		{9, "The quick..."},
+		{0, "..."},
+	}
+	for _, tt := range tests {
+		if got := truncateText(text, tt.maxLen); got != tt.want {
+			t.Errorf("truncateText(%d) = %q, want %q", tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeURLPreviewLength(t *testing.T) {
+	text := strings.Repeat("0123456789", 10)
+	tests := []struct {
+		name          string
+		previewLength int
+		wantText      string
+		wantTruncated bool
+	}{
+		{"configured length", 25, "Text: " + text[:25] + "...\n", true},
+		{"unset uses the default", 0, "Text: " + text + "\n", false},
+		{"longer than the text", 200, "Text: " + text + "\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, app := newTestAPI(t)
+			api.Handle("GET /scraper/health", respondJSON(http.StatusOK, map[string]string{"status": "ok"}))
+			api.Handle("POST /scrape", respondJSON(http.StatusOK, map[string]interface{}{"success": true, "url": "https://example.com", "text": text}))
+			app.cfg.CLI.PreviewLength = tt.previewLength
+
+			var err error
+			out := captureStdout(t, func() { err = app.ScrapeURL("https://example.com") })
+			if err != nil {
+				t.Fatalf("ScrapeURL: %v", err)
+			}
+			if !strings.Contains(out, tt.wantText) {
+				t.Errorf("output doesn't contain %q:\n%s", tt.wantText, out)
+			}
+			if got := strings.Contains(out, "full length: 100 characters"); got != tt.wantTruncated {
+				t.Errorf("truncation note shown = %v, want %v:\n%s", got, tt.wantTruncated, out)
+			}
+		})
+	}
+}
+
+func TestSetConfigValueDisplayWidths(t *testing.T) {
+	_, app := newTestAPI(t)
+	if err := setConfigValue(app.cfg, "cli.preview_length", "1200"); err != nil {
+		t.Fatalf("set cli.preview_length: %v", err)
+	}
+	if err := setConfigValue(app.cfg, "cli.url_display_width", "90"); err != nil {
+		t.Fatalf("set cli.url_display_width: %v", err)
+	}
+	if app.cfg.CLI.PreviewLength != 1200 || app.cfg.CLI.URLDisplayWidth != 90 {
+		t.Errorf("preview_length = %d, url_display_width = %d; want 1200, 90", app.cfg.CLI.PreviewLength, app.cfg.CLI.URLDisplayWidth)
+	}
+
+	for _, key := range []string{"cli.preview_length", "cli.url_display_width"} {
+		for _, value := range []string{"0", "-5", "wide"} {
+			if err := setConfigValue(app.cfg, key, value); err == nil {
+				t.Errorf("setConfigValue(%s, %q) succeeded, want an error", key, value)
+			}
+		}
+	}
+	if app.cfg.CLI.PreviewLength != 1200 || app.cfg.CLI.URLDisplayWidth != 90 {
+		t.Errorf("invalid values changed the config: preview_length = %d, url_display_width = %d", app.cfg.CLI.PreviewLength, app.cfg.CLI.URLDisplayWidth)
+	}
+}
+
+func TestTruncateTextMultibyte(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		maxLen int
+		want   string
+	}{
+		{"CJK", "日本語のテキストです", 4, "日本語の..."},
+		{"emoji", "🎉🚀✨🔥💡", 3, "🎉🚀✨..."},
+		{"emoji with text", "Launch 🚀 day", 8, "Launch 🚀..."},
+		{"fits by characters, not bytes", "日本語", 3, "日本語"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateText(tt.text, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("truncateText(%q, %d) = %q, want %q", tt.text, tt.maxLen, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncateText(%q, %d) = %q, which splits a character", tt.text, tt.maxLen, got)
+			}
+		})
+	}
+}
+
+func TestScrapeURLPreviewCountsCharacters(t *testing.T) {
+	text := strings.Repeat("日本語", 10) // 30 characters, 90 bytes
+	api, app := newTestAPI(t)
+	api.Handle("GET /scraper/health", respondJSON(http.StatusOK, map[string]string{"status": "ok"}))
+	api.Handle("POST /scrape", respondJSON(http.StatusOK, map[string]interface{}{"success": true, "url": "https://example.jp", "text": text}))
+
+	app.cfg.CLI.PreviewLength = 40
+	out := captureStdout(t, func() { app.ScrapeURL("https://example.jp") })
+	if !strings.Contains(out, "Text: "+text+"\n") || strings.Contains(out, "truncated") {
+		t.Errorf("30 characters truncated at a 40-character preview:\n%s", out)
+	}
+
+	app.cfg.CLI.PreviewLength = 4
+	out = captureStdout(t, func() { app.ScrapeURL("https://example.jp") })
+	if !strings.Contains(out, "Text: 日本語日...\n") {
+		t.Errorf("preview is not the first 4 characters:\n%s", out)
+	}
+	if !strings.Contains(out, "full length: 30 characters") {
+		t.Errorf("full length not counted in characters:\n%s", out)
+	}
+	if !utf8.ValidString(out) {
+		t.Errorf("output splits a character:\n%s", out)
+	}
+}
+
+func TestScrapeURLQuiet(t *testing.T) {
+	for _, quiet := range []bool{false, true} {
+		api, app := newTestAPI(t)
+		api.Handle("GET /scraper/health", respondJSON(http.StatusOK, map[string]string{"status": "ok"}))
+		api.Handle("POST /scrape", respondJSON(http.StatusOK, map[string]interface{}{"success": true, "url": "https://example.com", "title": "Example"}))
+		app.SetQuiet(quiet)
+
+		var err error
+		out := captureStdout(t, func() { err = app.ScrapeURL("https://example.com") })
+		if err != nil {
+			t.Fatalf("quiet %v: ScrapeURL: %v", quiet, err)
+		}
+		// Quiet output is just the result, starting with the URL
+		if got := strings.HasPrefix(out, "URL: https://example.com\n"); got != quiet {
+			t.Errorf("quiet %v: output starts with the result = %v:\n%s", quiet, got, out)
+		}
+		if !strings.Contains(out, "Title: Example\n") {
+			t.Errorf("quiet %v: output missing the title:\n%s", quiet, out)
+		}
+		for _, decoration := range []string{"⏳", "✓", "Checking scraper service"} {
+			if got := strings.Contains(out, decoration); got == quiet {
+				t.Errorf("quiet %v: output contains %q = %v:\n%s", quiet, decoration, got, out)
+			}
+		}
+	}
+}
+
+func TestScrapeURLTimeout(t *testing.T) {
+	tests := []struct {
+		configured int
+		want       int
+	}{
+		{20, 20000},
+		{0, 30000}, // unset falls back to 30 seconds
+	}
+	for _, tt := range tests {
+		api, app := newTestAPI(t)
+		app.cfg.CLI.ScrapeTimeout = tt.configured
+		api.Handle("GET /scraper/health", respondJSON(http.StatusOK, map[string]string{"status": "ok"}))
+		var sent struct {
+			Timeout int `json:"timeout"`
+		}
+		api.HandleFunc("POST /scrape", func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decoding request: %v", err)
+			}
+			respondJSON(http.StatusOK, map[string]interface{}{"success": true, "url": "https://example.com"})(w, r)
+		})
+
+		var err error
+		captureStdout(t, func() { err = app.ScrapeURL("https://example.com") })
+		if err != nil {
+			t.Fatalf("ScrapeURL: %v", err)
+		}
+		if sent.Timeout != tt.want {
+			t.Errorf("scrape_timeout %d: sent timeout %d ms, want %d", tt.configured, sent.Timeout, tt.want)
+		}
+	}
+}
+
+func TestScrapeURLUserAgent(t *testing.T) {
+	api, app := newTestAPI(t)
+	app.cfg.Scraper.UserAgent = "link-mgmt-test/1.0"
+	api.Handle("GET /scraper/health", respondJSON(http.StatusOK, map[string]string{"status": "ok"}))
+	var sent struct {
+		UserAgent string `json:"user_agent"`
+	}
+	api.HandleFunc("POST /scrape", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		respondJSON(http.StatusOK, map[string]interface{}{"success": true, "url": "https://example.com"})(w, r)
+	})
+
+	var err error
+	captureStdout(t, func() { err = app.ScrapeURL("https://example.com") })
+	if err != nil {
+		t.Fatalf("ScrapeURL: %v", err)
+	}
+	if sent.UserAgent != "link-mgmt-test/1.0" {
+		t.Errorf("sent user_agent %q, want the configured one", sent.UserAgent)
+	}
+}
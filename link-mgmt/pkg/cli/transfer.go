@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"link-mgmt/pkg/cli/links"
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+// TransferLinks moves links from the configured account to the account that
+// targetAPIKey belongs to. ids is "all" or a comma-separated list of full or
+// short IDs. Unless skipConfirm is set, it asks for confirmation on stdin first.
+func (a *App) TransferLinks(ids, targetAPIKey string, skipConfirm bool) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	targetAPIKey = strings.TrimSpace(targetAPIKey)
+	if targetAPIKey == "" {
+		return fmt.Errorf("target API key is required (--to-key)")
+	}
+
+	// Look up the target first, so a bad key fails before anything is resolved
+	target, err := a.newAPIClient(targetAPIKey).GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("failed to check target API key: %w", err)
+	}
+
+	all, err := apiClient.ListLinks()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+
+	linkIDs, err := transferIDs(ids, all)
+	if err != nil {
+		return err
+	}
+	if len(linkIDs) == 0 {
+		links.WriteToStdout(links.FormatEmptyState("No links to transfer."))
+		return nil
+	}
+
+	if !skipConfirm {
+		ok, err := a.confirm(fmt.Sprintf("Move %d link(s) to %s?", len(linkIDs), target.Email))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	transferred, err := apiClient.TransferLinks(linkIDs, targetAPIKey)
+	if err != nil {
+		return fmt.Errorf("failed to transfer links: %w", err)
+	}
+
+	fmt.Printf("✓ Moved %d link(s) to %s\n", transferred, target.Email)
+	return nil
+}
+
+// transferIDs resolves "all" or a comma-separated list of ID prefixes against
+// the user's links, dropping repeats
+func transferIDs(ids string, all []models.Link) ([]uuid.UUID, error) {
+	if strings.EqualFold(strings.TrimSpace(ids), "all") {
+		resolved := make([]uuid.UUID, len(all))
+		for i, link := range all {
+			resolved[i] = link.ID
+		}
+		return resolved, nil
+	}
+
+	var resolved []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range strings.Split(ids, ",") {
+		if strings.TrimSpace(id) == "" {
+			continue
+		}
+		linkID, err := links.ResolveLinkID(id, all)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[linkID] {
+			seen[linkID] = true
+			resolved = append(resolved, linkID)
+		}
+	}
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("link ID is required")
+	}
+	return resolved, nil
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"link-mgmt/pkg/cli/clipboard"
+	"link-mgmt/pkg/cli/tui/managelinks"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeClipboard records what is copied instead of touching the system clipboard
+type fakeClipboard struct {
+	written []string
+	err     error
+}
+
+func (c *fakeClipboard) Write(text string) error {
+	c.written = append(c.written, text)
+	return c.err
+}
+
+// pressKey sends key to m and runs the command it returns, feeding the result back
+func pressKey(m *manageLinksModel, key string) {
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	if cmd != nil {
+		m.Update(cmd())
+	}
+}
+
+func TestCopyURL(t *testing.T) {
+	link := testLink("https://example.com/copy-me", "Copy Me")
+
+	for _, step := range []int{managelinks.StepActionMenu, managelinks.StepViewDetails} {
+		clip := &fakeClipboard{}
+		m := newTestManageLinks(link)
+		m.clipboard = clip
+		m.step = step
+		m.viewedLink = &link
+
+		pressKey(m, "y")
+		if !slices.Equal(clip.written, []string{link.URL}) {
+			t.Errorf("step %v: copied %q, want %q", step, clip.written, link.URL)
+		}
+		if !strings.Contains(m.notice, "Copied!") {
+			t.Errorf("step %v: notice = %q, want a copy confirmation", step, m.notice)
+		}
+	}
+}
+
+func TestCopyURLFailures(t *testing.T) {
+	link := testLink("https://example.com/copy-me", "")
+	tests := []struct {
+		name string
+		clip clipboard.Writer
+		want string
+	}{
+		{"no clipboard", nil, "Can't copy"},
+		{"no clipboard tool", &fakeClipboard{err: clipboard.ErrUnavailable}, "Can't copy"},
+		{"copy fails", &fakeClipboard{err: errors.New("xclip crashed")}, "Copy failed: xclip crashed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManageLinks(link)
+			m.clipboard = tt.clip
+			m.step = managelinks.StepActionMenu
+
+			pressKey(m, "y")
+			if !strings.Contains(m.notice, tt.want) {
+				t.Errorf("notice = %q, want it to contain %q", m.notice, tt.want)
+			}
+			if m.err != nil {
+				t.Errorf("err = %v, want copy failures shown as a notice only", m.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ConfigField is one setting shown in the config editor
+type ConfigField struct {
+	Key    string // "section.key", as --config-set takes it
+	Value  string // Current value, as --config-get prints it
+	Secret bool   // Masked while shown and edited (e.g. the API key)
+}
+
+// ConfigSaveFunc validates and saves the edited values, keyed by
+// "section.key". It saves nothing if any value is invalid.
+type ConfigSaveFunc func(values map[string]string) error
+
+// configSavedMsg carries the result of saving the config
+type configSavedMsg struct {
+	err error
+}
+
+// configEditorModel is the Bubble Tea model for editing config values in place
+type configEditorModel struct {
+	fields []ConfigField
+	inputs []textinput.Model
+	save   ConfigSaveFunc
+
+	fromMenu bool // opened from the root menu, so 'm' can return to it
+
+	focused int
+	saving  bool
+	saved   bool
+	err     error
+}
+
+// NewConfigEditorModel creates a config editor over fields. Enter saves all
+// fields through save, which applies the same rules as --config-set.
+// fromMenu is set when it is opened from the root menu rather than on its own.
+func NewConfigEditorModel(fields []ConfigField, save ConfigSaveFunc, fromMenu bool) tea.Model {
+	inputs := make([]textinput.Model, len(fields))
+	for i, field := range fields {
+		input := textinput.New()
+		input.Prompt = "  "
+		input.CharLimit = 2048
+		input.Width = 60
+		input.SetValue(field.Value)
+		if field.Secret {
+			input.EchoMode = textinput.EchoPassword
+			input.EchoCharacter = '•'
+		}
+		inputs[i] = input
+	}
+
+	editor := &configEditorModel{
+		fields:   fields,
+		inputs:   inputs,
+		save:     save,
+		fromMenu: fromMenu,
+	}
+	editor.focusField(0)
+
+	// Wrap with viewport (the field list is longer than most terminals)
+	return NewViewportWrapper(editor, ViewportConfig{
+		Title:       "Edit Config",
+		ShowHeader:  true,
+		ShowFooter:  true,
+		UseViewport: true,
+		EnableHelp:  true,
+		EnableMenu:  fromMenu,
+		HelpContent: ConfigEditorHelpContent,
+		OnMenu: func() tea.Cmd {
+			// Return command that sends MenuNavigationMsg to return to root menu
+			return func() tea.Msg {
+				return MenuNavigationMsg{}
+			}
+		},
+		MinWidth:  60,
+		MinHeight: 10,
+	})
+}
+
+// Init implements tea.Model.
+func (m *configEditorModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update implements tea.Model.
+func (m *configEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Forward MenuNavigationMsg unchanged (let it bubble up to root)
+	switch msg.(type) {
+	case MenuNavigationMsg:
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case configSavedMsg:
+		m.saving = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.saved = true
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+		if m.saved {
+			// Any other key after saving exits ('m' is handled by the wrapper)
+			cmd, _ := exitOnAnyKey(msg)
+			return m, cmd
+		}
+		if m.saving {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "tab", "down":
+			m.focusField(m.focused + 1)
+			return m, textinput.Blink
+		case "shift+tab", "up":
+			m.focusField(m.focused - 1)
+			return m, textinput.Blink
+		case "enter":
+			m.saving = true
+			m.err = nil
+			return m, m.submit()
+		}
+	}
+
+	if m.saving || m.saved || len(m.inputs) == 0 {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.inputs[m.focused], cmd = m.inputs[m.focused].Update(msg)
+	return m, cmd
+}
+
+// focusField moves the focus to field i, wrapping around at either end
+func (m *configEditorModel) focusField(i int) {
+	if len(m.inputs) == 0 {
+		return
+	}
+	m.inputs[m.focused].Blur()
+	m.focused = (i + len(m.inputs)) % len(m.inputs)
+	m.inputs[m.focused].Focus()
+}
+
+// submit saves every field's value in the background
+func (m *configEditorModel) submit() tea.Cmd {
+	values := make(map[string]string, len(m.fields))
+	for i, field := range m.fields {
+		values[field.Key] = strings.TrimSpace(m.inputs[i].Value())
+	}
+	return func() tea.Msg {
+		return configSavedMsg{err: m.save(values)}
+	}
+}
+
+// IsCapturingInput implements InputCapturer so keys such as 'q', 'm', and '?'
+// can be typed into the fields; after saving they work as usual
+func (m *configEditorModel) IsCapturingInput() bool {
+	return !m.saved
+}
+
+// GetSelectedIndex implements SelectableModel so the viewport follows the focused field
+func (m *configEditorModel) GetSelectedIndex() int {
+	if m.saved {
+		return -1
+	}
+	return m.focused
+}
+
+// GetItemHeight implements SelectableModel: each field is a key line and an input line
+func (m *configEditorModel) GetItemHeight() int {
+	return 2
+}
+
+// GetListHeaderHeight implements SelectableModel: the intro line and a blank line
+func (m *configEditorModel) GetListHeaderHeight() int {
+	return 2
+}
+
+// View implements tea.Model.
+func (m *configEditorModel) View() string {
+	if m.saved {
+		hint := "Press any key to exit..."
+		if m.fromMenu {
+			hint = "Press 'm' for the menu, any other key to exit..."
+		}
+		return "\n" + renderSuccess("Config saved.") + "\n\n" +
+			mutedStyle.Render("Changes to the API connection apply the next time the CLI starts.") + "\n\n" +
+			helpStyle.Render(hint) + "\n"
+	}
+
+	var b strings.Builder
+	// Title is rendered by the viewport wrapper header
+	b.WriteString(boldStyle.Render("Edit the values and press Enter to save:") + "\n\n")
+	for i, field := range m.fields {
+		label := "  " + field.Key
+		if i == m.focused {
+			label = selectedMarkerStyle.Render("→") + " " + field.Key
+		}
+		b.WriteString(fieldLabelStyle.Render(label) + "\n")
+		b.WriteString(m.inputs[i].View() + "\n")
+	}
+
+	b.WriteString("\n")
+	if m.saving {
+		b.WriteString(infoStyle.Render("Saving...") + "\n\n")
+	}
+	if m.err != nil {
+		b.WriteString(renderInlineError(m.err) + "\n\n")
+	}
+	b.WriteString(helpStyle.Render("(↑/↓ or Tab/Shift+Tab to move between fields, Enter to save, Esc to cancel)") + "\n")
+
+	return b.String()
+}
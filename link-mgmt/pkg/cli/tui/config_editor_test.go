@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestConfigEditor returns a config editor over fields that saves through save
+func newTestConfigEditor(fields []ConfigField, save ConfigSaveFunc) *configEditorModel {
+	return NewConfigEditorModel(fields, save, false).(*ViewportWrapper).model.(*configEditorModel)
+}
+
+// updateAndRun sends msg to m and then whatever message its command produces
+func updateAndRun(m *configEditorModel, msg tea.Msg) {
+	if _, cmd := m.Update(msg); cmd != nil {
+		if next := cmd(); next != nil {
+			m.Update(next)
+		}
+	}
+}
+
+func TestConfigEditorSaves(t *testing.T) {
+	fields := []ConfigField{
+		{Key: "cli.base_url", Value: "http://localhost:8080"},
+		{Key: "cli.api_key", Value: "secret-key", Secret: true},
+	}
+	var saved map[string]string
+	m := newTestConfigEditor(fields, func(values map[string]string) error {
+		saved = values
+		return nil
+	})
+
+	if m.inputs[1].EchoMode != textinput.EchoPassword {
+		t.Error("API key field isn't masked")
+	}
+	if strings.Contains(m.View(), "secret-key") {
+		t.Errorf("view shows the API key:\n%s", m.View())
+	}
+
+	// Edit the second field, padded with spaces that are trimmed on save
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.inputs[1].SetValue("  new-key ")
+	updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	want := map[string]string{"cli.base_url": "http://localhost:8080", "cli.api_key": "new-key"}
+	if len(saved) != len(want) || saved["cli.base_url"] != want["cli.base_url"] || saved["cli.api_key"] != want["cli.api_key"] {
+		t.Errorf("saved %v, want %v", saved, want)
+	}
+	if !m.saved || !strings.Contains(m.View(), "Config saved.") {
+		t.Errorf("saved = %v, want the saved confirmation:\n%s", m.saved, m.View())
+	}
+}
+
+func TestConfigEditorShowsSaveError(t *testing.T) {
+	fields := []ConfigField{{Key: "api.log_format", Value: "text"}}
+	m := newTestConfigEditor(fields, func(map[string]string) error {
+		return errors.New("api.log_format: invalid log_format value: xml")
+	})
+
+	m.inputs[0].SetValue("xml")
+	updateAndRun(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.saved || m.saving {
+		t.Fatalf("saved = %v, saving = %v after a rejected save, want both false", m.saved, m.saving)
+	}
+	if !strings.Contains(m.View(), "invalid log_format value") {
+		t.Errorf("view doesn't show the error:\n%s", m.View())
+	}
+	// The edit is kept so it can be corrected
+	if got := m.inputs[0].Value(); got != "xml" {
+		t.Errorf("field = %q after the error, want the edit kept", got)
+	}
+}
+
+func TestConfigEditorFocusWraps(t *testing.T) {
+	fields := []ConfigField{{Key: "a.one"}, {Key: "a.two"}, {Key: "a.three"}}
+	m := newTestConfigEditor(fields, func(map[string]string) error { return nil })
+
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if m.focused != 2 {
+		t.Errorf("Shift+Tab from the first field focused %d, want 2", m.focused)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.focused != 0 {
+		t.Errorf("↓ from the last field focused %d, want 0", m.focused)
+	}
+}
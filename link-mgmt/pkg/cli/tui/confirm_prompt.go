@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"link-mgmt/pkg/utils"
+)
+
+// confirmPrompt is a y/N prompt for destructive actions. Enter submits the
+// answer; only y/yes (any case) accepts, so an empty answer means No.
+// Esc and ctrl+c reject. Opened with OpenCount, it instead accepts only the
+// typed count, for deleting many links at once.
+type confirmPrompt struct {
+	message string
+	count   int // answer required by a count prompt; 0 for y/N
+	input   textinput.Model
+	onYes   func() tea.Cmd
+	onNo    func() tea.Cmd
+}
+
+func newConfirmPrompt() confirmPrompt {
+	input := textinput.New()
+	input.Placeholder = "y/N"
+	input.CharLimit = 3
+	input.Width = 10
+	return confirmPrompt{input: input}
+}
+
+// Open resets the prompt with a new message and callbacks and focuses it.
+// Either callback may be nil.
+func (p *confirmPrompt) Open(message string, onYes, onNo func() tea.Cmd) tea.Cmd {
+	return p.open(message, 0, onYes, onNo)
+}
+
+// OpenCount is Open for a prompt that only accepts count typed out in full
+func (p *confirmPrompt) OpenCount(message string, count int, onYes, onNo func() tea.Cmd) tea.Cmd {
+	return p.open(message, count, onYes, onNo)
+}
+
+func (p *confirmPrompt) open(message string, count int, onYes, onNo func() tea.Cmd) tea.Cmd {
+	p.message = message
+	p.count = count
+	p.onYes = onYes
+	p.onNo = onNo
+	p.input.Placeholder = "y/N"
+	p.input.CharLimit = 3
+	if count > 0 {
+		p.input.Placeholder = strconv.Itoa(count)
+		p.input.CharLimit = len(strconv.Itoa(count))
+	}
+	p.input.Reset()
+	p.input.Focus()
+	return textinput.Blink
+}
+
+// Update handles a message while the prompt is active
+func (p *confirmPrompt) Update(msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "ctrl+c", "esc":
+			return p.resolve(false)
+		case "enter":
+			if p.count > 0 {
+				return p.resolve(utils.IsCountConfirmed(p.input.Value(), p.count))
+			}
+			return p.resolve(utils.IsConfirmed(p.input.Value()))
+		}
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return cmd
+}
+
+func (p *confirmPrompt) resolve(accepted bool) tea.Cmd {
+	p.input.Reset()
+	p.input.Blur()
+
+	callback := p.onNo
+	if accepted {
+		callback = p.onYes
+	}
+	if callback == nil {
+		return nil
+	}
+	return callback()
+}
+
+// View renders the question and answer input
+func (p confirmPrompt) View() string {
+	question := p.message + " (y/N):"
+	if p.count > 0 {
+		question = fmt.Sprintf("%s (type %d to confirm):", p.message, p.count)
+	}
+	return boldStyle.Render(question) + " " + p.input.View() + "\n\n" +
+		helpStyle.Render("(Press Enter to confirm, Esc to cancel)") + "\n"
+}
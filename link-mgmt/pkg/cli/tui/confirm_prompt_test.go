@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"link-mgmt/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// answerPrompt opens a y/N prompt, types answer, presses key and reports
+// which callback ran: "yes", "no", or "" for neither
+func answerPrompt(t *testing.T, answer, key string) string {
+	t.Helper()
+
+	var got string
+	p := newConfirmPrompt()
+	p.Open("Delete it?",
+		func() tea.Cmd { got = "yes"; return nil },
+		func() tea.Cmd { got = "no"; return nil },
+	)
+	if answer != "" {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(answer)})
+	}
+	switch key {
+	case "enter":
+		p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	case "esc":
+		p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	}
+	return got
+}
+
+func TestConfirmPrompt(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		key    string
+		want   string
+	}{
+		{"y accepts", "y", "enter", "yes"},
+		{"Y accepts", "Y", "enter", "yes"},
+		{"yes accepts", "yes", "enter", "yes"},
+		{"empty answer defaults to no", "", "enter", "no"},
+		{"n rejects", "n", "enter", "no"},
+		{"anything else rejects", "ok", "enter", "no"},
+		{"esc rejects even after y", "y", "esc", "no"},
+		{"nothing happens before enter", "y", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := answerPrompt(t, tt.answer, tt.key); got != tt.want {
+				t.Errorf("answered %q then %q: got %q, want %q", tt.answer, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmPromptReopensEmpty(t *testing.T) {
+	p := newConfirmPrompt()
+	p.Open("First?", nil, nil)
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	p.Open("Second?", nil, nil)
+	if got := p.input.Value(); got != "" {
+		t.Errorf("reopened prompt has answer %q, want it empty", got)
+	}
+}
+
+func TestConfirmPromptCount(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"12", true},
+		{"y", false},
+		{"", false},
+		{"11", false},
+	}
+	for _, tt := range tests {
+		var accepted bool
+		p := newConfirmPrompt()
+		p.OpenCount("Delete 12 links?", 12, func() tea.Cmd { accepted = true; return nil }, nil)
+		if !strings.Contains(p.View(), "type 12 to confirm") {
+			t.Errorf("count prompt doesn't ask for the count:\n%s", p.View())
+		}
+		if tt.answer != "" {
+			p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.answer)})
+		}
+		p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if accepted != tt.want {
+			t.Errorf("count prompt answered %q: accepted = %v, want %v", tt.answer, accepted, tt.want)
+		}
+	}
+}
+
+func TestManageLinksBulkDeleteConfirmation(t *testing.T) {
+	tests := []struct {
+		name      string
+		marked    int
+		threshold int
+		wantCount bool
+	}{
+		{"at the threshold asks y/N", 3, 3, false},
+		{"above the threshold asks for the count", 4, 3, true},
+		{"negative threshold always asks y/N", 10, -1, false},
+		{"a single unmarked link asks y/N", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := make([]models.Link, 10)
+			for i := range links {
+				links[i] = testLink(fmt.Sprintf("https://example.com/%d", i), "")
+			}
+			m := newTestManageLinks(links...)
+			m.bulkConfirmThreshold = tt.threshold
+			for _, link := range links[:tt.marked] {
+				m.marked[link.ID] = true
+			}
+
+			m.startDelete()
+			if got := m.confirm.count > 0; got != tt.wantCount {
+				t.Errorf("count prompt = %v, want %v", got, tt.wantCount)
+			}
+			if tt.wantCount && m.confirm.count != tt.marked {
+				t.Errorf("prompt asks for %d, want %d", m.confirm.count, tt.marked)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterDebounceDelay is how long typing must pause before the list filter re-runs
+const filterDebounceDelay = 150 * time.Millisecond
+
+// debounceMsg is delivered when a debouncer's delay has passed
+type debounceMsg struct {
+	debouncer *debouncer
+	seq       int // the Trigger call that scheduled the message
+}
+
+// debouncer collapses a burst of events into one, fired once the events
+// pause for delay. Call Trigger on every event and pass debounceMsg to Ready,
+// which reports true only for the message of the latest Trigger.
+type debouncer struct {
+	delay   time.Duration
+	seq     int // incremented by Trigger and Cancel, so earlier messages die out
+	pending bool
+}
+
+func newDebouncer(delay time.Duration) debouncer {
+	return debouncer{delay: delay}
+}
+
+// Trigger records an event and returns the command that fires after delay
+func (d *debouncer) Trigger() tea.Cmd {
+	d.seq++
+	d.pending = true
+	seq := d.seq
+	return tea.Tick(d.delay, func(time.Time) tea.Msg {
+		return debounceMsg{debouncer: d, seq: seq}
+	})
+}
+
+// Ready reports whether msg is the debounced event to act on now
+func (d *debouncer) Ready(msg debounceMsg) bool {
+	if msg.debouncer != d || msg.seq != d.seq || !d.pending {
+		return false
+	}
+	d.pending = false
+	return true
+}
+
+// Pending reports whether an event is waiting for its delay to pass
+func (d *debouncer) Pending() bool {
+	return d.pending
+}
+
+// Cancel drops the waiting event, e.g. when it is handled right away instead
+func (d *debouncer) Cancel() {
+	d.seq++
+	d.pending = false
+}
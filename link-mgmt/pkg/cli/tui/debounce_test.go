@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDebouncerFiresAfterDelay(t *testing.T) {
+	const delay = 30 * time.Millisecond
+	d := newDebouncer(delay)
+
+	start := time.Now()
+	msg, ok := d.Trigger()().(debounceMsg)
+	if !ok {
+		t.Fatal("Trigger's command didn't deliver a debounceMsg")
+	}
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("fired after %v, want at least %v", elapsed, delay)
+	}
+	if !d.Ready(msg) {
+		t.Error("Ready = false for the only trigger, want true")
+	}
+	if d.Ready(msg) || d.Pending() {
+		t.Error("the same message was ready twice")
+	}
+}
+
+func TestDebouncerOnlyLatestTriggerIsReady(t *testing.T) {
+	d := newDebouncer(time.Millisecond)
+
+	// A burst of triggers: only the last one's message acts
+	var msgs []debounceMsg
+	for range 3 {
+		msgs = append(msgs, d.Trigger()().(debounceMsg))
+	}
+	if !d.Pending() {
+		t.Fatal("Pending = false after triggers, want true")
+	}
+	for i, msg := range msgs[:2] {
+		if d.Ready(msg) {
+			t.Errorf("message of trigger %d was ready, want only the last", i+1)
+		}
+	}
+	if !d.Ready(msgs[2]) {
+		t.Error("message of the last trigger wasn't ready")
+	}
+
+	// Cancelled, even the latest message is dropped
+	msg := d.Trigger()().(debounceMsg)
+	d.Cancel()
+	if d.Pending() || d.Ready(msg) {
+		t.Error("a cancelled trigger still fired")
+	}
+
+	// and another debouncer's messages never apply
+	other := newDebouncer(time.Millisecond)
+	otherMsg := other.Trigger()().(debounceMsg)
+	d.Trigger()
+	if d.Ready(otherMsg) {
+		t.Error("Ready = true for another debouncer's message")
+	}
+}
+
+func TestManageLinksFilterDebounced(t *testing.T) {
+	links := []models.Link{
+		testLink("https://go.dev", "Go"),
+		testLink("https://rust-lang.org", "Rust"),
+	}
+	m := newTestManageLinks(links...)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+
+	// Typing schedules a filter but doesn't run it
+	for _, r := range "rust" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(m.links) != len(links) {
+		t.Fatalf("filtered while typing: %v", linkURLs(m.links))
+	}
+
+	// The final keystroke's message filters on everything typed
+	m.Update(debounceMsg{debouncer: &m.filterDebounce, seq: m.filterDebounce.seq})
+	if got := linkURLs(m.links); len(got) != 1 || got[0] != "https://rust-lang.org" {
+		t.Fatalf("after the pause: links = %v, want only rust-lang.org", got)
+	}
+
+	// Enter applies a pending change right away
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("go")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := linkURLs(m.links); len(got) != 1 || got[0] != "https://go.dev" {
+		t.Errorf("after Enter: links = %v, want only go.dev", got)
+	}
+	if m.filterDebounce.Pending() {
+		t.Error("filter still pending after Enter")
+	}
+}
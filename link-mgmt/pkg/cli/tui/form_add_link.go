@@ -1,8 +1,10 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"link-mgmt/pkg/cli/client"
 	"link-mgmt/pkg/models"
@@ -13,16 +15,26 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// ScraperHealthFunc reports whether the scraper service is reachable. The API
+// does the actual scraping; this only lets the add form skip it up front.
+type ScraperHealthFunc func(ctx context.Context) error
+
+// scraperPreflightTimeout bounds the health check run before review, so an
+// unavailable scraper is noticed in seconds rather than after the full scrape timeout
+const scraperPreflightTimeout = 2 * time.Second
+
 // addLinkForm is the Bubble Tea model for the add-link flow.
 type addLinkForm struct {
 	// Core dependencies
-	client *client.Client
+	client        *client.Client
+	scraperHealth ScraperHealthFunc // optional; nil skips the pre-flight check
 
 	// Inputs
 	urlInput   textinput.Model
 	titleInput textinput.Model
 	descInput  textinput.Model
 	textInput  textarea.Model
+	notesInput textinput.Model
 
 	// Flow / state
 	step          int
@@ -30,9 +42,9 @@ type addLinkForm struct {
 	created       *models.Link
 	currentField  int
 	scrapeEnabled bool
-
-	// Config
-	scrapeTimeoutSeconds int
+	scrape        scrapeController // runs while saving with scraping
+	scrapeSkipped bool             // scraping was turned off because the pre-flight check failed
+	scraperNotice string
 }
 
 const (
@@ -40,11 +52,17 @@ const (
 	stepReview
 	stepSaving
 	stepSuccess
+	stepRescraping // retrying the scrape of a link saved without scraped content
 )
 
+// reviewFieldCount is the number of fields Tab cycles through on the review
+// step: URL, title, description, text, and notes
+const reviewFieldCount = 5
+
 // NewAddLinkForm creates a new add link form model.
 func NewAddLinkForm(
 	apiClient *client.Client,
+	scraperHealth ScraperHealthFunc,
 	scrapeTimeoutSeconds int,
 ) tea.Model {
 	urlInput := textinput.New()
@@ -69,20 +87,24 @@ func NewAddLinkForm(
 	txt.SetHeight(5)
 	txt.CharLimit = 10000
 
-	if scrapeTimeoutSeconds <= 0 {
-		scrapeTimeoutSeconds = 30
-	}
+	notesInput := textinput.New()
+	notesInput.Placeholder = "Private notes (optional, never scraped)"
+	notesInput.CharLimit = models.MaxNotesLength
+	notesInput.Width = 60
 
 	form := &addLinkForm{
-		client:               apiClient,
-		urlInput:             urlInput,
-		titleInput:           titleInput,
-		descInput:            descInput,
-		textInput:            txt,
-		step:                 stepURLInput,
-		currentField:         0,
-		scrapeTimeoutSeconds: scrapeTimeoutSeconds,
-		scrapeEnabled:        true, // Enable scraping by default
+		client:        apiClient,
+		scraperHealth: scraperHealth,
+		urlInput:      urlInput,
+		titleInput:    titleInput,
+		descInput:     descInput,
+		textInput:     txt,
+		notesInput:    notesInput,
+		step:          stepURLInput,
+		currentField:  0,
+		scrapeEnabled: true, // Enable scraping by default
+		// Scraped values never replace what the user typed
+		scrape: newScrapeController(scrapeTimeoutSeconds, mergeFillEmpty),
 	}
 
 	// Wrap with viewport
@@ -119,6 +141,17 @@ type submitSuccessMsg struct {
 	link *models.Link
 }
 
+// rescrapeMsg carries the result of retrying the scrape of the created link.
+type rescrapeMsg struct {
+	link *models.Link
+	err  error
+}
+
+// scraperHealthMsg carries the result of a pre-flight scraper health check.
+type scraperHealthMsg struct {
+	err error
+}
+
 // Update implements tea.Model.
 func (m *addLinkForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Forward MenuNavigationMsg unchanged (let it bubble up to root)
@@ -139,17 +172,43 @@ func (m *addLinkForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleURLInputKey(msg)
 		case stepReview:
 			return m.handleReviewStep(msg)
+		case stepSuccess:
+			if msg.String() == "r" && m.canRetryScrape() {
+				return m, m.retryScrape()
+			}
+			cmd, _ := exitOnAnyKey(msg)
+			return m, cmd
 		}
 
+	case scrapeTickMsg:
+		return m, m.scrape.Update(msg)
+
+	case scraperHealthMsg:
+		m.handleScraperHealth(msg.err)
+		return m, nil
+
 	case submitErrorMsg:
-		m.err = userFacingError(msg.err)
+		if m.scrapeEnabled {
+			m.err = m.scrape.Finish(msg.err)
+		} else {
+			m.err = userFacingError(msg.err)
+		}
 		m.step = stepReview
 		return m, nil
 
 	case submitSuccessMsg:
+		m.scrape.Finish(nil)
 		m.created = msg.link
 		m.step = stepSuccess
 		return m, nil
+
+	case rescrapeMsg:
+		m.err = m.scrape.Finish(msg.err)
+		if msg.link != nil {
+			m.created = msg.link
+		}
+		m.step = stepSuccess
+		return m, nil
 	}
 
 	// Route updates to active input based on step.
@@ -167,8 +226,10 @@ func (m *addLinkForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.descInput, cmd = m.descInput.Update(msg)
 		case 3:
 			m.textInput, cmd = m.textInput.Update(msg)
+		case 4:
+			m.notesInput, cmd = m.notesInput.Update(msg)
 		}
-	case stepSaving, stepSuccess:
+	case stepSaving, stepSuccess, stepRescraping:
 		// No interactive inputs during these steps besides global keys handled above.
 	}
 
@@ -188,6 +249,9 @@ func (m *addLinkForm) handleURLInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.step = stepReview
 		m.currentField = 1
 		m.focusCurrentField()
+		if m.scrapeEnabled {
+			return m, tea.Batch(textinput.Blink, m.checkScraper())
+		}
 		return m, textinput.Blink
 
 	case "s":
@@ -206,17 +270,26 @@ func (m *addLinkForm) handleURLInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *addLinkForm) handleReviewStep(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "tab":
-		m.currentField = (m.currentField + 1) % 4
+		m.currentField = (m.currentField + 1) % reviewFieldCount
 		m.focusCurrentField()
 		return m, textinput.Blink
 	case "shift+tab":
-		m.currentField = (m.currentField - 1 + 4) % 4
+		m.currentField = (m.currentField - 1 + reviewFieldCount) % reviewFieldCount
 		m.focusCurrentField()
 		return m, textinput.Blink
 	case "enter":
 		// Save the link.
 		m.step = stepSaving
+		if m.scrapeEnabled {
+			return m, m.scrape.Start(m.submit())
+		}
 		return m, m.submit()
+	case "ctrl+r":
+		// Retry the scraper after a failed pre-flight check
+		if m.scrapeSkipped {
+			m.scraperNotice = "Checking scraper service..."
+			return m, m.checkScraper()
+		}
 	case "esc":
 		return m, tea.Quit
 	}
@@ -232,15 +305,71 @@ func (m *addLinkForm) handleReviewStep(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.descInput, cmd = m.descInput.Update(msg)
 	case 3:
 		m.textInput, cmd = m.textInput.Update(msg)
+	case 4:
+		m.notesInput, cmd = m.notesInput.Update(msg)
 	}
 	return m, cmd
 }
 
+// canRetryScrape reports whether the created link was saved without scraped
+// content although scraping was wanted (it failed, or the scraper was down).
+func (m *addLinkForm) canRetryScrape() bool {
+	return (m.scrapeEnabled || m.scrapeSkipped) && m.created != nil && m.created.LastScrapedAt == nil
+}
+
+// retryScrape scrapes the created link again. It enriches the saved link
+// rather than resubmitting the form, which would fail as a duplicate.
+func (m *addLinkForm) retryScrape() tea.Cmd {
+	m.err = nil
+	m.step = stepRescraping
+	linkID := m.created.ID
+	timeout, onlyFillEmpty := m.scrape.timeoutSeconds, m.scrape.policy.onlyFillEmpty()
+	return m.scrape.Start(func() tea.Msg {
+		link, err := m.client.EnrichLink(linkID, timeout, onlyFillEmpty)
+		return rescrapeMsg{link: link, err: err}
+	})
+}
+
+// checkScraper runs a short scraper health check in the background.
+func (m *addLinkForm) checkScraper() tea.Cmd {
+	if m.scraperHealth == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), scraperPreflightTimeout)
+		defer cancel()
+		return scraperHealthMsg{err: m.scraperHealth(ctx)}
+	}
+}
+
+// handleScraperHealth turns scraping off when the scraper is clearly down, so
+// saving doesn't wait out the full scrape timeout, and back on after a
+// successful retry. Results arriving after the user has saved are ignored.
+func (m *addLinkForm) handleScraperHealth(err error) {
+	if m.step != stepReview {
+		return
+	}
+	if err != nil {
+		if m.scrapeEnabled || m.scrapeSkipped {
+			m.scrapeEnabled = false
+			m.scrapeSkipped = true
+			m.scraperNotice = "Scraper unavailable; the link will be saved without scraping. Press Ctrl+R to retry."
+		}
+		return
+	}
+	if m.scrapeSkipped {
+		m.scrapeEnabled = true
+		m.scrapeSkipped = false
+		m.scraperNotice = "Scraper is available again; scraping enabled."
+	}
+}
+
 func (m *addLinkForm) focusCurrentField() {
 	m.urlInput.Blur()
 	m.titleInput.Blur()
 	m.descInput.Blur()
 	m.textInput.Blur()
+	m.notesInput.Blur()
 
 	switch m.currentField {
 	case 0:
@@ -251,6 +380,8 @@ func (m *addLinkForm) focusCurrentField() {
 		m.descInput.Focus()
 	case 3:
 		m.textInput.Focus()
+	case 4:
+		m.notesInput.Focus()
 	}
 }
 
@@ -265,6 +396,7 @@ func (m *addLinkForm) submit() tea.Cmd {
 		titleStr := strings.TrimSpace(m.titleInput.Value())
 		descStr := strings.TrimSpace(m.descInput.Value())
 		textStr := strings.TrimSpace(m.textInput.Value())
+		notesStr := strings.TrimSpace(m.notesInput.Value())
 
 		linkCreate := models.LinkCreate{URL: urlStr}
 		if titleStr != "" {
@@ -276,13 +408,17 @@ func (m *addLinkForm) submit() tea.Cmd {
 		if textStr != "" {
 			linkCreate.Text = &textStr
 		}
+		if notesStr != "" {
+			linkCreate.Notes = &notesStr
+		}
 
 		// Use new API endpoint - API handles scraping
 		created, err := m.client.CreateLinkWithScraping(
 			linkCreate,
 			m.scrapeEnabled,
-			m.scrapeTimeoutSeconds,
-			true, // only fill empty
+			m.scrape.timeoutSeconds,
+			m.scrape.policy.onlyFillEmpty(),
+			false, // no reachability check
 		)
 		if err != nil {
 			return submitErrorMsg{err: err}
@@ -295,7 +431,7 @@ func (m *addLinkForm) submit() tea.Cmd {
 // View implements tea.Model.
 func (m *addLinkForm) View() string {
 	switch m.step {
-	case stepSuccess:
+	case stepSuccess, stepRescraping:
 		if m.created != nil {
 			var b strings.Builder
 			b.WriteString("\n")
@@ -303,7 +439,19 @@ func (m *addLinkForm) View() string {
 			b.WriteString("\n\n")
 			b.WriteString(renderLinkDetails(m.created, false))
 			b.WriteString("\n")
-			b.WriteString(helpStyle.Render("Press any key to exit...") + "\n")
+			switch {
+			case m.step == stepRescraping:
+				b.WriteString(infoStyle.Render("Retrying scrape...") + "\n")
+				b.WriteString(m.scrape.View() + "\n")
+			case m.canRetryScrape():
+				if m.err != nil {
+					b.WriteString(renderInlineError(m.err) + "\n\n")
+				}
+				b.WriteString(warningStyle.Render("The link was saved without scraped content.") + "\n")
+				b.WriteString(helpStyle.Render("Press 'r' to retry scraping, Esc to exit") + "\n")
+			default:
+				b.WriteString(helpStyle.Render("Press any key to exit...") + "\n")
+			}
 			return b.String()
 		}
 		return renderSuccessView("Link created successfully!")
@@ -354,6 +502,11 @@ func (m *addLinkForm) renderReview() string {
 	b.WriteString(fieldLabelStyle.Render("URL:"))
 	b.WriteString(" " + m.urlInput.Value() + "\n\n")
 
+	if m.scraperNotice != "" {
+		b.WriteString(warningStyle.Render(m.scraperNotice))
+		b.WriteString("\n\n")
+	}
+
 	// Title field
 	b.WriteString(fieldLabelStyle.Render("Title:"))
 	b.WriteString("\n")
@@ -382,10 +535,26 @@ func (m *addLinkForm) renderReview() string {
 	} else {
 		b.WriteString(m.textInput.View())
 	}
+	b.WriteString("\n\n")
+
+	// Notes field
+	b.WriteString(fieldLabelStyle.Render("Notes (optional):"))
+	b.WriteString("\n")
+	if m.currentField == 4 {
+		b.WriteString(selectedStyle.Render(m.notesInput.View()))
+	} else {
+		b.WriteString(m.notesInput.View())
+	}
 
 	if m.step == stepSaving {
 		b.WriteString("\n\n")
-		b.WriteString(infoStyle.Render("Saving link..."))
+		if m.scrapeEnabled {
+			b.WriteString(infoStyle.Render("Scraping and saving link..."))
+			b.WriteString("\n")
+			b.WriteString(m.scrape.View())
+		} else {
+			b.WriteString(infoStyle.Render("Saving link..."))
+		}
 	}
 
 	if m.err != nil {
@@ -394,7 +563,11 @@ func (m *addLinkForm) renderReview() string {
 	}
 
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("[Tab] Navigate  [Enter] Save  [Esc] Cancel"))
+	if m.scrapeSkipped {
+		b.WriteString(helpStyle.Render("[Tab] Navigate  [Enter] Save  [Ctrl+R] Retry scraper  [Esc] Cancel"))
+	} else {
+		b.WriteString(helpStyle.Render("[Tab] Navigate  [Enter] Save  [Esc] Cancel"))
+	}
 
 	return b.String()
 }
@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/cli/client"
+	"link-mgmt/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestAddLinkForm returns an add-link form, without a client, on the review step
+func newTestAddLinkForm(health ScraperHealthFunc) *addLinkForm {
+	m := NewAddLinkForm(nil, health, 0).(*ViewportWrapper).model.(*addLinkForm)
+	m.step = stepReview
+	return m
+}
+
+func TestHandleScraperHealth(t *testing.T) {
+	down := errors.New("connection refused")
+
+	t.Run("skips scraping when the scraper is down", func(t *testing.T) {
+		m := newTestAddLinkForm(nil)
+		m.handleScraperHealth(down)
+		if m.scrapeEnabled || !m.scrapeSkipped || m.scraperNotice == "" {
+			t.Errorf("enabled %v, skipped %v, notice %q; want scraping skipped with a notice", m.scrapeEnabled, m.scrapeSkipped, m.scraperNotice)
+		}
+
+		// and turns it back on once a retry succeeds
+		m.handleScraperHealth(nil)
+		if !m.scrapeEnabled || m.scrapeSkipped {
+			t.Errorf("after a healthy retry: enabled %v, skipped %v; want scraping back on", m.scrapeEnabled, m.scrapeSkipped)
+		}
+	})
+
+	t.Run("a healthy scraper changes nothing", func(t *testing.T) {
+		m := newTestAddLinkForm(nil)
+		m.handleScraperHealth(nil)
+		if !m.scrapeEnabled || m.scrapeSkipped || m.scraperNotice != "" {
+			t.Errorf("enabled %v, skipped %v, notice %q; want scraping on without a notice", m.scrapeEnabled, m.scrapeSkipped, m.scraperNotice)
+		}
+	})
+
+	t.Run("keeps scraping off when the user turned it off", func(t *testing.T) {
+		m := newTestAddLinkForm(nil)
+		m.scrapeEnabled = false
+		m.handleScraperHealth(down)
+		m.handleScraperHealth(nil)
+		if m.scrapeEnabled || m.scrapeSkipped {
+			t.Errorf("enabled %v, skipped %v; want the user's choice kept", m.scrapeEnabled, m.scrapeSkipped)
+		}
+	})
+
+	t.Run("ignores results after saving", func(t *testing.T) {
+		m := newTestAddLinkForm(nil)
+		m.step = stepSaving
+		m.handleScraperHealth(down)
+		if !m.scrapeEnabled || m.scrapeSkipped {
+			t.Errorf("enabled %v, skipped %v; want the late result ignored", m.scrapeEnabled, m.scrapeSkipped)
+		}
+	})
+}
+
+func TestCheckScraperIsQuick(t *testing.T) {
+	if cmd := newTestAddLinkForm(nil).checkScraper(); cmd != nil {
+		t.Error("checkScraper without a health check returned a command")
+	}
+
+	var deadline time.Time
+	m := newTestAddLinkForm(func(ctx context.Context) error {
+		deadline, _ = ctx.Deadline()
+		return errors.New("down")
+	})
+	msg, ok := m.checkScraper()().(scraperHealthMsg)
+	end := time.Now()
+	if !ok || msg.err == nil {
+		t.Fatalf("checkScraper() = %#v, want the failed health check", msg)
+	}
+	if deadline.IsZero() || deadline.Sub(end) > scraperPreflightTimeout {
+		t.Errorf("health check deadline %v away, want at most %v", deadline.Sub(end), scraperPreflightTimeout)
+	}
+}
+
+// awaitMsg runs the commands in cmd, a batch or a single command, and
+// returns the first message of type T, ignoring the others (such as timer ticks)
+func awaitMsg[T tea.Msg](t *testing.T, cmd tea.Cmd) T {
+	t.Helper()
+
+	msgs := make(chan tea.Msg, 4)
+	var run func(tea.Cmd)
+	run = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, c := range batch {
+				go run(c)
+			}
+			return
+		}
+		msgs <- msg
+	}
+	go run(cmd)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg := <-msgs:
+			if want, ok := msg.(T); ok {
+				return want
+			}
+		case <-timeout:
+			var zero T
+			t.Fatalf("no %T delivered", zero)
+			return zero
+		}
+	}
+}
+
+func TestAddLinkFormRetryScrape(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.Method + " " + r.URL.Path
+		link := testLink("https://example.com/new", "Scraped Title")
+		scraped := time.Now()
+		link.LastScrapedAt = &scraped
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(link)
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestAddLinkForm(nil)
+	m.client = client.NewClient(server.URL, "test-key")
+	created := testLink("https://example.com/new", "")
+	m.created = &created
+	m.scrapeEnabled = true
+	m.step = stepSuccess
+	m.err = errors.New("scraping timed out")
+	if !m.canRetryScrape() {
+		t.Fatal("canRetryScrape() = false for a link saved without scraped content")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil || m.step != stepRescraping || m.err != nil {
+		t.Fatalf("after 'r': step = %v, err = %v, cmd = %v; want rescraping with the error cleared", m.step, m.err, cmd)
+	}
+	if !m.scrape.timer.running {
+		t.Error("scrape timer not started")
+	}
+
+	m.Update(awaitMsg[rescrapeMsg](t, cmd))
+	if want := "POST /api/v1/links/" + created.ID.String() + "/enrich"; path != want {
+		t.Errorf("request = %q, want %q", path, want)
+	}
+	if m.step != stepSuccess || m.err != nil || m.scrape.timer.running {
+		t.Errorf("after the retry: step = %v, err = %v, timer running %v; want success", m.step, m.err, m.scrape.timer.running)
+	}
+	if m.created.LastScrapedAt == nil || m.created.Title == nil || *m.created.Title != "Scraped Title" {
+		t.Errorf("created link = %+v, want the scraped link", m.created)
+	}
+	if m.canRetryScrape() {
+		t.Error("canRetryScrape() = true after a successful retry")
+	}
+}
+
+func TestAddLinkFormSubmitsNotes(t *testing.T) {
+	var sent models.LinkCreate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		created := models.Link{URL: sent.URL, Notes: sent.Notes}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestAddLinkForm(nil)
+	m.client = client.NewClient(server.URL, "test-key")
+	m.scrapeEnabled = false
+	m.urlInput.SetValue("https://example.com/noted")
+
+	// Tab reaches the notes field, the fifth, and wraps back to the URL
+	for range 4 {
+		m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	if m.currentField != 4 || !m.notesInput.Focused() {
+		t.Fatalf("after 4 tabs: field %d (notes focused %v), want the notes field", m.currentField, m.notesInput.Focused())
+	}
+	m.notesInput.SetValue("  check the comments  ")
+
+	msg, ok := m.submit()().(submitSuccessMsg)
+	if !ok {
+		t.Fatal("submit didn't succeed")
+	}
+	if sent.Notes == nil || *sent.Notes != "check the comments" {
+		t.Errorf("sent notes %v, want the trimmed notes", sent.Notes)
+	}
+	if msg.link.Notes == nil {
+		t.Error("created link has no notes")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.currentField != 0 {
+		t.Errorf("tab from notes: field %d, want 0", m.currentField)
+	}
+}
+
+func TestAddLinkFormScrapeKeepsTypedFields(t *testing.T) {
+	// Scraping happens on save and only fills what was left empty, so there
+	// is never a scraped value replacing one the user typed
+	var sent struct {
+		models.LinkCreate
+		Scrape *struct {
+			Enabled       bool `json:"enabled"`
+			OnlyFillEmpty bool `json:"only_fill_empty"`
+		} `json:"scrape"`
+	}
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(models.Link{URL: sent.URL, Title: sent.Title})
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestAddLinkForm(nil)
+	m.client = client.NewClient(server.URL, "test-key")
+	m.urlInput.SetValue("https://example.com/typed")
+	m.titleInput.SetValue("My Title")
+
+	if _, ok := m.submit()().(submitSuccessMsg); !ok {
+		t.Fatal("submit didn't succeed")
+	}
+	if path != "/api/v1/links/with-scraping" {
+		t.Errorf("requested %s, want the with-scraping endpoint", path)
+	}
+	if sent.Title == nil || *sent.Title != "My Title" {
+		t.Errorf("sent title %v, want the typed one", sent.Title)
+	}
+	if sent.Scrape == nil || !sent.Scrape.Enabled || !sent.Scrape.OnlyFillEmpty {
+		t.Errorf("scrape options = %+v, want scraping that only fills empty fields", sent.Scrape)
+	}
+}
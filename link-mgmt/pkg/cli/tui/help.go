@@ -25,7 +25,7 @@ func CommonHelpContent() string {
 // RootMenuHelpContent returns help for root menu
 func RootMenuHelpContent() string {
 	items := []HelpItem{
-		{"1-2", "Select menu option (Add link / Manage links)"},
+		{"1-3", "Select menu option (Add link / Manage links / Edit config)"},
 		{"q / Esc", "Quit"},
 		{"?", "Show this help"},
 	}
@@ -37,10 +37,30 @@ func ManageLinksHelpContent() string {
 	items := []HelpItem{
 		{"↑ / ↓ / j / k", "Navigate link list"},
 		{"Enter", "Select link"},
+		{"Space", "Mark/unmark link for bulk delete"},
+		{"d", "Delete marked links (list view); above cli.bulk_confirm_threshold, type the count to confirm"},
+		{"K / J / Shift+↑ / Shift+↓", "Move link up/down in the saved order (list view, manual order only)"},
+		{"s", "Cycle the sort: manual order, newest first, title A–Z, URL A–Z (list view)"},
+		{"f", "Toggle favorites-only (list view)"},
+		{"u", "Toggle unread-only (list view)"},
+		{"x", "Mark the highlighted link read/unread (list view)"},
+		{"g", "Show only one domain's links, picked from the loaded links' domains; again to show all (list view)"},
+		{"r", "Reload the list from the server (list view)"},
+		{"/", "Filter by title or URL (Esc clears)"},
+		{"Ctrl+U", "Clear filter (list view)"},
 		{"Esc / b", "Go back"},
 		{"1 / v", "View details"},
 		{"2 / d", "Delete link"},
 		{"3 / s", "Scrape & enrich"},
+		{"S", "Re-scrape, overwriting fields the link already has"},
+		{"r", "Retry a failed enrichment"},
+		{"4 / f", "Toggle favorite"},
+		{"5 / o", "Open in browser"},
+		{"6 / y", "Copy URL to clipboard (also 'y' in details)"},
+		{"7 / x", "Mark read/unread"},
+		{"8 / i", "Find links similar to the selected one"},
+		{"M", "Copy the link as Markdown (details view)"},
+		{"t", "Read the full text, scrolling with ↑/↓ and PgUp/PgDn (details view)"},
 		{"m", "Return to menu"},
 		{"q", "Quit"},
 		{"?", "Show this help"},
@@ -54,6 +74,8 @@ func AddLinkFormHelpContent() string {
 		{"Enter", "Start scraping (URL input) / Save link (review)"},
 		{"s", "Skip scraping, go to review"},
 		{"Tab / Shift+Tab", "Navigate fields (review step)"},
+		{"Ctrl+R", "Retry the scraper after it was found unavailable (review step)"},
+		{"r", "Retry scraping a link that was saved without scraped content"},
 		{"Esc", "Cancel / Quit"},
 		{"m", "Return to menu"},
 		{"?", "Show this help"},
@@ -61,6 +83,18 @@ func AddLinkFormHelpContent() string {
 	return renderHelpItems(items)
 }
 
+// ConfigEditorHelpContent returns help for the config editor
+func ConfigEditorHelpContent() string {
+	items := []HelpItem{
+		{"↑ / ↓ / Tab / Shift+Tab", "Move between fields"},
+		{"Enter", "Validate and save all fields"},
+		{"Esc", "Cancel without saving"},
+		{"m", "Return to menu (after saving)"},
+		{"?", "Show this help (after saving)"},
+	}
+	return renderHelpItems(items)
+}
+
 // renderHelpItems formats help items into a readable string
 func renderHelpItems(items []HelpItem) string {
 	var b strings.Builder
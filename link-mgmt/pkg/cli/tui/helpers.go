@@ -1,14 +1,21 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"strings"
+	"unicode/utf8"
 
+	"link-mgmt/pkg/cli/client"
 	"link-mgmt/pkg/models"
 	"link-mgmt/pkg/scraper"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
 )
 
 // renderErrorView renders a standard error view with exit message
@@ -17,6 +24,16 @@ func renderErrorView(err error) string {
 		helpStyle.Render("Press any key to exit...") + "\n"
 }
 
+// exitOnAnyKey handles the key press on a terminal "Press any key to exit..."
+// screen (see renderErrorView and renderSuccessView): any key quits. It
+// reports whether msg was a key press, so callers can return cmd as-is.
+func exitOnAnyKey(msg tea.Msg) (cmd tea.Cmd, handled bool) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		return tea.Quit, true
+	}
+	return nil, false
+}
+
 // renderEmptyState renders a standard empty state message
 func renderEmptyState(message string) string {
 	return "\n" + mutedStyle.Render(message) + "\n\n" +
@@ -43,7 +60,8 @@ type LinkListItem struct {
 
 // renderLinkList renders a selectable list of links with navigation markers
 // maxWidth is used for URL truncation to ensure content fits the viewport
-func renderLinkList(links []models.Link, selected int, title string, subtitle string, maxWidth int) string {
+// marked links (multi-select) are rendered with a check box; pass nil to disable
+func renderLinkList(links []models.Link, selected int, marked map[uuid.UUID]bool, title string, subtitle string, maxWidth int) string {
 	if len(links) == 0 {
 		return renderEmptyState("No links found.")
 	}
@@ -67,6 +85,14 @@ func renderLinkList(links []models.Link, selected int, title string, subtitle st
 			marker = selectedMarkerStyle.Render("→")
 		}
 
+		check := ""
+		if marked != nil {
+			check = "[ ] "
+			if marked[link.ID] {
+				check = selectedMarkerStyle.Render("[x]") + " "
+			}
+		}
+
 		title := formatLinkTitle(link)
 		// Use maxWidth for URL truncation, but leave some margin for formatting
 		urlTruncateWidth := maxWidth - 4
@@ -75,14 +101,27 @@ func renderLinkList(links []models.Link, selected int, title string, subtitle st
 		}
 		url := truncateURL(link.URL, urlTruncateWidth)
 
+		// Read links are dimmed so unread ones stand out
 		var titleStyle lipgloss.Style
-		if i == selected {
+		switch {
+		case i == selected:
 			titleStyle = selectedStyle
-		} else {
+		case link.IsRead:
+			titleStyle = mutedStyle
+		default:
 			titleStyle = linkTitleStyle
 		}
 
-		b.WriteString(fmt.Sprintf("%s %s\n", marker, titleStyle.Render(title)))
+		star := ""
+		if link.IsFavorite {
+			star = favoriteStyle.Render("★") + " "
+		}
+		read := ""
+		if link.IsRead {
+			read = mutedStyle.Render("✓") + " "
+		}
+
+		b.WriteString(fmt.Sprintf("%s %s%s%s%s\n", marker, check, star, read, titleStyle.Render(title)))
 		b.WriteString(fmt.Sprintf("  %s\n", linkURLStyle.Render(url)))
 	}
 
@@ -98,12 +137,63 @@ func formatLinkTitle(link models.Link) string {
 	return "(no title)"
 }
 
-// truncateURL truncates a URL to the specified max length
+// visibleWindow returns the [start, end) range of a list of total items that
+// fits in size rows. The window moves as little as possible from offset while
+// keeping selected in view, so scrolling only happens at the window edges.
+func visibleWindow(selected, offset, total, size int) (start, end int) {
+	if size < 1 {
+		size = 1
+	}
+	if total <= size {
+		return 0, total
+	}
+
+	if selected < offset {
+		offset = selected
+	} else if selected >= offset+size {
+		offset = selected - size + 1
+	}
+	if offset > total-size {
+		offset = total - size
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset, offset + size
+}
+
+// filterLinks returns the links whose title or URL contains term (case-insensitive)
+// An empty term returns links unchanged
+func filterLinks(links []models.Link, term string) []models.Link {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return links
+	}
+
+	filtered := make([]models.Link, 0, len(links))
+	for _, link := range links {
+		if strings.Contains(strings.ToLower(link.URL), term) {
+			filtered = append(filtered, link)
+			continue
+		}
+		if link.Title != nil && strings.Contains(strings.ToLower(*link.Title), term) {
+			filtered = append(filtered, link)
+		}
+	}
+	return filtered
+}
+
+// truncateURL truncates a URL to the specified max length in characters,
+// never splitting a multibyte character
 func truncateURL(url string, maxLen int) string {
-	if len(url) <= maxLen {
+	runes := []rune(url)
+	if len(runes) <= maxLen {
 		return url
 	}
-	return url[:maxLen-3] + "..."
+	if maxLen < 3 {
+		return string(runes[:max(maxLen, 0)])
+	}
+	return string(runes[:maxLen-3]) + "..."
 }
 
 // renderLinkDetails renders common link details (ID, URL, Title, Created date)
@@ -141,9 +231,14 @@ func renderLinkDetails(link *models.Link, includeUserID bool) string {
 	return b.String()
 }
 
+// defaultPreviewLength is how much link text the detail view shows when no
+// preview length is configured
+const defaultPreviewLength = 500
+
 // renderLinkDetailsFull renders all link details including description and text
-// maxWidth is used for text wrapping to ensure content fits the viewport
-func renderLinkDetailsFull(link *models.Link, maxWidth int) string {
+// maxWidth is used for text wrapping to ensure content fits the viewport, and
+// text longer than previewLength is truncated
+func renderLinkDetailsFull(link *models.Link, maxWidth, previewLength int) string {
 	if link == nil {
 		return ""
 	}
@@ -155,6 +250,24 @@ func renderLinkDetailsFull(link *models.Link, maxWidth int) string {
 	var b strings.Builder
 	b.WriteString(renderLinkDetails(link, true))
 
+	// Site metadata (populated by scraping)
+	if link.SiteName != nil && *link.SiteName != "" {
+		b.WriteString(fieldLabelStyle.Render("Site:"))
+		b.WriteString(fmt.Sprintf(" %s\n", *link.SiteName))
+	}
+	if link.Favicon != nil && *link.Favicon != "" {
+		b.WriteString(fieldLabelStyle.Render("Favicon:"))
+		b.WriteString(fmt.Sprintf(" %s\n", linkURLStyle.Render(*link.Favicon)))
+	}
+	if link.ImageURL != nil && *link.ImageURL != "" {
+		b.WriteString(fieldLabelStyle.Render("Image:"))
+		b.WriteString(fmt.Sprintf(" %s\n", linkURLStyle.Render(*link.ImageURL)))
+	}
+	if link.VisitCount > 0 {
+		b.WriteString(fieldLabelStyle.Render("Visits:"))
+		b.WriteString(fmt.Sprintf(" %d\n", link.VisitCount))
+	}
+
 	// Description
 	b.WriteString(fieldLabelStyle.Render("Description:"))
 	if link.Description != nil && *link.Description != "" {
@@ -169,6 +282,16 @@ func renderLinkDetailsFull(link *models.Link, maxWidth int) string {
 		b.WriteString(" " + mutedStyle.Render("(not set)") + "\n")
 	}
 
+	// Notes (the user's own, shown only when set)
+	if link.Notes != nil && *link.Notes != "" {
+		wrapWidth := maxWidth - 2
+		if wrapWidth < 40 {
+			wrapWidth = 40 // Minimum
+		}
+		b.WriteString(fieldLabelStyle.Render("Notes:"))
+		b.WriteString(wrapText(*link.Notes, wrapWidth, " "))
+	}
+
 	// Text
 	b.WriteString(fieldLabelStyle.Render("Text:"))
 	if link.Text != nil && *link.Text != "" {
@@ -177,13 +300,14 @@ func renderLinkDetailsFull(link *models.Link, maxWidth int) string {
 		if wrapWidth < 40 {
 			wrapWidth = 40 // Minimum
 		}
-		if len(text) > 500 {
-			preview := text[:500]
-			if lastSpace := strings.LastIndex(preview, " "); lastSpace > 400 {
+		if length := utf8.RuneCountInString(text); length > previewLength {
+			preview := string([]rune(text)[:previewLength])
+			// Break at a word unless that would drop more than a fifth of the preview
+			if lastSpace := strings.LastIndex(preview, " "); utf8.RuneCountInString(preview[:max(lastSpace, 0)]) > previewLength*4/5 {
 				preview = preview[:lastSpace]
 			}
 			b.WriteString(fmt.Sprintf(" %s...\n", preview))
-			b.WriteString(fmt.Sprintf("  %s\n", mutedStyle.Render(fmt.Sprintf("(truncated, full length: %d characters)", len(text)))))
+			b.WriteString(fmt.Sprintf("  %s\n", mutedStyle.Render(fmt.Sprintf("(truncated, full length: %d characters)", length))))
 		} else {
 			b.WriteString(wrapText(text, wrapWidth, " "))
 		}
@@ -198,30 +322,60 @@ func renderLinkDetailsFull(link *models.Link, maxWidth int) string {
 	return b.String()
 }
 
-// wrapText wraps text to a specified width, breaking at word boundaries
+// wrapText wraps text to a specified width, breaking at word boundaries.
+// Paragraphs (separated by blank lines) are wrapped on their own and kept
+// apart by a blank line; other line breaks and runs of spaces collapse.
 func wrapText(text string, width int, indent string) string {
-	words := strings.Fields(text)
-	if len(words) == 0 {
+	paragraphs := splitParagraphs(text)
+	if len(paragraphs) == 0 {
 		return indent + "\n"
 	}
 
 	var b strings.Builder
-	line := ""
-	for _, word := range words {
-		if len(line)+len(word)+1 > width {
+	for i, words := range paragraphs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		line := ""
+		for _, word := range words {
+			if line != "" && utf8.RuneCountInString(line)+utf8.RuneCountInString(word)+1 > width {
+				b.WriteString(fmt.Sprintf("%s%s\n", indent, line))
+				line = word
+			} else {
+				if line != "" {
+					line += " "
+				}
+				line += word
+			}
+		}
+		if line != "" {
 			b.WriteString(fmt.Sprintf("%s%s\n", indent, line))
-			line = word
-		} else {
-			if line != "" {
-				line += " "
+		}
+	}
+	return b.String()
+}
+
+// splitParagraphs splits text at blank (or whitespace-only) lines into the
+// words of each paragraph. Leading, trailing, and repeated blank lines add
+// no empty paragraphs.
+func splitParagraphs(text string) [][]string {
+	var paragraphs [][]string
+	var words []string
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			if len(words) > 0 {
+				paragraphs = append(paragraphs, words)
+				words = nil
 			}
-			line += word
+			continue
 		}
+		words = append(words, fields...)
 	}
-	if line != "" {
-		b.WriteString(fmt.Sprintf("%s%s\n", indent, line))
+	if len(words) > 0 {
+		paragraphs = append(paragraphs, words)
 	}
-	return b.String()
+	return paragraphs
 }
 
 // handleListNavigation handles common navigation keys for list views (up/down/j/k)
@@ -275,8 +429,31 @@ func renderInlineError(err error) string {
 	return renderError(err.Error())
 }
 
-// userFacingError converts structured scraper errors into friendly messages,
-// while leaving other error types unchanged.
+// scrapeTimeoutError explains a scrape that ran past its timeout, distinct
+// from other failures so users know to retry or raise the timeout
+func scrapeTimeoutError(timeoutSeconds int) error {
+	return fmt.Errorf("scraping timed out after %ds. The page may be slow to load; try again or raise it with: --config-set cli.scrape_timeout=<seconds>", timeoutSeconds)
+}
+
+// isTimeoutError reports whether err is a scrape, gateway, or request timeout
+func isTimeoutError(err error) bool {
+	var scraperErr *scraper.ScraperError
+	if errors.As(err, &scraperErr) && scraperErr.Type == scraper.ErrorTypeTimeout {
+		return true
+	}
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusGatewayTimeout {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// userFacingError converts structured scraper and API errors into friendly
+// messages, while leaving other error types unchanged.
 func userFacingError(err error) error {
 	if err == nil {
 		return nil
@@ -287,5 +464,10 @@ func userFacingError(err error) error {
 		return errors.New(scraperErr.UserMessage())
 	}
 
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return errors.New(apiErr.UserMessage())
+	}
+
 	return err
 }
@@ -0,0 +1,417 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"link-mgmt/pkg/cli/logger"
+	"link-mgmt/pkg/cli/tui/managelinks"
+	"link-mgmt/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// TestMain sends log output to stderr, so the error paths these tests take
+// don't leave log files under tmp/ in the source tree
+func TestMain(m *testing.M) {
+	if err := logger.Configure([]string{"stderr"}, "error"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// testLink returns a link with a fresh ID, the given URL, and title (nil if
+// title is "")
+func testLink(url, title string) models.Link {
+	link := models.Link{ID: uuid.New(), URL: url}
+	if title != "" {
+		link.Title = &title
+	}
+	return link
+}
+
+// linkURLs returns the URLs of links in order
+func linkURLs(links []models.Link) []string {
+	urls := make([]string, len(links))
+	for i, link := range links {
+		urls[i] = link.URL
+	}
+	return urls
+}
+
+// newTestManageLinks returns a manage-links model, without a client, that has
+// loaded links
+func newTestManageLinks(links ...models.Link) *manageLinksModel {
+	m := NewManageLinksModel(nil, nil, nil, 0, 0, 0).(*ViewportWrapper).model.(*manageLinksModel)
+	m.allLinks = links
+	m.applyFilters()
+	m.ready = true
+	return m
+}
+
+func TestFilterLinks(t *testing.T) {
+	links := []models.Link{
+		testLink("https://go.dev/doc", "Go Documentation"),
+		testLink("https://example.com/golang", ""),
+		testLink("https://news.example.org", "Daily News"),
+		testLink("https://blog.example.net/post", "Learning GO"),
+	}
+
+	tests := []struct {
+		name string
+		term string
+		want []string
+	}{
+		{"empty term", "", linkURLs(links)},
+		{"whitespace term", "   ", linkURLs(links)},
+		{"title match is case-insensitive", "documentation", []string{"https://go.dev/doc"}},
+		{"url match", "news.example", []string{"https://news.example.org"}},
+		{"link without title matches on url", "golang", []string{"https://example.com/golang"}},
+		{"title or url, in list order", "go", []string{"https://go.dev/doc", "https://example.com/golang", "https://blog.example.net/post"}},
+		{"term is trimmed", "  daily ", []string{"https://news.example.org"}},
+		{"no match", "rust", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := linkURLs(filterLinks(links, tt.term))
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterLinks(%q) = %v, want %v", tt.term, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("filterLinks(%q) = %v, want %v", tt.term, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyFiltersKeepsSelection(t *testing.T) {
+	links := []models.Link{
+		testLink("https://a.example", "Alpha"),
+		testLink("https://b.example", "Beta"),
+		testLink("https://c.example", "Gamma alpha"),
+	}
+	m := newTestManageLinks(links...)
+	m.selected = 2
+
+	// The selected link stays selected when the filter keeps it
+	m.filterInput.SetValue("alpha")
+	m.applyFilters()
+	if len(m.links) != 2 || m.links[m.selected].ID != links[2].ID {
+		t.Fatalf("selected %d of %v, want %s", m.selected, linkURLs(m.links), links[2].URL)
+	}
+
+	// and the selection is clamped into range when it doesn't
+	m.filterInput.SetValue("beta")
+	m.applyFilters()
+	if m.selected != 0 || len(m.links) != 1 {
+		t.Fatalf("selected %d of %v, want 0 of 1", m.selected, linkURLs(m.links))
+	}
+
+	// Clearing the filter shows every link again
+	m.filterInput.Reset()
+	m.applyFilters()
+	if len(m.links) != len(links) || m.links[m.selected].ID != links[1].ID {
+		t.Fatalf("selected %d of %v, want %s", m.selected, linkURLs(m.links), links[1].URL)
+	}
+}
+
+func TestVisibleWindow(t *testing.T) {
+	tests := []struct {
+		name                          string
+		selected, offset, total, size int
+		wantStart, wantEnd            int
+	}{
+		{"list fits", 3, 0, 5, 10, 0, 5},
+		{"empty list", 0, 0, 0, 10, 0, 0},
+		{"selection inside window", 4, 2, 20, 5, 2, 7},
+		{"selection at bottom edge", 6, 2, 20, 5, 2, 7},
+		{"scrolls down past bottom edge", 7, 2, 20, 5, 3, 8},
+		{"scrolls up past top edge", 1, 2, 20, 5, 1, 6},
+		{"jump to end", 19, 0, 20, 5, 15, 20},
+		{"jump to start", 0, 15, 20, 5, 0, 5},
+		{"offset beyond end after shrink", 3, 15, 8, 5, 3, 8},
+		{"size below one shows one row", 4, 0, 10, 0, 4, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := visibleWindow(tt.selected, tt.offset, tt.total, tt.size)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("visibleWindow(%d, %d, %d, %d) = [%d, %d), want [%d, %d)",
+					tt.selected, tt.offset, tt.total, tt.size, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestManageLinksKeepsSelectionInView(t *testing.T) {
+	links := make([]models.Link, 50)
+	for i := range links {
+		links[i] = testLink(fmt.Sprintf("https://example.com/%d", i), "")
+	}
+	m := newTestManageLinks(links...)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	size := m.listPageSize()
+	if size >= len(links) {
+		t.Fatalf("listPageSize() = %d, want fewer than %d links per page", size, len(links))
+	}
+	for range len(links) - 1 {
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		start, end := visibleWindow(m.selected, m.offset, len(m.links), size)
+		if m.selected < start || m.selected >= end {
+			t.Fatalf("selected %d outside window [%d, %d)", m.selected, start, end)
+		}
+	}
+	if m.selected != len(links)-1 || m.offset != len(links)-size {
+		t.Errorf("selected %d at offset %d, want %d at offset %d", m.selected, m.offset, len(links)-1, len(links)-size)
+	}
+	if got := strings.Count(m.renderList(), "https://example.com/"); got != size {
+		t.Errorf("rendered %d links, want %d", got, size)
+	}
+}
+
+func TestTruncateURL(t *testing.T) {
+	url := "https://example.com/articles/2024/a-long-path" // 45 characters
+	tests := []struct {
+		maxLen int
+		want   string
+	}{
+		{60, url},
+		{45, url},
+		{30, "https://example.com/article..."},
+		{3, "..."},
+		{1, "h"},
+	}
+	for _, tt := range tests {
+		if got := truncateURL(url, tt.maxLen); got != tt.want {
+			t.Errorf("truncateURL(%d) = %q, want %q", tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+func TestRenderLinkDetailsFullPreviewLength(t *testing.T) {
+	link := testLink("https://example.com/long-read", "Long Read")
+	text := strings.Repeat("word ", 200) // 1000 characters
+	link.Text = &text
+
+	tests := []struct {
+		previewLength int
+		truncated     bool
+	}{
+		{100, true},
+		{defaultPreviewLength, true},
+		{2000, false},
+	}
+	for _, tt := range tests {
+		out := renderLinkDetailsFull(&link, 80, tt.previewLength)
+		if got := strings.Contains(out, "full length: 1000 characters"); got != tt.truncated {
+			t.Errorf("previewLength %d: truncated = %v, want %v", tt.previewLength, got, tt.truncated)
+		}
+		if !tt.truncated {
+			continue
+		}
+		// The preview breaks at a word within the configured length
+		shown := strings.Count(out, "word")
+		if shown > tt.previewLength/5 || shown < tt.previewLength*4/5/5 {
+			t.Errorf("previewLength %d: showed %d words, want about %d", tt.previewLength, shown, tt.previewLength/5)
+		}
+	}
+}
+
+func TestTruncateURLMultibyte(t *testing.T) {
+	tests := []struct {
+		url    string
+		maxLen int
+		want   string
+	}{
+		{"https://例え.jp/日本語のページ", 18, "https://例え.jp/日..."},
+		{"https://x.io/🎉🎉🎉🎉🎉", 16, "https://x.io/..."},
+		{"https://x.io/🎉🎉🎉🎉🎉", 17, "https://x.io/🎉..."},
+		{"https://例え.jp/日本", 16, "https://例え.jp/日本"},
+	}
+	for _, tt := range tests {
+		got := truncateURL(tt.url, tt.maxLen)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("truncateURL(%q, %d) = %q, want %q", tt.url, tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+func TestRenderLinkDetailsFullMultibytePreview(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		previewLength int
+		wantPreview   string
+	}{
+		{"CJK without spaces", strings.Repeat("日本語", 10), 5, " 日本語日本..."},
+		{"emoji", strings.Repeat("🎉🚀", 10), 5, " 🎉🚀🎉🚀🎉..."},
+		{"breaks at a word", "これは テスト です " + strings.Repeat("長", 20), 8, " これは テスト..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link := testLink("https://example.jp", "")
+			link.Text = &tt.text
+			out := renderLinkDetailsFull(&link, 80, tt.previewLength)
+			if !utf8.ValidString(out) {
+				t.Fatalf("details split a character:\n%s", out)
+			}
+			if !strings.Contains(out, tt.wantPreview+"\n") {
+				t.Errorf("details don't show preview %q:\n%s", tt.wantPreview, out)
+			}
+			want := fmt.Sprintf("full length: %d characters", utf8.RuneCountInString(tt.text))
+			if !strings.Contains(out, want) {
+				t.Errorf("details don't show %q:\n%s", want, out)
+			}
+		})
+	}
+}
+
+func TestRenderLinkDetailsFullImageURL(t *testing.T) {
+	link := testLink("https://example.com/article", "Article")
+	if out := renderLinkDetailsFull(&link, 80, defaultPreviewLength); strings.Contains(out, "Image:") {
+		t.Errorf("details show an image field for a link without one:\n%s", out)
+	}
+
+	image := "https://example.com/og.webp"
+	link.ImageURL = &image
+	out := renderLinkDetailsFull(&link, 80, defaultPreviewLength)
+	if !strings.Contains(out, "Image:") || !strings.Contains(out, image) {
+		t.Errorf("details don't show the image URL:\n%s", out)
+	}
+}
+
+func TestRenderLinkDetailsFullNotes(t *testing.T) {
+	link := testLink("https://example.com/article", "Article")
+	if out := renderLinkDetailsFull(&link, 80, defaultPreviewLength); strings.Contains(out, "Notes:") {
+		t.Errorf("details show a notes field for a link without notes:\n%s", out)
+	}
+
+	notes := "Revisit after the release"
+	link.Notes = &notes
+	out := renderLinkDetailsFull(&link, 80, defaultPreviewLength)
+	if !strings.Contains(out, "Notes:") || !strings.Contains(out, notes) {
+		t.Errorf("details don't show the notes:\n%s", out)
+	}
+}
+
+// quits reports whether cmd quits the program
+func quits(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func TestExitOnAnyKey(t *testing.T) {
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("q")},
+		{Type: tea.KeyRunes, Runes: []rune("x")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeySpace},
+		{Type: tea.KeyDown},
+	}
+	for _, key := range keys {
+		cmd, handled := exitOnAnyKey(key)
+		if !handled || !quits(cmd) {
+			t.Errorf("exitOnAnyKey(%q) = quits %v, handled %v; want it to quit", key, quits(cmd), handled)
+		}
+	}
+
+	for _, msg := range []tea.Msg{tea.WindowSizeMsg{Width: 80, Height: 24}, nil} {
+		if cmd, handled := exitOnAnyKey(msg); cmd != nil || handled {
+			t.Errorf("exitOnAnyKey(%T) handled a message that isn't a key press", msg)
+		}
+	}
+}
+
+func TestAnyKeyExitScreens(t *testing.T) {
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+	link := testLink("https://example.com/article", "Article")
+
+	t.Run("add link success", func(t *testing.T) {
+		m := newTestAddLinkForm(nil)
+		m.step = stepSuccess
+		if _, cmd := m.Update(key); !quits(cmd) {
+			t.Error("key press didn't quit")
+		}
+	})
+
+	t.Run("manage links done", func(t *testing.T) {
+		m := newTestManageLinks(link)
+		m.step = managelinks.StepDone
+		if _, cmd := m.Update(key); !quits(cmd) {
+			t.Error("key press didn't quit")
+		}
+	})
+
+	t.Run("manage links error view", func(t *testing.T) {
+		m := newTestManageLinks(link)
+		m.err = errors.New("boom")
+		if !strings.Contains(m.View(), "Press any key to exit") {
+			t.Fatalf("error view not shown:\n%s", m.View())
+		}
+		if _, cmd := m.Update(key); !quits(cmd) {
+			t.Error("key press didn't quit")
+		}
+	})
+
+	t.Run("config editor saved", func(t *testing.T) {
+		m := newTestConfigEditor([]ConfigField{{Key: "cli.base_url", Value: "http://localhost:8080"}}, nil)
+		m.saved = true
+		if _, cmd := m.Update(key); !quits(cmd) {
+			t.Error("key press didn't quit")
+		}
+	})
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{"empty", "", 20, "  \n"},
+		{"only blank lines", "  \n \n\t\n", 20, "  \n"},
+		{"fits", "one two three", 20, "  one two three\n"},
+		{"wraps at words", "alpha beta gamma delta", 12, "  alpha beta\n  gamma delta\n"},
+		{"keeps paragraphs", "First paragraph.\n\nSecond paragraph.", 40, "  First paragraph.\n\n  Second paragraph.\n"},
+		{"wraps within paragraphs", "alpha beta gamma\n\ndelta epsilon", 12, "  alpha beta\n  gamma\n\n  delta\n  epsilon\n"},
+		{"single line breaks fold", "line one\nline two", 40, "  line one line two\n"},
+		{"whitespace-only line separates", "A\n   \nB", 40, "  A\n\n  B\n"},
+		{"extra blank lines and CRLF", "\r\n\nA\r\n\r\n\r\nB\n\n", 40, "  A\n\n  B\n"},
+		{"counts characters, not bytes", "日本語 日本語 日本語", 8, "  日本語 日本語\n  日本語\n"},
+		{"long first word", "https://example.com/a/long/path then", 12, "  https://example.com/a/long/path\n  then\n"},
+		{"long first word in a later paragraph", "Intro.\n\nhttps://example.com/a/long/path then", 12, "  Intro.\n\n  https://example.com/a/long/path\n  then\n"},
+		{"long word mid-line", "see https://example.com/a/long/path", 12, "  see\n  https://example.com/a/long/path\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.width, "  "); got != tt.want {
+				t.Errorf("wrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderLinkDetailsFullKeepsParagraphs(t *testing.T) {
+	link := testLink("https://example.com/article", "Article")
+	text := "The first paragraph.\n\nThe second paragraph."
+	link.Text = &text
+
+	out := renderLinkDetailsFull(&link, 80, defaultPreviewLength)
+	if !strings.Contains(out, "The first paragraph.\n\n") || !strings.Contains(out, "The second paragraph.") {
+		t.Errorf("details lost the paragraph break:\n%s", out)
+	}
+}
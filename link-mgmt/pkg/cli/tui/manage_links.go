@@ -1,61 +1,169 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
+	"link-mgmt/pkg/cli/browser"
 	"link-mgmt/pkg/cli/client"
+	"link-mgmt/pkg/cli/clipboard"
+	"link-mgmt/pkg/cli/links"
 	"link-mgmt/pkg/cli/logger"
 	"link-mgmt/pkg/cli/tui/managelinks"
 	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/scraper"
+	"link-mgmt/pkg/utils"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
 )
 
+// linkSort is an order the manage-links list can be shown in. Links are
+// fetched a page at a time, so the API does the sorting.
+type linkSort struct {
+	label string             // shown in the list header, e.g. "title A–Z"
+	opts  models.ListOptions // SortBy and Order sent to the API
+}
+
+// linkSorts are the orders 's' cycles through in the list view, starting
+// with the manual order, which is the only one links can be moved in (K/J)
+var linkSorts = []linkSort{
+	{label: "manual order", opts: models.ListOptions{SortBy: "position"}},
+	{label: "newest first", opts: models.ListOptions{SortBy: "created_at", Order: "desc"}},
+	{label: "title A–Z", opts: models.ListOptions{SortBy: "title", Order: "asc"}},
+	{label: "URL A–Z", opts: models.ListOptions{SortBy: "url", Order: "asc"}},
+}
+
 // manageLinksModel is a combined Bubble Tea model that allows listing, viewing,
 // deleting, and enriching links in a single unified flow.
 type manageLinksModel struct {
-	client *client.Client
+	client    *client.Client
+	browser   browser.Launcher
+	clipboard clipboard.Writer
 
-	links    []models.Link
+	allLinks []models.Link // Unfiltered list from the API
+	links    []models.Link // Filtered list (what's displayed/navigated)
 	selected int
-	step     int // 0=list, 1=action menu, 2=view details, 3=delete confirm, 4=enriching, 5=enrich done, 6=done
+	step     int // 0=list, 1=action menu, 2=view details, 3=delete confirm, 4=enriching, 5=enrich done, 6=done, 7=read text, 8=similar, 9=domains
 	err      error
 	ready    bool
 
-	// For delete confirmation
-	confirm textinput.Model
+	// Reloading the list in place (started with 'r' in the list view)
+	refreshing bool
+
+	// Links are fetched a page at a time: hasMore is set while pages remain,
+	// and loadingMore while the next one is being fetched
+	totalLinks  int64
+	hasMore     bool
+	loadingMore bool
+	moreErr     error // last failure fetching the next page; retried on the next move
+
+	// Index into linkSorts of the order links are fetched in (cycled with 's')
+	sortIndex int
 
-	// Enrichment result
+	// For delete confirmation
+	confirm confirmPrompt
+	// Deleting more marked links than this asks for the count to be typed;
+	// negative always asks y/N
+	bulkConfirmThreshold int
+
+	// Show only favorite links (toggled with 'f' in the list view)
+	favoritesOnly bool
+	// Show only unread links (toggled with 'u' in the list view)
+	unreadOnly bool
+	// Show only links on domainFilter (picked with 'g' in the list view);
+	// "" is links without a host, so it needs its own flag
+	domainFiltered bool
+	domainFilter   string
+
+	// Domains of the loaded links, largest first, for the 'g' picker
+	domainGroups   []links.DomainGroup
+	domainSelected int
+	domainOffset   int
+
+	// Live title/URL filter (focused with '/' in the list view)
+	filterInput    textinput.Model
+	filterFocused  bool
+	filterDebounce debouncer // re-filters once typing pauses, not on every key
+
+	// Links marked for bulk deletion (toggled with space in the list view)
+	marked       map[uuid.UUID]bool
+	deletedCount int64
+
+	// Manual ordering (moved with K/J in the list view): at most one save is
+	// in flight, and a move made meanwhile is saved when it returns
+	reordering   bool
+	reorderStale bool
+
+	// Fresh copy of the selected link for the detail view; nil while loading
+	viewedLink *models.Link
+
+	// Brief styled confirmation (e.g. "Copied!") shown until the next key press
+	notice string
+
+	// Enrichment progress and result
+	enrichEvents chan tea.Msg // progress then a final success/error message; nil when no enrich is running
+	enrichStatus string
+	enrichScrape scrapeController
 	enrichedLink *models.Link
 
-	// Config
-	scrapeTimeoutSeconds int
+	// Characters of link text shown in the detail view before truncating
+	previewLength int
+
+	// Full text of the viewed link, opened with 't' in the detail view
+	pager textPager
+
+	// Links sharing terms with the selected link; nil while loading
+	similarLinks    []models.Link
+	similarSelected int
 
 	// Viewport dimensions for proper rendering
-	width int
+	width  int
+	height int
+
+	// Index of the first link rendered in the list window
+	offset int
 }
 
 // NewManageLinksModel creates a new combined manage links flow.
 func NewManageLinksModel(
 	c *client.Client,
+	launcher browser.Launcher,
+	clip clipboard.Writer,
 	timeoutSeconds int,
+	previewLength int,
+	bulkConfirmThreshold int,
 ) tea.Model {
-	if timeoutSeconds <= 0 {
-		timeoutSeconds = 30
+	if previewLength <= 0 {
+		previewLength = defaultPreviewLength
 	}
 
-	confirm := textinput.New()
-	confirm.Placeholder = "y/N"
-	confirm.CharLimit = 1
-	confirm.Width = 10
+	filterInput := textinput.New()
+	filterInput.Prompt = "/ "
+	filterInput.Placeholder = "filter by title or URL"
+	filterInput.CharLimit = 200
+	filterInput.Width = 40
 
 	model := &manageLinksModel{
-		client:               c,
-		step:                 managelinks.StepListLinks,
-		confirm:              confirm,
-		scrapeTimeoutSeconds: timeoutSeconds,
+		client:      c,
+		browser:     launcher,
+		clipboard:   clip,
+		step:        managelinks.StepListLinks,
+		confirm:     newConfirmPrompt(),
+		filterInput: filterInput,
+		marked:      make(map[uuid.UUID]bool),
+		// Filtering a long list on every keystroke makes typing lag
+		filterDebounce: newDebouncer(filterDebounceDelay),
+		// Re-scraping only fills in what the link is missing
+		enrichScrape:  newScrapeController(timeoutSeconds, mergeFillEmpty),
+		previewLength: previewLength,
+		pager:         newTextPager(),
+
+		bulkConfirmThreshold: bulkConfirmThreshold,
 	}
 
 	// Wrap with viewport (enable scrolling for long lists)
@@ -77,23 +185,102 @@ func NewManageLinksModel(
 		MinHeight: 10,
 	})
 }
+
 func (m *manageLinksModel) Init() tea.Cmd {
+	return m.loadLinks()
+}
+
+// loadLinks fetches the first page of links in the current sort, replacing
+// any loaded before
+func (m *manageLinksModel) loadLinks() tea.Cmd {
+	opts := m.pageOptions(0)
+	return func() tea.Msg {
+		page, err := m.client.ListLinksPage(models.LinkFilter{}, opts)
+		if err != nil {
+			return managelinks.LinksLoadedMsg{Err: err}
+		}
+		return managelinks.LinksLoadedMsg{Links: page.Links, Total: page.Total, HasMore: page.HasNext()}
+	}
+}
+
+// loadMoreIfNeeded fetches the next page of links once the selection is
+// within LoadMoreThreshold of the end of the list, unless a fetch is already
+// running or every page is loaded
+func (m *manageLinksModel) loadMoreIfNeeded() tea.Cmd {
+	if !m.hasMore || m.loadingMore || m.refreshing || !nearListEnd(m.selected, len(m.links)) {
+		return nil
+	}
+	m.loadingMore = true
+	offset := len(m.allLinks)
+	opts := m.pageOptions(offset)
 	return func() tea.Msg {
-		links, err := m.client.ListLinks()
-		return managelinks.LinksLoadedMsg{Links: links, Err: err}
+		page, err := m.client.ListLinksPage(models.LinkFilter{}, opts)
+		if err != nil {
+			return managelinks.MoreLinksLoadedMsg{Offset: offset, Err: err}
+		}
+		return managelinks.MoreLinksLoadedMsg{Links: page.Links, Offset: offset, Total: page.Total, HasMore: page.HasNext()}
+	}
+}
+
+// pageOptions returns the list options for the page of links starting at
+// offset, in the current sort
+func (m *manageLinksModel) pageOptions(offset int) models.ListOptions {
+	opts := linkSorts[m.sortIndex].opts
+	opts.Limit = managelinks.LinkPageSize
+	opts.Offset = offset
+	return opts
+}
+
+// manualOrder reports whether the list is in the manual order, the only one
+// links can be moved in
+func (m *manageLinksModel) manualOrder() bool {
+	return m.sortIndex == 0
+}
+
+// nearListEnd reports whether selected is within LoadMoreThreshold of the
+// last of total displayed links. A short filtered list always is, so paging
+// on through it keeps looking for matches.
+func nearListEnd(selected, total int) bool {
+	return total-1-selected < managelinks.LoadMoreThreshold
+}
+
+// appendLinks adds a following page to allLinks. Links already loaded are
+// skipped, since adding or deleting links elsewhere shifts the pages.
+func (m *manageLinksModel) appendLinks(page []models.Link) {
+	loaded := make(map[uuid.UUID]bool, len(m.allLinks))
+	for _, link := range m.allLinks {
+		loaded[link.ID] = true
 	}
+	links := slices.Clone(m.allLinks)
+	for _, link := range page {
+		if !loaded[link.ID] {
+			links = append(links, link)
+		}
+	}
+	m.allLinks = links
+	m.applyFilters()
 }
 
 func (m *manageLinksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	logger.Log("manageLinksModel.Update() called: msg_type=%T, step=%d, ready=%v", msg, m.step, m.ready)
+	logger.Debug("manageLinksModel.Update() called: msg_type=%T, step=%d, ready=%v", msg, m.step, m.ready)
+
+	// Keep the selected link inside the rendered window after every update
+	defer m.scrollToSelected()
 
 	// Forward MenuNavigationMsg unchanged (let it bubble up to root)
 	switch msg.(type) {
 	case MenuNavigationMsg:
-		logger.Log("manageLinksModel.Update: forwarding MenuNavigationMsg")
+		logger.Debug("manageLinksModel.Update: forwarding MenuNavigationMsg")
 		return m, nil
 	}
 
+	// Any key exits after deletion success, and from the error view
+	if m.step == managelinks.StepDone || m.showsErrorView() {
+		if cmd, handled := exitOnAnyKey(msg); handled {
+			return m, cmd
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		// Store width for rendering - this allows us to render content that fits the viewport
@@ -101,56 +288,215 @@ func (m *manageLinksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.width == 0 {
 			m.width = managelinks.DefaultWidth
 		}
-		logger.Log("manageLinksModel.Update: received WindowSizeMsg, width=%d", m.width)
+		m.height = msg.Height
+		m.resizePager()
+		logger.Debug("manageLinksModel.Update: received WindowSizeMsg, width=%d, height=%d", m.width, m.height)
 		return m, nil
 
 	case managelinks.LinksLoadedMsg:
-		logger.Log("manageLinksModel.Update: received LinksLoadedMsg, links_count=%d, err=%v", len(msg.Links), msg.Err != nil)
+		logger.Debug("manageLinksModel.Update: received LinksLoadedMsg, links_count=%d, err=%v", len(msg.Links), msg.Err != nil)
+		m.refreshing = false
 		if msg.Err != nil {
-			m.err = msg.Err
+			logger.Error(msg.Err, "failed to load links")
+			m.err = userFacingError(msg.Err)
 			m.ready = true
 			return m, nil
 		}
-		m.links = msg.Links
+		m.allLinks = msg.Links
+		m.totalLinks = msg.Total
+		m.hasMore = msg.HasMore
+		m.moreErr = nil
+		m.pruneMarked()
+		m.applyFilters()
 		m.ready = true
 		return m, nil
 
+	case managelinks.MoreLinksLoadedMsg:
+		m.loadingMore = false
+		if msg.Offset != len(m.allLinks) {
+			// The list was reloaded meanwhile; the next move fetches again
+			return m, nil
+		}
+		if msg.Err != nil {
+			logger.Error(msg.Err, "failed to load more links")
+			m.moreErr = userFacingError(msg.Err)
+			return m, nil
+		}
+		m.moreErr = nil
+		m.totalLinks = msg.Total
+		m.hasMore = msg.HasMore && len(msg.Links) > 0
+		m.appendLinks(msg.Links)
+		return m, nil
+
+	case managelinks.LinksReorderedMsg:
+		m.reordering = false
+		if msg.Err != nil {
+			logger.Error(msg.Err, "failed to reorder links")
+			m.err = userFacingError(msg.Err)
+			m.reorderStale = false
+			// Reload so the list matches the order the server kept
+			return m, m.loadLinks()
+		}
+		if m.reorderStale {
+			m.reorderStale = false
+			return m, m.saveOrder()
+		}
+		return m, nil
+
+	case managelinks.FavoriteToggledMsg:
+		if msg.Err != nil {
+			logger.Error(msg.Err, "failed to toggle favorite")
+			m.err = userFacingError(msg.Err)
+			return m, nil
+		}
+		for i := range m.allLinks {
+			if m.allLinks[i].ID == msg.Link.ID {
+				m.allLinks[i] = *msg.Link
+			}
+		}
+		m.applyFilters()
+		// The link may have been filtered out (unfavorited while showing favorites only)
+		if m.selected >= len(m.links) || m.links[m.selected].ID != msg.Link.ID {
+			m.step = managelinks.StepListLinks
+		}
+		return m, nil
+
+	case managelinks.ReadToggledMsg:
+		if msg.Err != nil {
+			logger.Error(msg.Err, "failed to update read status")
+			m.err = userFacingError(msg.Err)
+			return m, nil
+		}
+		for i := range m.allLinks {
+			if m.allLinks[i].ID == msg.Link.ID {
+				m.allLinks[i] = *msg.Link
+			}
+		}
+		m.applyFilters()
+		// The link may have been filtered out (marked read while showing unread only)
+		if m.selected >= len(m.links) || m.links[m.selected].ID != msg.Link.ID {
+			m.step = managelinks.StepListLinks
+		}
+		return m, nil
+
+	case managelinks.LinkFetchedMsg:
+		if msg.Err != nil {
+			logger.Error(msg.Err, "failed to fetch link")
+			m.err = userFacingError(msg.Err)
+			return m, nil
+		}
+		m.viewedLink = msg.Link
+		// Keep the list in sync with the fresh copy
+		for i := range m.allLinks {
+			if m.allLinks[i].ID == msg.Link.ID {
+				m.allLinks[i] = *msg.Link
+			}
+		}
+		m.applyFilters()
+		return m, nil
+
+	case managelinks.SimilarLinksMsg:
+		// Ignore a late result after leaving the similar view
+		if m.step != managelinks.StepSimilar {
+			return m, nil
+		}
+		if msg.Err != nil {
+			logger.Error(msg.Err, "failed to find similar links")
+			m.err = userFacingError(msg.Err)
+			return m, nil
+		}
+		m.similarLinks = msg.Links
+		m.similarSelected = 0
+		return m, nil
+
+	case managelinks.LinkOpenedMsg:
+		if msg.Err != nil {
+			logger.Error(msg.Err, "failed to open link in browser")
+			m.err = userFacingError(msg.Err)
+		}
+		return m, nil
+
+	case managelinks.URLCopiedMsg:
+		switch {
+		case errors.Is(msg.Err, clipboard.ErrUnavailable):
+			m.notice = warningStyle.Render(fmt.Sprintf("Can't copy: %v", msg.Err))
+		case msg.Err != nil:
+			logger.Error(msg.Err, "failed to copy URL")
+			m.notice = warningStyle.Render(fmt.Sprintf("Copy failed: %v", msg.Err))
+		default:
+			m.notice = successStyle.Render("✓ Copied!")
+		}
+		return m, nil
+
 	case managelinks.DeleteErrorMsg:
-		m.err = msg.Err
+		logger.Error(msg.Err, "failed to delete link(s)")
+		m.err = userFacingError(msg.Err)
 		return m, tea.Quit
 
 	case managelinks.DeleteSuccessMsg:
 		m.step = managelinks.StepDone
+		m.deletedCount = msg.Count
+		m.marked = make(map[uuid.UUID]bool)
 		// Reload links after deletion
-		return m, func() tea.Msg {
-			links, err := m.client.ListLinks()
-			return managelinks.LinksLoadedMsg{Links: links, Err: err}
+		return m, m.loadLinks()
+
+	case managelinks.EnrichProgressMsg:
+		m.enrichStatus = msg.Message
+		return m, waitForEnrichEvent(m.enrichEvents)
+
+	case scrapeTickMsg:
+		return m, m.enrichScrape.Update(msg)
+
+	case debounceMsg:
+		if m.filterDebounce.Ready(msg) {
+			m.applyFilters()
 		}
+		return m, nil
 
 	case managelinks.EnrichSuccessMsg:
-		m.enrichedLink = msg.Link
+		m.enrichScrape.Finish(nil)
+		m.enrichEvents = nil
+		if m.step != managelinks.StepEnriching {
+			// Left with Esc; say so without pulling the user off their screen
+			m.notice = successStyle.Render("✓ Finished enriching the previous link")
+			return m, m.loadLinks()
+		}
+		// Leave enrichedLink nil when nothing changed, which renders as "no changes"
+		if msg.Changed {
+			m.enrichedLink = msg.Link
+		}
 		m.step = managelinks.StepEnrichDone
 		// Reload links after enrichment
-		return m, func() tea.Msg {
-			links, err := m.client.ListLinks()
-			return managelinks.LinksLoadedMsg{Links: links, Err: err}
-		}
+		return m, m.loadLinks()
 
 	case managelinks.EnrichErrorMsg:
-		m.err = userFacingError(msg.Err)
+		logger.Error(msg.Err, "failed to enrich link")
+		err := m.enrichScrape.Finish(msg.Err)
+		m.enrichEvents = nil
+		if m.step != managelinks.StepEnriching {
+			m.notice = warningStyle.Render(fmt.Sprintf("Enriching the previous link failed: %v", err))
+			return m, m.loadLinks()
+		}
+		m.err = err
 		m.step = managelinks.StepEnrichDone
 		return m, nil
 
 	case tea.KeyMsg:
-		logger.Log("manageLinksModel.Update: received KeyMsg, key=%q, step=%d", msg.String(), m.step)
+		logger.Debug("manageLinksModel.Update: received KeyMsg, key=%q, step=%d", msg.String(), m.step)
 		switch m.step {
 		case managelinks.StepListLinks:
-			logger.Log("manageLinksModel.Update: handling list keys")
+			logger.Debug("manageLinksModel.Update: handling list keys")
 			return m.handleListKeys(msg)
 		case managelinks.StepActionMenu:
 			return m.handleActionMenuKeys(msg)
 		case managelinks.StepViewDetails:
 			return m.handleViewDetailsKeys(msg)
+		case managelinks.StepReadText:
+			return m.handleReadTextKeys(msg)
+		case managelinks.StepSimilar:
+			return m.handleSimilarKeys(msg)
+		case managelinks.StepDomains:
+			return m.handleDomainsKeys(msg)
 		case managelinks.StepDeleteConfirm:
 			return m.handleDeleteConfirmKeys(msg)
 		case managelinks.StepEnriching:
@@ -161,34 +507,117 @@ func (m *manageLinksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 		case managelinks.StepEnrichDone:
-			// Any key goes back to action menu after enrichment
+			// 'r' retries a failed enrichment; any other key goes back to the action menu
+			if m.err != nil && msg.String() == "r" {
+				return m, m.startEnrich(m.enrichScrape.policy)
+			}
+			m.err = nil
 			m.step = managelinks.StepActionMenu
 			return m, nil
-		case managelinks.StepDone:
-			// Any key exits after deletion success
-			return m, tea.Quit
 		}
 	}
 
 	// Handle text input updates for delete confirmation
 	if m.step == managelinks.StepDeleteConfirm {
-		var cmd tea.Cmd
-		m.confirm, cmd = m.confirm.Update(msg)
-		return m, cmd
+		return m, m.confirm.Update(msg)
 	}
 
 	return m, nil
 }
 
 func (m *manageLinksModel) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filterFocused {
+		return m.handleFilterKeys(msg)
+	}
 	if handleQuitKeys(msg.String()) {
 		return m, tea.Quit
 	}
 	if newSelected, handled := handleListNavigation(msg.String(), m.selected, len(m.links)); handled {
 		m.selected = newSelected
-		return m, nil
+		return m, m.loadMoreIfNeeded()
 	}
-	if msg.String() == "enter" {
+	switch msg.String() {
+	case "/":
+		// Focus the filter input
+		m.filterFocused = true
+		m.filterInput.Focus()
+		return m, textinput.Blink
+	case "ctrl+u":
+		// Clear the filter without focusing it
+		m.filterInput.Reset()
+		m.applyFilters()
+		return m, nil
+	case " ":
+		// Toggle the highlighted link for bulk deletion
+		if m.selected < len(m.links) {
+			id := m.links[m.selected].ID
+			if m.marked[id] {
+				delete(m.marked, id)
+			} else {
+				m.marked[id] = true
+			}
+		}
+		return m, nil
+	case "K", "shift+up":
+		// Move the highlighted link up in the manual order
+		return m, m.moveSelected(-1)
+	case "J", "shift+down":
+		// Move the highlighted link down in the manual order
+		return m, m.moveSelected(1)
+	case "f":
+		// Toggle favorites-only view
+		m.favoritesOnly = !m.favoritesOnly
+		m.applyFilters()
+		return m, nil
+	case "u":
+		// Toggle unread-only view
+		m.unreadOnly = !m.unreadOnly
+		m.applyFilters()
+		return m, nil
+	case "g":
+		// Pick a domain to show only its links, or show all of them again
+		if m.domainFiltered {
+			m.domainFiltered = false
+			m.applyFilters()
+			return m, nil
+		}
+		if len(m.allLinks) == 0 {
+			return m, nil
+		}
+		m.domainGroups = links.GroupLinksByDomain(m.allLinks)
+		m.domainSelected = 0
+		m.domainOffset = 0
+		m.step = managelinks.StepDomains
+		return m, nil
+	case "x":
+		// Mark the highlighted link read or unread
+		if m.selected < 0 || m.selected >= len(m.links) {
+			return m, nil
+		}
+		return m, m.toggleRead()
+	case "d":
+		// Bulk delete marked links
+		if len(m.marked) == 0 {
+			return m, nil
+		}
+		return m, m.startDelete()
+	case "r":
+		// Reload the list to pick up changes made elsewhere
+		if m.refreshing {
+			return m, nil
+		}
+		m.refreshing = true
+		return m, m.loadLinks()
+	case "s":
+		// Cycle the sort order and fetch the list again in it. Not while a
+		// fetch or an order save is running, so neither lands in the new order.
+		if m.refreshing || m.loadingMore || m.reordering {
+			return m, nil
+		}
+		m.sortIndex = (m.sortIndex + 1) % len(linkSorts)
+		m.refreshing = true
+		return m, m.loadLinks()
+	case "enter":
 		if len(m.links) == 0 {
 			return m, nil
 		}
@@ -205,105 +634,450 @@ func (m *manageLinksModel) handleActionMenuKeys(msg tea.KeyMsg) (tea.Model, tea.
 	if handleQuitKeys(msg.String()) {
 		return m, tea.Quit
 	}
+	m.notice = ""
 	switch msg.String() {
 	case "esc", "b":
 		m.step = managelinks.StepListLinks
 		return m, nil
 	case "1", "v":
+		if m.selected < 0 || m.selected >= len(m.links) {
+			return m, nil
+		}
 		m.step = managelinks.StepViewDetails
-		return m, nil
+		m.viewedLink = nil
+		return m, m.fetchLink(m.links[m.selected].ID)
 	case "2", "d":
-		m.step = managelinks.StepDeleteConfirm
-		m.confirm.Focus()
-		return m, textinput.Blink
+		// A single delete from the action menu ignores any bulk selection
+		m.marked = make(map[uuid.UUID]bool)
+		return m, m.startDelete()
 	case "3", "s":
-		// Enrich link (scraping handled by API)
+		// Enrich link (scraping handled by API), filling only empty fields
 		if m.selected < 0 || m.selected >= len(m.links) {
 			return m, nil
 		}
-		m.step = managelinks.StepEnriching
-		m.enrichedLink = nil
-		m.err = nil
-		return m, m.enrichLink()
+		return m, m.startEnrich(mergeFillEmpty)
+	case "S":
+		// Re-scrape, replacing fields the link already has
+		if m.selected < 0 || m.selected >= len(m.links) {
+			return m, nil
+		}
+		return m, m.startEnrich(mergeOverwrite)
+	case "4", "f":
+		if m.selected < 0 || m.selected >= len(m.links) {
+			return m, nil
+		}
+		return m, m.toggleFavorite()
+	case "5", "o":
+		if m.selected < 0 || m.selected >= len(m.links) || m.browser == nil {
+			return m, nil
+		}
+		return m, m.openLink()
+	case "6", "y":
+		if m.selected < 0 || m.selected >= len(m.links) {
+			return m, nil
+		}
+		return m, m.copyText(m.links[m.selected].URL)
+	case "7", "x":
+		if m.selected < 0 || m.selected >= len(m.links) {
+			return m, nil
+		}
+		return m, m.toggleRead()
+	case "8", "i":
+		if m.selected < 0 || m.selected >= len(m.links) {
+			return m, nil
+		}
+		m.step = managelinks.StepSimilar
+		m.similarLinks = nil
+		return m, m.fetchSimilar(m.links[m.selected].ID)
 	}
 	return m, nil
 }
 
+// handleFilterKeys routes keys to the focused filter input, re-filtering the list as the user types
+func (m *manageLinksModel) handleFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "ctrl+u":
+		// Clear the filter and return to the full list
+		m.filterInput.Reset()
+		m.filterInput.Blur()
+		m.filterFocused = false
+		m.filterDebounce.Cancel()
+		m.applyFilters()
+		return m, nil
+	case "enter":
+		// Keep the filter applied and hand keys back to the list
+		m.filterInput.Blur()
+		m.filterFocused = false
+		m.flushFilter()
+		return m, nil
+	case "up", "down":
+		// Navigate the list as filtered by everything typed so far
+		m.flushFilter()
+		if newSelected, handled := handleListNavigation(msg.String(), m.selected, len(m.links)); handled {
+			m.selected = newSelected
+		}
+		return m, m.loadMoreIfNeeded()
+	}
+
+	prev := m.filterInput.Value()
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	if m.filterInput.Value() != prev {
+		return m, tea.Batch(cmd, m.filterDebounce.Trigger())
+	}
+	return m, cmd
+}
+
+// flushFilter applies a filter change still waiting out the debounce delay
+func (m *manageLinksModel) flushFilter() {
+	if m.filterDebounce.Pending() {
+		m.filterDebounce.Cancel()
+		m.applyFilters()
+	}
+}
+
+// IsCapturingInput implements InputCapturer so the viewport wrapper passes
+// keys such as 'q', 'm', and Esc through to the filter input while it is
+// focused, and to the delete confirmation prompt
+func (m *manageLinksModel) IsCapturingInput() bool {
+	return (m.step == managelinks.StepListLinks && m.filterFocused) ||
+		m.step == managelinks.StepDeleteConfirm
+}
+
+// moveSelected moves the highlighted link past its visible neighbour, up for
+// delta -1 or down for delta 1, and saves the new order. The swap is made in
+// allLinks, so links hidden by the filter keep their places. Links only move
+// in the manual order.
+func (m *manageLinksModel) moveSelected(delta int) tea.Cmd {
+	if !m.manualOrder() {
+		return nil
+	}
+	target := m.selected + delta
+	if m.selected < 0 || m.selected >= len(m.links) || target < 0 || target >= len(m.links) {
+		return nil
+	}
+
+	from := slices.IndexFunc(m.allLinks, func(link models.Link) bool { return link.ID == m.links[m.selected].ID })
+	to := slices.IndexFunc(m.allLinks, func(link models.Link) bool { return link.ID == m.links[target].ID })
+	if from < 0 || to < 0 {
+		return nil
+	}
+	// Swap in a copy: m.links may share allLinks' backing array, and
+	// applyFilters reads the selected link's ID from it
+	links := slices.Clone(m.allLinks)
+	links[from], links[to] = links[to], links[from]
+	m.allLinks = links
+	m.applyFilters() // the selection follows the moved link
+
+	return m.saveOrder()
+}
+
+// saveOrder sends the current order of allLinks to the API, or marks it to
+// be sent once the save already in flight returns
+func (m *manageLinksModel) saveOrder() tea.Cmd {
+	if m.reordering {
+		m.reorderStale = true
+		return nil
+	}
+	m.reordering = true
+
+	ids := make([]uuid.UUID, len(m.allLinks))
+	for i, link := range m.allLinks {
+		ids[i] = link.ID
+	}
+	return func() tea.Msg {
+		return managelinks.LinksReorderedMsg{Err: m.client.ReorderLinks(ids)}
+	}
+}
+
+// pruneMarked drops marks on links that are no longer in allLinks, e.g.
+// deleted elsewhere before a refresh
+func (m *manageLinksModel) pruneMarked() {
+	for id := range m.marked {
+		if !slices.ContainsFunc(m.allLinks, func(link models.Link) bool { return link.ID == id }) {
+			delete(m.marked, id)
+		}
+	}
+}
+
+// applyFilters rebuilds the displayed list from allLinks, keeping the
+// selected link selected if it is still visible and the selection in range
+func (m *manageLinksModel) applyFilters() {
+	var selectedID uuid.UUID
+	if m.selected >= 0 && m.selected < len(m.links) {
+		selectedID = m.links[m.selected].ID
+	}
+
+	visible := m.allLinks
+	if m.favoritesOnly {
+		favorites := make([]models.Link, 0, len(visible))
+		for _, link := range visible {
+			if link.IsFavorite {
+				favorites = append(favorites, link)
+			}
+		}
+		visible = favorites
+	}
+	if m.unreadOnly {
+		unread := make([]models.Link, 0, len(visible))
+		for _, link := range visible {
+			if !link.IsRead {
+				unread = append(unread, link)
+			}
+		}
+		visible = unread
+	}
+	if m.domainFiltered {
+		onDomain := make([]models.Link, 0, len(visible))
+		for _, link := range visible {
+			if links.LinkDomain(link.URL) == m.domainFilter {
+				onDomain = append(onDomain, link)
+			}
+		}
+		visible = onDomain
+	}
+	m.links = filterLinks(visible, m.filterInput.Value())
+
+	if selectedID != uuid.Nil {
+		for i, link := range m.links {
+			if link.ID == selectedID {
+				m.selected = i
+				break
+			}
+		}
+	}
+
+	if m.selected >= len(m.links) {
+		m.selected = len(m.links) - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+}
+
 func (m *manageLinksModel) handleViewDetailsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if handleQuitKeys(msg.String()) {
 		return m, tea.Quit
 	}
+	m.notice = ""
 	switch msg.String() {
 	case "esc", "b", "enter":
 		m.step = managelinks.StepActionMenu
 		return m, nil
+	case "y":
+		if m.viewedLink == nil {
+			return m, nil
+		}
+		return m, m.copyText(m.viewedLink.URL)
+	case "M":
+		if m.viewedLink == nil {
+			return m, nil
+		}
+		return m, m.copyText(links.FormatMarkdown(m.viewedLink))
+	case "t":
+		if m.viewedLink == nil || m.viewedLink.Text == nil || *m.viewedLink.Text == "" {
+			return m, nil
+		}
+		m.step = managelinks.StepReadText
+		m.resizePager()
+		m.pager.Open(*m.viewedLink.Text)
+		return m, nil
 	}
 	return m, nil
 }
 
-func (m *manageLinksModel) handleDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// handleReadTextKeys scrolls the full-text pager; 'b', 't', or Enter go back
+// to the details
+func (m *manageLinksModel) handleReadTextKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if handleQuitKeys(msg.String()) {
+		return m, tea.Quit
+	}
+	switch msg.String() {
+	case "b", "t", "enter":
+		m.step = managelinks.StepViewDetails
+		return m, nil
+	}
+	return m, m.pager.Update(msg)
+}
+
+// OwnsScrolling implements ScrollOwner: the full-text pager scrolls itself
+func (m *manageLinksModel) OwnsScrolling() bool {
+	return m.step == managelinks.StepReadText
+}
+
+// resizePager fits the pager to the screen below its title and above its
+// status and help lines, inside the wrapper's header and footer
+func (m *manageLinksModel) resizePager() {
+	chrome := lipgloss.Height(m.renderReadTextHeader()) + lipgloss.Height(m.renderReadTextFooter())
+	m.pager.SetSize(m.getMaxWidth(), m.getMaxHeight()-managelinks.ChromeHeight-chrome)
+}
+
+// handleSimilarKeys navigates the similar links; Enter selects one in the
+// list and opens its actions, Esc or 'b' go back to the action menu
+func (m *manageLinksModel) handleSimilarKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if handleQuitKeys(msg.String()) {
+		return m, tea.Quit
+	}
+	if newSelected, handled := handleListNavigation(msg.String(), m.similarSelected, len(m.similarLinks)); handled {
+		m.similarSelected = newSelected
+		return m, nil
+	}
 	switch msg.String() {
-	case "ctrl+c", "esc":
+	case "esc", "b":
 		m.step = managelinks.StepActionMenu
 		return m, nil
 	case "enter":
-		answer := strings.ToLower(strings.TrimSpace(m.confirm.Value()))
-		if answer == "y" || answer == "yes" {
-			return m, m.deleteLink()
+		if m.similarSelected >= len(m.similarLinks) {
+			return m, nil
 		}
-		// Cancelled - go back to action menu
+		m.selectLink(m.similarLinks[m.similarSelected].ID)
 		m.step = managelinks.StepActionMenu
 		return m, nil
-	default:
-		var cmd tea.Cmd
-		m.confirm, cmd = m.confirm.Update(msg)
-		return m, cmd
 	}
+	return m, nil
+}
+
+// handleDomainsKeys navigates the domain picker; Enter shows only the
+// highlighted domain's links, Esc or 'b' go back to the list unchanged
+func (m *manageLinksModel) handleDomainsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "b":
+		m.step = managelinks.StepListLinks
+		return m, nil
+	case "enter":
+		if m.domainSelected < len(m.domainGroups) {
+			m.domainFilter = m.domainGroups[m.domainSelected].Domain
+			m.domainFiltered = true
+			m.applyFilters()
+		}
+		m.step = managelinks.StepListLinks
+		return m, nil
+	}
+	if handleQuitKeys(msg.String()) {
+		return m, tea.Quit
+	}
+	if newSelected, handled := handleListNavigation(msg.String(), m.domainSelected, len(m.domainGroups)); handled {
+		m.domainSelected = newSelected
+		m.domainOffset, _ = visibleWindow(m.domainSelected, m.domainOffset, len(m.domainGroups), m.domainPageSize())
+	}
+	return m, nil
+}
+
+// selectLink selects the link with the given ID, clearing the filters if
+// they hide it
+func (m *manageLinksModel) selectLink(id uuid.UUID) {
+	find := func() bool {
+		for i, link := range m.links {
+			if link.ID == id {
+				m.selected = i
+				return true
+			}
+		}
+		return false
+	}
+	if find() {
+		return
+	}
+	m.favoritesOnly = false
+	m.unreadOnly = false
+	m.domainFiltered = false
+	m.filterInput.Reset()
+	m.filterDebounce.Cancel()
+	m.applyFilters()
+	if !find() {
+		// Not loaded yet (e.g. created since the list was fetched)
+		m.step = managelinks.StepListLinks
+	}
+}
+
+func (m *manageLinksModel) handleDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	return m, m.confirm.Update(msg)
+}
+
+// startDelete asks to confirm deleting the marked links, or the selected
+// link if none are marked. Marking more links than the bulk confirmation
+// threshold asks for their count to be typed instead of y/N.
+func (m *manageLinksModel) startDelete() tea.Cmd {
+	m.step = managelinks.StepDeleteConfirm
+	onYes := func() tea.Cmd {
+		if len(m.marked) > 0 {
+			return m.deleteMarkedLinks()
+		}
+		return m.deleteLink()
+	}
+	onNo := func() tea.Cmd {
+		// Cancelled - go back to where the delete was started
+		m.step = m.deleteReturnStep()
+		return nil
+	}
+	if count := len(m.marked); utils.NeedsCountConfirmation(count, m.bulkConfirmThreshold) {
+		return m.confirm.OpenCount("Confirm", count, onYes, onNo)
+	}
+	return m.confirm.Open("Confirm", onYes, onNo)
 }
 
 func (m *manageLinksModel) View() string {
-	logger.Log("manageLinksModel.View() called: ready=%v, step=%d, err=%v, links_count=%d, selected=%d",
+	logger.Debug("manageLinksModel.View() called: ready=%v, step=%d, err=%v, links_count=%d, selected=%d",
 		m.ready, m.step, m.err != nil, len(m.links), m.selected)
 
 	if !m.ready {
-		logger.Log("View: returning loading state")
+		logger.Debug("View: returning loading state")
 		return renderLoadingState("Loading links...")
 	}
 
-	if m.err != nil && m.step != managelinks.StepDone {
-		logger.LogError(m.err, "View: returning error view, step=%d", m.step)
+	if m.showsErrorView() {
+		logger.Debug("View: returning error view, step=%d, err=%v", m.step, m.err)
 		return renderErrorView(m.err)
 	}
 
 	var result string
 	switch m.step {
 	case managelinks.StepListLinks:
-		logger.Log("View: rendering list view")
+		logger.Debug("View: rendering list view")
 		result = m.renderList()
 	case managelinks.StepActionMenu:
-		logger.Log("View: rendering action menu, selected=%d", m.selected)
+		logger.Debug("View: rendering action menu, selected=%d", m.selected)
 		result = m.renderActionMenu()
 	case managelinks.StepViewDetails:
-		logger.Log("View: rendering view details, selected=%d", m.selected)
+		logger.Debug("View: rendering view details, selected=%d", m.selected)
 		result = m.renderViewDetails()
+	case managelinks.StepReadText:
+		logger.Debug("View: rendering text pager")
+		result = m.renderReadTextHeader() + m.pager.View() + "\n" + m.renderReadTextFooter()
+	case managelinks.StepSimilar:
+		logger.Debug("View: rendering similar links, selected=%d", m.similarSelected)
+		result = m.renderSimilar()
+	case managelinks.StepDomains:
+		logger.Debug("View: rendering domains, selected=%d", m.domainSelected)
+		result = m.renderDomains()
 	case managelinks.StepDeleteConfirm:
-		logger.Log("View: rendering delete confirm, selected=%d", m.selected)
+		logger.Debug("View: rendering delete confirm, selected=%d", m.selected)
 		result = m.renderDeleteConfirm()
 	case managelinks.StepEnriching:
-		logger.Log("View: rendering enriching")
+		logger.Debug("View: rendering enriching")
 		result = "\n" + infoStyle.Render("Enriching link...") + "\n"
+		if m.enrichStatus != "" {
+			result += mutedStyle.Render(m.enrichStatus) + "\n"
+		}
+		result += m.enrichScrape.View() + "\n"
 	case managelinks.StepEnrichDone:
-		logger.Log("View: rendering enrich done, error=%v, enriched=%v", m.err != nil, m.enrichedLink != nil)
+		logger.Debug("View: rendering enrich done, error=%v, enriched=%v", m.err != nil, m.enrichedLink != nil)
 		result = m.renderEnrichDone()
 	case managelinks.StepDone:
-		logger.Log("View: rendering done (deletion success)")
-		result = renderSuccessView("Link deleted successfully!")
+		logger.Debug("View: rendering done (deletion success)")
+		if m.deletedCount > 1 {
+			result = renderSuccessView(fmt.Sprintf("%d links deleted successfully!", m.deletedCount))
+		} else {
+			result = renderSuccessView("Link deleted successfully!")
+		}
 	default:
-		logger.Log("View: unknown step=%d, returning empty string", m.step)
+		logger.Debug("View: unknown step=%d, returning empty string", m.step)
 		return ""
 	}
 
-	logger.Log("View: result length=%d bytes", len(result))
+	logger.Debug("View: result length=%d bytes", len(result))
 	return result
 }
 
@@ -315,11 +1089,41 @@ func (m *manageLinksModel) getMaxWidth() int {
 	return managelinks.DefaultWidth
 }
 
+// getMaxHeight returns the terminal height, using DefaultHeight as fallback
+func (m *manageLinksModel) getMaxHeight() int {
+	if m.height > 0 {
+		return m.height
+	}
+	return managelinks.DefaultHeight
+}
+
+// listPageSize returns how many links fit on screen below the list header
+func (m *manageLinksModel) listPageSize() int {
+	rows := m.getMaxHeight() - managelinks.ChromeHeight - m.GetListHeaderHeight() - managelinks.ListFooterHeight
+	size := rows / m.GetItemHeight()
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// domainPageSize returns how many domains fit on screen below the picker's
+// subtitle and above its status and help lines
+func (m *manageLinksModel) domainPageSize() int {
+	return max(1, m.getMaxHeight()-managelinks.ChromeHeight-2-2)
+}
+
+// scrollToSelected moves the list window so the selected link stays visible
+func (m *manageLinksModel) scrollToSelected() {
+	m.offset, _ = visibleWindow(m.selected, m.offset, len(m.links), m.listPageSize())
+}
+
 // GetSelectedIndex implements SelectableModel interface for automatic viewport scrolling
 func (m *manageLinksModel) GetSelectedIndex() int {
-	// Only return selection when in list view step
+	// Only return selection when in list view step. The list is already
+	// windowed, so the index is relative to the first rendered link.
 	if m.step == managelinks.StepListLinks {
-		return m.selected
+		return m.selected - m.offset
 	}
 	return -1 // No selection in other steps
 }
@@ -338,16 +1142,45 @@ func (m *manageLinksModel) GetItemHeight() int {
 func (m *manageLinksModel) GetListHeaderHeight() int {
 	if m.step == managelinks.StepListLinks {
 		// Subtitle "Select a link:" (1 line) + blank line (1 line) = 2 lines
+		// Filter input (1 line) + blank line (1 line) when shown
+		if m.showFilter() {
+			return 4
+		}
 		return 2
 	}
 	return 0
 }
 
 func (m *manageLinksModel) renderList() string {
-	logger.Log("renderList: called with %d links, selected=%d, width=%d", len(m.links), m.selected, m.width)
+	logger.Debug("renderList: called with %d links, selected=%d, width=%d", len(m.links), m.selected, m.width)
+
+	var filterBar string
+	if m.showFilter() {
+		filterBar = m.filterInput.View() + "\n\n"
+	}
 
 	if len(m.links) == 0 {
-		logger.Log("renderList: no links, returning empty state")
+		logger.Debug("renderList: no links, returning empty state")
+		if m.filterInput.Value() != "" {
+			notLoaded := ""
+			if m.hasMore {
+				notLoaded = fmt.Sprintf(" Only the %d loaded links were searched; press ↓ to load more.", len(m.allLinks))
+			}
+			return filterBar + mutedStyle.Render("No links match the filter."+notLoaded) + "\n\n" +
+				helpStyle.Render("(Esc or Ctrl+U to clear the filter)") + "\n"
+		}
+		if m.favoritesOnly && m.unreadOnly {
+			return renderEmptyState("No unread favorite links found. (Press 'f' or 'u' to show more links.)")
+		}
+		if m.favoritesOnly {
+			return renderEmptyState("No favorite links found. (Press 'f' to show all links.)")
+		}
+		if m.unreadOnly {
+			return renderEmptyState("No unread links. (Press 'u' to show all links.)")
+		}
+		if m.domainFiltered {
+			return renderEmptyState("No links on " + links.DomainLabel(m.domainFilter) + ". (Press 'g' to show all domains.)")
+		}
 		return renderEmptyState("No links found.")
 	}
 
@@ -355,13 +1188,63 @@ func (m *manageLinksModel) renderList() string {
 	maxWidth := m.getMaxWidth()
 
 	// Title is rendered by the viewport wrapper header
-	s := renderLinkList(m.links, m.selected, "", "Select a link:", maxWidth)
-	s += helpStyle.Render("(Use ↑/↓ or j/k to navigate, Enter to select, Esc to quit)") + "\n"
+	shown := linkSorts[m.sortIndex].label
+	switch {
+	case m.favoritesOnly && m.unreadOnly:
+		shown = "unread favorites only, " + shown
+	case m.favoritesOnly:
+		shown = "favorites only, " + shown
+	case m.unreadOnly:
+		shown = "unread only, " + shown
+	}
+	if m.domainFiltered {
+		shown = links.DomainLabel(m.domainFilter) + ", " + shown
+	}
+	subtitle := fmt.Sprintf("Select a link (%s):", shown)
+	// Render only the window of links that fits the terminal
+	start, end := visibleWindow(m.selected, m.offset, len(m.links), m.listPageSize())
+	s := filterBar + renderLinkList(m.links[start:end], m.selected-start, m.marked, "", subtitle, maxWidth)
+	if len(m.marked) > 0 {
+		s += infoStyle.Render(fmt.Sprintf("%d link(s) marked for deletion", len(m.marked))) + "\n"
+	}
+	if m.refreshing {
+		s += infoStyle.Render("Refreshing…") + "\n"
+	}
+	switch {
+	case m.moreErr != nil:
+		s += renderInlineError(fmt.Errorf("couldn't load more links: %w", m.moreErr)) + "\n"
+	case m.loadingMore:
+		s += infoStyle.Render("Loading more links…") + "\n"
+	case m.hasMore:
+		s += mutedStyle.Render(fmt.Sprintf("Showing %d-%d of %d loaded links (%d in all; more load as you scroll)", start+1, end, len(m.links), m.totalLinks)) + "\n"
+	case end-start < len(m.links):
+		s += mutedStyle.Render(fmt.Sprintf("Showing %d-%d of %d links", start+1, end, len(m.links))) + "\n"
+	}
+	if m.filterFocused {
+		s += helpStyle.Render("(Type to filter, ↑/↓ to navigate, Enter to keep filter, Esc to clear)") + "\n"
+	} else {
+		move := ""
+		if m.manualOrder() {
+			move = " K/J to move,"
+		}
+		s += helpStyle.Render("(Use ↑/↓ or j/k to navigate, / to filter, Space to mark, d to delete marked,"+move+" s to sort, f for favorites, u for unread, g for domains, x to mark read, r to refresh, Enter to select, Esc to quit)") + "\n"
+	}
 
-	logger.Log("renderList: generated content, length=%d bytes", len(s))
+	logger.Debug("renderList: generated content, length=%d bytes", len(s))
 	return s
 }
 
+// showsErrorView reports whether an error replaces the current step's view.
+// The done steps show their own errors instead.
+func (m *manageLinksModel) showsErrorView() bool {
+	return m.ready && m.err != nil && m.step != managelinks.StepDone && m.step != managelinks.StepEnrichDone
+}
+
+// showFilter reports whether the filter input should be rendered above the list
+func (m *manageLinksModel) showFilter() bool {
+	return m.filterFocused || m.filterInput.Value() != ""
+}
+
 func (m *manageLinksModel) renderActionMenu() string {
 	if m.selected >= len(m.links) {
 		return renderErrorView(fmt.Errorf("invalid selection"))
@@ -391,37 +1274,116 @@ func (m *manageLinksModel) renderActionMenu() string {
 	b.WriteString(boldStyle.Render("Choose an action:") + "\n\n")
 	b.WriteString("  " + selectedMarkerStyle.Render("1)") + " View details\n")
 	b.WriteString("  " + selectedMarkerStyle.Render("2)") + " Delete link\n")
-	b.WriteString("  " + selectedMarkerStyle.Render("3)") + " Enrich link\n")
+	b.WriteString("  " + selectedMarkerStyle.Render("3)") + " Enrich link (S to overwrite existing fields)\n")
+	if link.IsFavorite {
+		b.WriteString("  " + selectedMarkerStyle.Render("4)") + " Remove from favorites\n")
+	} else {
+		b.WriteString("  " + selectedMarkerStyle.Render("4)") + " Add to favorites\n")
+	}
+	b.WriteString("  " + selectedMarkerStyle.Render("5)") + " Open in browser\n")
+	b.WriteString("  " + selectedMarkerStyle.Render("6)") + " Copy URL\n")
+	if link.IsRead {
+		b.WriteString("  " + selectedMarkerStyle.Render("7)") + " Mark as unread\n")
+	} else {
+		b.WriteString("  " + selectedMarkerStyle.Render("7)") + " Mark as read\n")
+	}
+	b.WriteString("  " + selectedMarkerStyle.Render("8)") + " Find similar\n")
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("(Press 1/v to view, 2/d to delete, 3/s to enrich, Esc/b to go back, q to quit)") + "\n")
+	if m.notice != "" {
+		b.WriteString(m.notice + "\n\n")
+	}
+	b.WriteString(helpStyle.Render("(Press 1/v to view, 2/d to delete, 3/s to enrich, S to re-scrape overwriting, 4/f to favorite, 5/o to open, 6/y to copy URL, 7/x to mark read/unread, 8/i to find similar, Esc/b to go back, q to quit)") + "\n")
 
 	return b.String()
 }
 
 func (m *manageLinksModel) renderViewDetails() string {
-	if m.selected >= len(m.links) {
-		return renderErrorView(fmt.Errorf("invalid selection"))
+	if m.viewedLink == nil {
+		return renderLoadingState("Loading link...")
 	}
 
 	// Use stored width for rendering, with fallback
 	maxWidth := m.getMaxWidth()
 
-	link := m.links[m.selected]
 	var b strings.Builder
 
 	b.WriteString(renderTitle("Link Details"))
 	b.WriteString(renderDivider(maxWidth))
 	b.WriteString("\n\n")
 
-	b.WriteString(renderLinkDetailsFull(&link, maxWidth))
+	b.WriteString(renderLinkDetailsFull(m.viewedLink, maxWidth, m.previewLength))
 
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("(Press Enter, 'b', Esc, or 'q' to go back)") + "\n")
+	if m.notice != "" {
+		b.WriteString(m.notice + "\n\n")
+	}
+	b.WriteString(helpStyle.Render("(Press 't' to read the full text, 'y' to copy the URL, 'M' to copy as Markdown; Enter, 'b', Esc, or 'q' to go back)") + "\n")
 
 	return b.String()
 }
 
+// renderSimilar renders the links similar to the selected one
+func (m *manageLinksModel) renderSimilar() string {
+	if m.similarLinks == nil {
+		return renderLoadingState("Finding similar links...")
+	}
+	if len(m.similarLinks) == 0 {
+		return renderEmptyState("No similar links found. (Press 'b' to go back.)")
+	}
+
+	subtitle := "Similar to " + truncateURL(formatLinkTitle(m.links[m.selected]), m.getMaxWidth()-12) + ":"
+	s := renderLinkList(m.similarLinks, m.similarSelected, nil, "", subtitle, m.getMaxWidth())
+	s += helpStyle.Render("(Use ↑/↓ or j/k to navigate, Enter to select, Esc/b to go back)") + "\n"
+	return s
+}
+
+// renderDomains renders the domain picker: each domain of the loaded links
+// with its link count, in a window that fits the terminal
+func (m *manageLinksModel) renderDomains() string {
+	var b strings.Builder
+	b.WriteString(boldStyle.Render("Show links on:") + "\n\n")
+
+	start, end := visibleWindow(m.domainSelected, m.domainOffset, len(m.domainGroups), m.domainPageSize())
+	for i := start; i < end; i++ {
+		group := m.domainGroups[i]
+		label := truncateURL(links.DomainLabel(group.Domain), m.getMaxWidth()-16)
+		count := mutedStyle.Render(fmt.Sprintf(" (%d)", len(group.Links)))
+		if i == m.domainSelected {
+			b.WriteString(selectedMarkerStyle.Render("→") + " " + selectedStyle.Render(label) + count + "\n")
+		} else {
+			b.WriteString("  " + label + count + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.hasMore {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Domains of the %d loaded links (%d in all)", len(m.allLinks), m.totalLinks)) + "\n")
+	} else if end-start < len(m.domainGroups) {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Showing %d-%d of %d domains", start+1, end, len(m.domainGroups))) + "\n")
+	}
+	b.WriteString(helpStyle.Render("(Use ↑/↓ or j/k to navigate, Enter to show only that domain, Esc/b to go back)") + "\n")
+	return b.String()
+}
+
+// renderReadTextHeader renders the title above the full-text pager
+func (m *manageLinksModel) renderReadTextHeader() string {
+	title := "Text"
+	if m.viewedLink != nil {
+		title = formatLinkTitle(*m.viewedLink)
+	}
+	return renderTitle(truncateURL(title, m.getMaxWidth()))
+}
+
+// renderReadTextFooter renders the scroll position and keys below the pager
+func (m *manageLinksModel) renderReadTextFooter() string {
+	return mutedStyle.Render(m.pager.Status()) + "\n" +
+		helpStyle.Render("(↑/↓ j/k scroll, PgUp/PgDn page, Home/End; t/b/Enter to go back)") + "\n"
+}
+
 func (m *manageLinksModel) renderDeleteConfirm() string {
+	if len(m.marked) > 0 {
+		return m.renderBulkDeleteConfirm()
+	}
 	if m.selected >= len(m.links) {
 		return renderErrorView(fmt.Errorf("invalid selection"))
 	}
@@ -447,15 +1409,60 @@ func (m *manageLinksModel) renderDeleteConfirm() string {
 	b.WriteString(fieldLabelStyle.Render("URL:"))
 	b.WriteString(fmt.Sprintf(" %s\n\n", url))
 
-	b.WriteString(boldStyle.Render("Confirm (y/N):"))
-	b.WriteString(" ")
 	b.WriteString(m.confirm.View())
-	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("(Press Enter to confirm, Esc to cancel)") + "\n")
 
 	return b.String()
 }
 
+// renderBulkDeleteConfirm renders the confirmation prompt for deleting all marked links
+func (m *manageLinksModel) renderBulkDeleteConfirm() string {
+	maxWidth := m.getMaxWidth()
+	urlTruncateWidth := maxWidth - 10
+	if urlTruncateWidth < 40 {
+		urlTruncateWidth = 40
+	}
+
+	var b strings.Builder
+	b.WriteString(renderTitle("Delete Links"))
+	b.WriteString(warningStyle.Render("⚠️  Confirm Deletion") + "\n\n")
+
+	b.WriteString(boldStyle.Render(fmt.Sprintf("Are you sure you want to delete %d link(s):", len(m.marked))) + "\n")
+	for _, link := range m.links {
+		if !m.marked[link.ID] {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  %s\n", linkTitleStyle.Render(formatLinkTitle(link))))
+		b.WriteString(fmt.Sprintf("  %s\n", linkURLStyle.Render(truncateURL(link.URL, urlTruncateWidth))))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.confirm.View())
+
+	return b.String()
+}
+
+// deleteReturnStep returns the step to go back to when a delete is cancelled
+func (m *manageLinksModel) deleteReturnStep() int {
+	if len(m.marked) > 0 {
+		return managelinks.StepListLinks
+	}
+	return managelinks.StepActionMenu
+}
+
+func (m *manageLinksModel) deleteMarkedLinks() tea.Cmd {
+	ids := make([]uuid.UUID, 0, len(m.marked))
+	for id := range m.marked {
+		ids = append(ids, id)
+	}
+	return func() tea.Msg {
+		deleted, err := m.client.DeleteLinks(ids)
+		if err != nil {
+			return managelinks.DeleteErrorMsg{Err: err}
+		}
+		return managelinks.DeleteSuccessMsg{Count: deleted}
+	}
+}
+
 func (m *manageLinksModel) deleteLink() tea.Cmd {
 	return func() tea.Msg {
 		if m.selected >= len(m.links) {
@@ -468,33 +1475,131 @@ func (m *manageLinksModel) deleteLink() tea.Cmd {
 			return managelinks.DeleteErrorMsg{Err: err}
 		}
 
-		return managelinks.DeleteSuccessMsg{}
+		return managelinks.DeleteSuccessMsg{Count: 1}
 	}
 }
 
+// startEnrich resets the enrichment state and enriches the selected link,
+// merging the scraped fields by policy. It does nothing while an earlier
+// enrich (e.g. one left with Esc) is still running, so only one stream ever
+// feeds m.enrichEvents.
+func (m *manageLinksModel) startEnrich(policy scrapeMergePolicy) tea.Cmd {
+	if m.enrichEvents != nil {
+		m.notice = warningStyle.Render("Still enriching the previous link; try again when it finishes")
+		return nil
+	}
+	m.enrichScrape.policy = policy
+	m.step = managelinks.StepEnriching
+	m.enrichedLink = nil
+	m.enrichStatus = ""
+	m.err = nil
+	return m.enrichScrape.Start(m.enrichLink())
+}
+
+// enrichLink starts a streaming enrich in the background. Progress stages and
+// the final result are delivered in order through m.enrichEvents, which
+// waitForEnrichEvent drains one message at a time.
 func (m *manageLinksModel) enrichLink() tea.Cmd {
-	return func() tea.Msg {
-		if m.selected < 0 || m.selected >= len(m.links) {
+	if m.selected < 0 || m.selected >= len(m.links) {
+		return func() tea.Msg {
 			return managelinks.EnrichErrorMsg{Err: fmt.Errorf("invalid selection")}
 		}
+	}
 
-		link := m.links[m.selected]
-		updated, err := m.client.EnrichLink(
+	link := m.links[m.selected]
+	events := make(chan tea.Msg, 8)
+	m.enrichEvents = events
+
+	go func() {
+		defer close(events)
+		updated, changed, err := m.client.EnrichLinkStream(
 			link.ID,
-			m.scrapeTimeoutSeconds,
-			true, // only fill empty
+			m.enrichScrape.timeoutSeconds,
+			m.enrichScrape.policy.onlyFillEmpty(),
+			func(stage scraper.ScrapeStage, message string) {
+				events <- managelinks.EnrichProgressMsg{Stage: string(stage), Message: message}
+			},
 		)
 		if err != nil {
-			return managelinks.EnrichErrorMsg{Err: err}
+			events <- managelinks.EnrichErrorMsg{Err: err}
+			return
+		}
+		events <- managelinks.EnrichSuccessMsg{Link: updated, Changed: changed}
+	}()
+
+	return waitForEnrichEvent(events)
+}
+
+// waitForEnrichEvent returns a command that blocks for the next enrich event
+func waitForEnrichEvent(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
 		}
+		return msg
+	}
+}
 
-		return managelinks.EnrichSuccessMsg{Link: updated}
+func (m *manageLinksModel) toggleFavorite() tea.Cmd {
+	link := m.links[m.selected]
+	return func() tea.Msg {
+		updated, err := m.client.ToggleFavorite(link.ID)
+		return managelinks.FavoriteToggledMsg{Link: updated, Err: err}
+	}
+}
+
+// toggleRead marks the selected link read, or unread if it already is
+func (m *manageLinksModel) toggleRead() tea.Cmd {
+	link := m.links[m.selected]
+	return func() tea.Msg {
+		updated, err := m.client.MarkRead(link.ID, !link.IsRead)
+		return managelinks.ReadToggledMsg{Link: updated, Err: err}
+	}
+}
+
+// fetchSimilar loads the links sharing terms with a link
+func (m *manageLinksModel) fetchSimilar(id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		similar, err := m.client.GetSimilar(id, 0)
+		return managelinks.SimilarLinksMsg{Links: similar, Err: err}
+	}
+}
+
+// fetchLink loads a fresh copy of a single link for the detail view
+func (m *manageLinksModel) fetchLink(id uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		link, err := m.client.GetLink(id)
+		return managelinks.LinkFetchedMsg{Link: link, Err: err}
+	}
+}
+
+func (m *manageLinksModel) openLink() tea.Cmd {
+	link := m.links[m.selected]
+	return func() tea.Msg {
+		// Count the visit as --open does; a failure shouldn't stop the link opening
+		if _, err := m.client.RecordVisit(link.ID); err != nil {
+			logger.Error(err, "failed to record visit")
+		}
+		return managelinks.LinkOpenedMsg{Err: m.browser.Open(link.URL)}
+	}
+}
+
+// copyText copies text (a URL or a Markdown snippet) to the clipboard
+func (m *manageLinksModel) copyText(text string) tea.Cmd {
+	clip := m.clipboard
+	return func() tea.Msg {
+		if clip == nil {
+			return managelinks.URLCopiedMsg{Err: clipboard.ErrUnavailable}
+		}
+		return managelinks.URLCopiedMsg{Err: clip.Write(text)}
 	}
 }
 
 func (m *manageLinksModel) renderEnrichDone() string {
 	if m.err != nil {
-		return renderErrorView(m.err)
+		return "\n" + renderError(fmt.Sprintf("Error: %v", m.err)) + "\n\n" +
+			helpStyle.Render("Press 'r' to retry, any other key to go back") + "\n"
 	}
 
 	if m.enrichedLink == nil {
@@ -0,0 +1,634 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"link-mgmt/pkg/cli/client"
+	"link-mgmt/pkg/cli/tui/managelinks"
+	"link-mgmt/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWaitForEnrichEventForwardsInOrder(t *testing.T) {
+	events := make(chan tea.Msg, 8)
+	sent := []tea.Msg{
+		managelinks.EnrichProgressMsg{Stage: "health_check", Message: "Checking scraper service..."},
+		managelinks.EnrichProgressMsg{Stage: "fetching", Message: "Fetching page..."},
+		managelinks.EnrichSuccessMsg{Changed: true},
+	}
+	go func() {
+		defer close(events)
+		for _, msg := range sent {
+			events <- msg
+		}
+	}()
+
+	// Each command delivers exactly the next event, the final one included
+	for i, want := range sent {
+		if got := waitForEnrichEvent(events)(); got != want {
+			t.Fatalf("event %d = %#v, want %#v", i, got, want)
+		}
+	}
+	// and once the stream ends, nothing more
+	if got := waitForEnrichEvent(events)(); got != nil {
+		t.Errorf("after the last event got %#v, want nil", got)
+	}
+}
+
+func TestEnrichProgressKeepsWaiting(t *testing.T) {
+	m := newTestManageLinks()
+	events := make(chan tea.Msg, 1)
+	m.enrichEvents = events
+	m.step = managelinks.StepEnriching
+
+	_, cmd := m.Update(managelinks.EnrichProgressMsg{Stage: "fetching", Message: "Fetching page..."})
+	if m.enrichStatus != "Fetching page..." {
+		t.Errorf("enrichStatus = %q, want the progress message", m.enrichStatus)
+	}
+	if cmd == nil {
+		t.Fatal("progress returned no command, want one waiting for the next event")
+	}
+	final := managelinks.EnrichErrorMsg{}
+	events <- final
+	if got := cmd(); got != final {
+		t.Errorf("next command delivered %#v, want the queued %#v", got, final)
+	}
+}
+
+func TestViewDetailsFetchesFreshLink(t *testing.T) {
+	stale := testLink("https://example.com/article", "Stale Title")
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.Method + " " + r.URL.Path
+		fresh := stale
+		title := "Fresh Title"
+		fresh.Title = &title
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(fresh)
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestManageLinks(stale, testLink("https://example.org", "Other"))
+	m.client = client.NewClient(server.URL, "test-key")
+	m.step = managelinks.StepActionMenu
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.step != managelinks.StepViewDetails || cmd == nil {
+		t.Fatalf("step = %v, cmd = %v; want the detail view fetching the link", m.step, cmd)
+	}
+	m.Update(cmd())
+
+	if want := "GET /api/v1/links/" + stale.ID.String(); requested != want {
+		t.Errorf("requested %q, want %q", requested, want)
+	}
+	if m.viewedLink == nil || m.viewedLink.Title == nil || *m.viewedLink.Title != "Fresh Title" {
+		t.Fatalf("viewed link = %+v, want the fresh copy", m.viewedLink)
+	}
+	if got := *m.allLinks[0].Title; got != "Fresh Title" {
+		t.Errorf("list title = %q, want it updated to the fresh copy", got)
+	}
+}
+
+// drainEnrich delivers m's pending enrich events to Update until the stream
+// ends, as the running program would
+func drainEnrich(t *testing.T, m *manageLinksModel) {
+	t.Helper()
+
+	events := m.enrichEvents
+	if events == nil {
+		t.Fatal("no enrich running")
+	}
+	for msg := waitForEnrichEvent(events)(); msg != nil; msg = waitForEnrichEvent(events)() {
+		m.Update(msg)
+	}
+}
+
+func TestManageLinksRetryEnrich(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"error": "scraper unavailable"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestManageLinks(testLink("https://example.com/flaky", "Flaky"))
+	m.client = client.NewClient(server.URL, "test-key")
+	m.step = managelinks.StepActionMenu
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	drainEnrich(t, m)
+	if m.step != managelinks.StepEnrichDone || m.err == nil {
+		t.Fatalf("after a failed enrich: step = %v, err = %v; want the enrich-done step with an error", m.step, m.err)
+	}
+	if m.enrichEvents != nil {
+		t.Error("enrichEvents kept after the enrich finished")
+	}
+	run := m.enrichScrape.timer.run
+
+	// 'r' clears the failure and starts a new enrich
+	m.enrichStatus = "Fetching page..."
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil || m.step != managelinks.StepEnriching {
+		t.Fatalf("after 'r': step = %v, cmd = %v; want enriching with a command", m.step, cmd)
+	}
+	if m.err != nil || m.enrichStatus != "" || m.enrichedLink != nil {
+		t.Errorf("after 'r': err = %v, status = %q, link = %v; want them reset", m.err, m.enrichStatus, m.enrichedLink)
+	}
+	if m.enrichScrape.timer.run != run+1 || !m.enrichScrape.timer.running {
+		t.Errorf("timer run = %d (running %v), want a fresh run %d", m.enrichScrape.timer.run, m.enrichScrape.timer.running, run+1)
+	}
+
+	drainEnrich(t, m)
+	if got := requests.Load(); got != 2 {
+		t.Errorf("scraper requests = %d, want 2", got)
+	}
+	if m.step != managelinks.StepEnrichDone || m.err == nil || m.enrichEvents != nil {
+		t.Errorf("after the retry failed: step = %v, err = %v, events = %v; want done with an error and no stream", m.step, m.err, m.enrichEvents)
+	}
+
+	// Any other key goes back to the action menu, clearing the error
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.step != managelinks.StepActionMenu || m.err != nil {
+		t.Errorf("after another key: step = %v, err = %v; want the action menu with no error", m.step, m.err)
+	}
+}
+
+func TestManageLinksRetryWaitsForRunningEnrich(t *testing.T) {
+	m := newTestManageLinks(testLink("https://example.com/slow", "Slow"))
+	m.step = managelinks.StepEnrichDone
+	m.err = errors.New("scrape failed")
+	m.enrichEvents = make(chan tea.Msg)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd != nil || m.step != managelinks.StepEnrichDone {
+		t.Errorf("step = %v, cmd = %v; want no second enrich while one is running", m.step, cmd)
+	}
+	if m.notice == "" {
+		t.Error("no notice explaining why the retry didn't start")
+	}
+}
+
+func TestManageLinksEnrichFinishesAfterLeaving(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        tea.Msg
+		wantNotice string
+	}{
+		{"success", managelinks.EnrichSuccessMsg{Changed: true}, "Finished enriching"},
+		{"failure", managelinks.EnrichErrorMsg{Err: errors.New("scraper unavailable")}, "scraper unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManageLinks(testLink("https://example.com/slow", "Slow"))
+			m.client = client.NewClient("http://127.0.0.1:1", "test-key")
+			m.step = managelinks.StepEnriching
+			m.enrichEvents = make(chan tea.Msg)
+
+			// Leave the running enrich and open the link's details
+			m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+			if m.step != managelinks.StepViewDetails {
+				t.Fatalf("step = %v, want the details view", m.step)
+			}
+
+			_, cmd := m.Update(tt.msg)
+			if m.step != managelinks.StepViewDetails {
+				t.Errorf("step = %v after the enrich finished, want the details view kept", m.step)
+			}
+			if m.err != nil {
+				t.Errorf("err = %v, want the result left to the notice", m.err)
+			}
+			if m.enrichEvents != nil {
+				t.Error("enrichEvents kept after the enrich finished")
+			}
+			if !strings.Contains(m.notice, tt.wantNotice) {
+				t.Errorf("notice = %q, want it to contain %q", m.notice, tt.wantNotice)
+			}
+			if cmd == nil {
+				t.Error("no command to reload the links")
+			}
+		})
+	}
+}
+
+func TestManageLinksUnreadFilter(t *testing.T) {
+	read := testLink("https://example.com/read", "Read")
+	read.IsRead = true
+	unread := testLink("https://example.com/unread", "Unread")
+	m := newTestManageLinks(read, unread)
+
+	pressKey(m, "u")
+	if got := linkURLs(m.links); len(got) != 1 || got[0] != unread.URL {
+		t.Fatalf("unread only: links = %v, want [%s]", got, unread.URL)
+	}
+	if !strings.Contains(m.renderList(), "unread only") {
+		t.Errorf("list doesn't say it shows unread links only:\n%s", m.renderList())
+	}
+	pressKey(m, "u")
+	if len(m.links) != 2 {
+		t.Errorf("after toggling back: %d links, want 2", len(m.links))
+	}
+}
+
+func TestManageLinksDomainFilter(t *testing.T) {
+	blog := testLink("https://blog.example.com/post", "Blog")
+	first := testLink("https://example.com/a", "A")
+	second := testLink("https://www.example.com:8443/b", "B")
+	m := newTestManageLinks(blog, first, second)
+
+	pressKey(m, "g")
+	if m.step != managelinks.StepDomains {
+		t.Fatalf("after g: step = %d, want the domain picker", m.step)
+	}
+	if view := m.renderDomains(); !strings.Contains(view, "example.com (2)") || !strings.Contains(view, "blog.example.com (1)") {
+		t.Errorf("picker doesn't list the domains with their counts:\n%s", view)
+	}
+
+	pressKey(m, "enter")
+	if m.step != managelinks.StepListLinks {
+		t.Fatalf("after enter: step = %d, want the list", m.step)
+	}
+	if got := linkURLs(m.links); !slices.Equal(got, []string{first.URL, second.URL}) {
+		t.Errorf("example.com only: links = %v, want [%s %s]", got, first.URL, second.URL)
+	}
+	if !strings.Contains(m.renderList(), "example.com, ") {
+		t.Errorf("list doesn't name the domain it shows:\n%s", m.renderList())
+	}
+
+	pressKey(m, "g")
+	if m.step != managelinks.StepListLinks || len(m.links) != 3 {
+		t.Errorf("after g again: step = %d with %d links, want the list with all 3", m.step, len(m.links))
+	}
+
+	pressKey(m, "g")
+	pressKey(m, "down")
+	pressKey(m, "esc")
+	if m.step != managelinks.StepListLinks || len(m.links) != 3 {
+		t.Errorf("after esc in the picker: step = %d with %d links, want the list unchanged", m.step, len(m.links))
+	}
+}
+
+func TestManageLinksToggleRead(t *testing.T) {
+	first := testLink("https://example.com/first", "First")
+	second := testLink("https://example.com/second", "Second")
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Read bool `json:"read"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, fmt.Sprintf("%s %s read=%v", r.Method, r.URL.Path, body.Read))
+		updated := first
+		updated.IsRead = body.Read
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(updated)
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestManageLinks(first, second)
+	m.client = client.NewClient(server.URL, "test-key")
+
+	// x marks the highlighted link read, and again unread
+	pressKey(m, "x")
+	if !m.allLinks[0].IsRead {
+		t.Fatal("after x: link not marked read")
+	}
+	pressKey(m, "x")
+	if m.allLinks[0].IsRead {
+		t.Fatal("after a second x: link still read")
+	}
+	want := []string{
+		"POST /api/v1/links/" + first.ID.String() + "/read read=true",
+		"POST /api/v1/links/" + first.ID.String() + "/read read=false",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+
+	// Marked read from the action menu while showing unread only, the link
+	// drops out of the list and the menu closes
+	pressKey(m, "u")
+	m.step = managelinks.StepActionMenu
+	pressKey(m, "7")
+	if m.step != managelinks.StepListLinks {
+		t.Errorf("step = %v, want back at the list", m.step)
+	}
+	if got := linkURLs(m.links); len(got) != 1 || got[0] != second.URL {
+		t.Errorf("links = %v, want [%s]", got, second.URL)
+	}
+}
+
+func TestManageLinksToggleReadError(t *testing.T) {
+	link := testLink("https://example.com/article", "Article")
+	m := newTestManageLinks(link)
+	m.Update(managelinks.ReadToggledMsg{Err: errors.New("boom")})
+	if m.err == nil || m.allLinks[0].IsRead {
+		t.Errorf("err = %v, IsRead = %v; want the error shown and the link unchanged", m.err, m.allLinks[0].IsRead)
+	}
+}
+
+func TestManageLinksFindSimilar(t *testing.T) {
+	source := testLink("https://example.com/go", "Go")
+	hidden := testLink("https://example.com/go-channels", "Go channels")
+	hidden.IsRead = true
+	other := testLink("https://example.com/bread", "Bread")
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]models.Link{hidden})
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestManageLinks(source, hidden, other)
+	m.client = client.NewClient(server.URL, "test-key")
+	pressKey(m, "u") // the similar link is read, so hidden from the list
+	m.step = managelinks.StepActionMenu
+
+	pressKey(m, "8")
+	if m.step != managelinks.StepSimilar {
+		t.Fatalf("step = %v, want the similar view", m.step)
+	}
+	if want := "/api/v1/links/" + source.ID.String() + "/similar"; requested != want {
+		t.Errorf("requested %q, want %q", requested, want)
+	}
+	if !strings.Contains(m.View(), "Go channels") {
+		t.Errorf("similar view doesn't list the result:\n%s", m.View())
+	}
+
+	// Enter selects it in the list, clearing the filter that hid it
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.step != managelinks.StepActionMenu || m.links[m.selected].ID != hidden.ID {
+		t.Errorf("step %v with %s selected, want the action menu for %s", m.step, m.links[m.selected].URL, hidden.URL)
+	}
+	if m.unreadOnly {
+		t.Error("unread-only filter still on, want it cleared to show the link")
+	}
+}
+
+func TestManageLinksRefresh(t *testing.T) {
+	a := testLink("https://example.com/a", "A")
+	b := testLink("https://example.com/b", "B")
+	c := testLink("https://example.com/c", "C")
+
+	var calls atomic.Int32
+	var serve atomic.Value
+	serve.Store([]models.Link{a, c})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(serve.Load())
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestManageLinks(a, b, c)
+	m.client = client.NewClient(server.URL, "test-key")
+	m.selected = 2
+	m.marked[b.ID] = true
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if !m.refreshing || cmd == nil {
+		t.Fatalf("refreshing = %v, cmd = %v; want a reload running", m.refreshing, cmd)
+	}
+	if !strings.Contains(m.renderList(), "Refreshing…") {
+		t.Errorf("list doesn't show it is refreshing:\n%s", m.renderList())
+	}
+	// A second r while reloading doesn't start another
+	if _, again := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); again != nil {
+		t.Error("r while refreshing started another reload")
+	}
+
+	m.Update(cmd())
+	if got := calls.Load(); got != 1 {
+		t.Errorf("client called %d times, want 1", got)
+	}
+	if m.refreshing || strings.Contains(m.renderList(), "Refreshing…") {
+		t.Error("still refreshing after the reload returned")
+	}
+	// The selected link stays selected, and marks on deleted links go
+	if m.links[m.selected].ID != c.ID {
+		t.Errorf("selected %s, want %s kept", m.links[m.selected].URL, c.URL)
+	}
+	if len(m.marked) != 0 {
+		t.Errorf("marked = %v, want the deleted link's mark dropped", m.marked)
+	}
+
+	// When the selected link is gone, the selection stays in range
+	serve.Store([]models.Link{a})
+	pressKey(m, "r")
+	if got := calls.Load(); got != 2 {
+		t.Errorf("client called %d times, want 2", got)
+	}
+	if m.selected != 0 || len(m.links) != 1 {
+		t.Errorf("selected %d of %d links, want 0 of 1", m.selected, len(m.links))
+	}
+}
+
+func TestNearListEnd(t *testing.T) {
+	tests := []struct {
+		selected, total int
+		want            bool
+	}{
+		{0, 0, true},
+		{0, 5, true},
+		{0, 100, false},
+		{89, 100, false},
+		{90, 100, true},
+		{99, 100, true},
+	}
+	for _, tt := range tests {
+		if got := nearListEnd(tt.selected, tt.total); got != tt.want {
+			t.Errorf("nearListEnd(%d, %d) = %v, want %v", tt.selected, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestManageLinksLoadsMoreNearEnd(t *testing.T) {
+	links := make([]models.Link, 25)
+	for i := range links {
+		links[i] = testLink(fmt.Sprintf("https://example.com/%d", i), "")
+	}
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		// The second page starts with a link already loaded, as if one was
+		// added at the top meanwhile
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total-Count", "26")
+		json.NewEncoder(w).Encode(links[19:])
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestManageLinks(links[:20]...)
+	m.client = client.NewClient(server.URL, "test-key")
+	m.hasMore = true
+	m.totalLinks = 26
+	m.selected = 8
+
+	// Ten links from the end is far enough away
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown}); cmd != nil || m.loadingMore {
+		t.Fatalf("selected %d of 20: started fetching the next page", m.selected)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if cmd == nil || !m.loadingMore {
+		t.Fatalf("selected %d of 20: didn't fetch the next page", m.selected)
+	}
+	if !strings.Contains(m.renderList(), "Loading more links…") {
+		t.Errorf("list doesn't show the page loading:\n%s", m.renderList())
+	}
+	// Moving on while the page loads doesn't fetch it again
+	if _, again := m.Update(tea.KeyMsg{Type: tea.KeyDown}); again != nil {
+		t.Error("moving while a page loads started another fetch")
+	}
+
+	m.Update(cmd())
+	if len(queries) != 1 || !strings.Contains(queries[0], "offset=20") || !strings.Contains(queries[0], fmt.Sprintf("limit=%d", managelinks.LinkPageSize)) {
+		t.Fatalf("queries = %q, want one for the page at offset 20", queries)
+	}
+	if got, want := linkURLs(m.links), linkURLs(links); !slices.Equal(got, want) {
+		t.Errorf("links = %v, want %v with the overlap skipped", got, want)
+	}
+	if m.loadingMore || m.hasMore {
+		t.Errorf("loadingMore = %v, hasMore = %v after the last page, want both false", m.loadingMore, m.hasMore)
+	}
+	if m.links[m.selected].ID != links[11].ID {
+		t.Errorf("selected %s, want %s kept", m.links[m.selected].URL, links[11].URL)
+	}
+}
+
+func TestManageLinksLoadMoreOutcomes(t *testing.T) {
+	links := []models.Link{testLink("https://example.com/a", ""), testLink("https://example.com/b", "")}
+	more := testLink("https://example.com/c", "")
+
+	t.Run("stale page is discarded", func(t *testing.T) {
+		m := newTestManageLinks(links...)
+		m.hasMore, m.loadingMore = true, true
+		m.Update(managelinks.MoreLinksLoadedMsg{Links: []models.Link{more}, Offset: 5, HasMore: false})
+		if len(m.allLinks) != 2 || !m.hasMore || m.loadingMore {
+			t.Errorf("got %d links, hasMore %v, loadingMore %v; want the page dropped and fetching allowed again", len(m.allLinks), m.hasMore, m.loadingMore)
+		}
+	})
+
+	t.Run("failure is shown and retried", func(t *testing.T) {
+		m := newTestManageLinks(links...)
+		m.hasMore, m.loadingMore = true, true
+		m.Update(managelinks.MoreLinksLoadedMsg{Offset: 2, Err: errors.New("connection refused")})
+		if m.moreErr == nil || !strings.Contains(m.renderList(), "couldn't load more links") {
+			t.Errorf("list doesn't show the failure:\n%s", m.renderList())
+		}
+		if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown}); cmd == nil || !m.loadingMore {
+			t.Error("the next move didn't retry the fetch")
+		}
+	})
+}
+
+// fakeLauncher records the URLs it is asked to open
+type fakeLauncher struct {
+	opened []string
+}
+
+func (l *fakeLauncher) Open(url string) error {
+	l.opened = append(l.opened, url)
+	return nil
+}
+
+func TestManageLinksOpenCountsVisit(t *testing.T) {
+	link := testLink("https://example.com/article", "Article")
+	for _, status := range []int{http.StatusOK, http.StatusForbidden} {
+		var visited []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			visited = append(visited, r.Method+" "+r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]int{"visit_count": 1})
+		}))
+		t.Cleanup(server.Close)
+
+		launcher := &fakeLauncher{}
+		m := newTestManageLinks(link)
+		m.client = client.NewClient(server.URL, "test-key")
+		m.browser = launcher
+		m.step = managelinks.StepActionMenu
+
+		pressKey(m, "o")
+		want := "POST /api/v1/links/" + link.ID.String() + "/visit"
+		if len(visited) != 1 || visited[0] != want {
+			t.Errorf("status %d: requests = %q, want %q", status, visited, want)
+		}
+		// A visit that isn't counted still opens the link
+		if len(launcher.opened) != 1 || launcher.opened[0] != link.URL {
+			t.Errorf("status %d: opened %q, want %q", status, launcher.opened, link.URL)
+		}
+		if m.err != nil {
+			t.Errorf("status %d: err = %v, want none shown", status, m.err)
+		}
+	}
+}
+
+func TestManageLinksSortCycle(t *testing.T) {
+	a := testLink("https://example.com/a", "")
+	b := testLink("https://example.com/b", "Bravo")
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("sort")+" "+r.URL.Query().Get("order"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]models.Link{a, b})
+	}))
+	t.Cleanup(server.Close)
+
+	m := newTestManageLinks(a, b)
+	m.client = client.NewClient(server.URL, "test-key")
+
+	tests := []struct {
+		label string
+		query string
+	}{
+		{"newest first", "created_at desc"},
+		{"title A–Z", "title asc"},
+		{"URL A–Z", "url asc"},
+		{"manual order", "position "},
+	}
+	for i, tt := range tests {
+		pressKey(m, "s")
+		if len(queries) != i+1 || queries[i] != tt.query {
+			t.Fatalf("sort %q: queries = %q, want the list fetched with %q", tt.label, queries, tt.query)
+		}
+		if !strings.Contains(m.renderList(), "Select a link ("+tt.label+"):") {
+			t.Errorf("header doesn't name the sort %q:\n%s", tt.label, m.renderList())
+		}
+		// Links only move in the manual order
+		if manual := tt.label == "manual order"; strings.Contains(m.renderList(), "K/J to move") != manual {
+			t.Errorf("sort %q: K/J hint shown = %v, want %v", tt.label, !manual, manual)
+		}
+	}
+
+	// A sort change waits for a running fetch
+	m.refreshing = true
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}); cmd != nil || m.sortIndex != 0 {
+		t.Errorf("s while refreshing changed the sort to %q", linkSorts[m.sortIndex].label)
+	}
+}
+
+func TestManageLinksMoveOnlyInManualOrder(t *testing.T) {
+	m := newTestManageLinks(testLink("https://example.com/a", ""), testLink("https://example.com/b", ""))
+	m.sortIndex = 2 // title A–Z
+	if cmd := m.moveSelected(1); cmd != nil {
+		t.Error("moveSelected started a save outside the manual order")
+	}
+	if got := linkURLs(m.links); got[0] != "https://example.com/a" {
+		t.Errorf("links = %v, want them unmoved", got)
+	}
+}
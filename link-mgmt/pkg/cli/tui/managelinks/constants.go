@@ -9,7 +9,29 @@ const (
 	StepEnriching
 	StepEnrichDone
 	StepDone
+	StepReadText
+	StepSimilar
+	StepDomains
 )
 
 // DefaultWidth is the default terminal width fallback
 const DefaultWidth = 80
+
+// DefaultHeight is the default terminal height fallback
+const DefaultHeight = 24
+
+// ChromeHeight is the number of lines taken by the viewport wrapper's header
+// (title, margin, navigation hint) and footer around the list
+const ChromeHeight = 6
+
+// ListFooterHeight is the number of lines rendered below the list items
+// (blank separator, marked count, scroll position, and key help)
+const ListFooterHeight = 4
+
+// LinkPageSize is the number of links fetched per request; more are fetched
+// as the selection nears the end of those loaded
+const LinkPageSize = 200
+
+// LoadMoreThreshold is how close, in links, the selection gets to the end of
+// the loaded list before the next page is fetched
+const LoadMoreThreshold = 10
@@ -4,10 +4,22 @@ import (
 	"link-mgmt/pkg/models"
 )
 
-// LinksLoadedMsg is emitted when links have been fetched
+// LinksLoadedMsg is emitted when the first page of links has been fetched
 type LinksLoadedMsg struct {
-	Links []models.Link
-	Err   error
+	Links   []models.Link
+	Total   int64 // links across all pages
+	HasMore bool
+	Err     error
+}
+
+// MoreLinksLoadedMsg is emitted when a following page of links, starting at
+// Offset, has been fetched
+type MoreLinksLoadedMsg struct {
+	Links   []models.Link
+	Offset  int
+	Total   int64
+	HasMore bool
+	Err     error
 }
 
 // DeleteErrorMsg is emitted when link deletion fails
@@ -16,11 +28,60 @@ type DeleteErrorMsg struct {
 }
 
 // DeleteSuccessMsg is emitted when link deletion succeeds
-type DeleteSuccessMsg struct{}
+type DeleteSuccessMsg struct {
+	Count int64
+}
+
+// LinksReorderedMsg is emitted after trying to save the manual link order
+type LinksReorderedMsg struct {
+	Err error
+}
+
+// FavoriteToggledMsg is emitted when a link's favorite flag has been toggled
+type FavoriteToggledMsg struct {
+	Link *models.Link
+	Err  error
+}
+
+// ReadToggledMsg is emitted when a link has been marked read or unread
+type ReadToggledMsg struct {
+	Link *models.Link
+	Err  error
+}
+
+// LinkFetchedMsg is emitted when a single link has been fetched for the detail view
+type LinkFetchedMsg struct {
+	Link *models.Link
+	Err  error
+}
+
+// SimilarLinksMsg is emitted when the links similar to the selected one have been fetched
+type SimilarLinksMsg struct {
+	Links []models.Link
+	Err   error
+}
+
+// LinkOpenedMsg is emitted after trying to open a link in the browser
+type LinkOpenedMsg struct {
+	Err error
+}
+
+// URLCopiedMsg is emitted after trying to copy a link's URL (or Markdown
+// snippet) to the clipboard
+type URLCopiedMsg struct {
+	Err error
+}
+
+// EnrichProgressMsg is emitted for each scrape stage reported while enriching
+type EnrichProgressMsg struct {
+	Stage   string
+	Message string
+}
 
 // EnrichSuccessMsg is emitted when link enrichment succeeds
 type EnrichSuccessMsg struct {
-	Link *models.Link
+	Link    *models.Link
+	Changed bool // false if the content was identical to the last scrape
 }
 
 // EnrichErrorMsg is emitted when link enrichment fails
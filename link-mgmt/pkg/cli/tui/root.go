@@ -3,7 +3,9 @@ package tui
 import (
 	"strings"
 
+	"link-mgmt/pkg/cli/browser"
 	"link-mgmt/pkg/cli/client"
+	"link-mgmt/pkg/cli/clipboard"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -16,7 +18,13 @@ type MenuNavigationMsg struct{}
 type rootModel struct {
 	// Shared dependencies
 	client        *client.Client
+	browser       browser.Launcher
+	clipboard     clipboard.Writer
+	scraperHealth ScraperHealthFunc
 	scrapeTimeout int
+	previewLength int
+	bulkConfirm   int              // cli.bulk_confirm_threshold, for bulk deletes
+	configEditor  func() tea.Model // optional; nil hides the config entry
 
 	// Current active flow (when nil, we are in the main menu)
 	current tea.Model
@@ -27,18 +35,41 @@ func (m *rootModel) IsDelegating() bool {
 	return m.current != nil
 }
 
+// IsCapturingInput reports whether the active child flow has a focused text input
+func (m *rootModel) IsCapturingInput() bool {
+	if capturer, ok := m.current.(InputCapturer); ok {
+		return capturer.IsCapturingInput()
+	}
+	return false
+}
+
 // NewRootModel constructs the root app-shell model that can launch multiple flows.
 func NewRootModel(
 	apiClient *client.Client,
+	launcher browser.Launcher,
+	clip clipboard.Writer,
+	scraperHealth ScraperHealthFunc,
 	scrapeTimeoutSeconds int,
+	previewLength int,
+	bulkConfirmThreshold int,
+	configEditor func() tea.Model,
 ) tea.Model {
 	if scrapeTimeoutSeconds <= 0 {
-		scrapeTimeoutSeconds = 30
+		scrapeTimeoutSeconds = defaultScrapeTimeoutSeconds
+	}
+	if previewLength <= 0 {
+		previewLength = defaultPreviewLength
 	}
 
 	root := &rootModel{
 		client:        apiClient,
+		browser:       launcher,
+		clipboard:     clip,
+		scraperHealth: scraperHealth,
 		scrapeTimeout: scrapeTimeoutSeconds,
+		previewLength: previewLength,
+		bulkConfirm:   bulkConfirmThreshold,
+		configEditor:  configEditor,
 	}
 
 	// Wrap with viewport (simple responsive, no scrolling needed for menu)
@@ -63,7 +94,7 @@ func (m *rootModel) Init() tea.Cmd {
 func (m *rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Handle menu navigation message before delegating
 	if _, ok := msg.(MenuNavigationMsg); ok {
-		// Return to menu (clear current flow)
+		// Return to menu (clear current flow; the next one starts fresh)
 		m.current = nil
 		return m, nil
 	}
@@ -83,25 +114,32 @@ func (m *rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "1":
 			// Add link flow (scraping handled by API).
-			m.current = NewAddLinkForm(m.client, m.scrapeTimeout)
-			if initer, ok := m.current.(interface{ Init() tea.Cmd }); ok {
-				return m, initer.Init()
-			}
-			return m, nil
+			return m.startFlow(NewAddLinkForm(m.client, m.scraperHealth, m.scrapeTimeout))
 
 		case "2":
-			// Manage links flow (list, view, delete, enrich).
-			m.current = NewManageLinksModel(m.client, m.scrapeTimeout)
-			if initer, ok := m.current.(interface{ Init() tea.Cmd }); ok {
-				return m, initer.Init()
+			// Manage links flow (list, view, delete, enrich, open).
+			return m.startFlow(NewManageLinksModel(m.client, m.browser, m.clipboard, m.scrapeTimeout, m.previewLength, m.bulkConfirm))
+
+		case "3":
+			// Config editor (validates and saves like --config-set).
+			if m.configEditor == nil {
+				return m, nil
 			}
-			return m, nil
+			return m.startFlow(m.configEditor())
 		}
 	}
 
 	return m, nil
 }
 
+// startFlow makes flow the active one and starts it. It also asks for the
+// terminal size, which the program only sends once at startup: without it, a
+// flow opened from the menu (or reopened after 'm') is laid out for 80x24.
+func (m *rootModel) startFlow(flow tea.Model) (tea.Model, tea.Cmd) {
+	m.current = flow
+	return m, tea.Batch(flow.Init(), tea.WindowSize())
+}
+
 func (m *rootModel) View() string {
 	// When a flow is active, defer to its view.
 	if m.current != nil {
@@ -116,6 +154,9 @@ func (m *rootModel) View() string {
 	b.WriteString(boldStyle.Render("Select an action:") + "\n\n")
 	b.WriteString("  " + selectedMarkerStyle.Render("1)") + " Add link (with scraping)\n")
 	b.WriteString("  " + selectedMarkerStyle.Render("2)") + " Manage links (list, view, delete, scrape)\n")
+	if m.configEditor != nil {
+		b.WriteString("  " + selectedMarkerStyle.Render("3)") + " Edit config\n")
+	}
 	b.WriteString("\n")
 	b.WriteString(helpStyle.Render("Press the number of an option, or 'q' / Esc to quit.") + "\n")
 
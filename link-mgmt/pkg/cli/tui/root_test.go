@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stubFlow is a flow that does nothing, standing in for the config editor
+type stubFlow struct{}
+
+func (stubFlow) Init() tea.Cmd                         { return nil }
+func (s stubFlow) Update(tea.Msg) (tea.Model, tea.Cmd) { return s, nil }
+func (stubFlow) View() string                          { return "stub flow" }
+
+// newTestRoot returns the root menu model, without a client
+func newTestRoot(configEditor func() tea.Model) *rootModel {
+	return NewRootModel(nil, nil, nil, nil, 0, 0, 0, configEditor).(*ViewportWrapper).model.(*rootModel)
+}
+
+// unwrap returns the model inside a ViewportWrapper, or model itself
+func unwrap(model tea.Model) tea.Model {
+	if wrapper, ok := model.(*ViewportWrapper); ok {
+		return wrapper.model
+	}
+	return model
+}
+
+func TestRootMenuKeys(t *testing.T) {
+	editor := func() tea.Model { return stubFlow{} }
+	tests := []struct {
+		name   string
+		key    string
+		editor func() tea.Model
+		want   func(tea.Model) bool // nil: the menu stays open
+	}{
+		{"1 adds a link", "1", editor, func(m tea.Model) bool { _, ok := m.(*addLinkForm); return ok }},
+		{"2 manages links", "2", editor, func(m tea.Model) bool { _, ok := m.(*manageLinksModel); return ok }},
+		{"3 edits config", "3", editor, func(m tea.Model) bool { _, ok := m.(stubFlow); return ok }},
+		{"3 without a config editor", "3", nil, nil},
+		{"unknown key", "9", editor, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestRoot(tt.editor)
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+
+			if tt.want == nil {
+				if m.current != nil {
+					t.Errorf("key %q started %T, want the menu to stay open", tt.key, unwrap(m.current))
+				}
+				return
+			}
+			if m.current == nil || !tt.want(unwrap(m.current)) {
+				t.Errorf("key %q started %T, want a different flow", tt.key, unwrap(m.current))
+			}
+			if cmd == nil {
+				t.Errorf("key %q returned no command, want the flow started", tt.key)
+			}
+		})
+	}
+}
+
+func TestRootMenuNavigation(t *testing.T) {
+	opened := 0
+	editor := func() tea.Model {
+		opened++
+		return NewViewportWrapper(stubFlow{}, ViewportConfig{EnableMenu: true})
+	}
+	m := newTestRoot(editor)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	if !m.IsDelegating() {
+		t.Fatal("3 didn't open the flow")
+	}
+
+	// 'm' inside the flow sends MenuNavigationMsg back up to the root
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	if cmd == nil {
+		t.Fatal("m in the flow returned no command")
+	}
+	msg := cmd()
+	if _, ok := msg.(MenuNavigationMsg); !ok {
+		t.Fatalf("m in the flow sent %T, want MenuNavigationMsg", msg)
+	}
+	m.Update(msg)
+	if m.IsDelegating() {
+		t.Fatalf("still delegating to %T after MenuNavigationMsg", unwrap(m.current))
+	}
+	if view := m.View(); !strings.Contains(view, "Manage links") {
+		t.Errorf("menu not shown after returning:\n%s", view)
+	}
+
+	// Reopening starts a fresh flow and asks for the terminal size again
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	if opened != 2 || !m.IsDelegating() {
+		t.Fatalf("opened %d flows, delegating %v; want a second flow open", opened, m.IsDelegating())
+	}
+	if !requestsWindowSize(cmd) {
+		t.Error("opening a flow didn't request the window size")
+	}
+}
+
+// requestsWindowSize reports whether cmd, or a command it batches, is
+// tea.WindowSize()
+func requestsWindowSize(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	want := fmt.Sprintf("%T", tea.WindowSize()())
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if requestsWindowSize(c) {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprintf("%T", msg) == want
+	}
+}
+
+func TestRootWrapperDelegates(t *testing.T) {
+	wrapper := NewRootModel(nil, nil, nil, nil, 0, 0, 0, func() tea.Model { return stubFlow{} }).(*ViewportWrapper)
+	root := wrapper.model.(*rootModel)
+	wrapper.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	key := func(k string) tea.Cmd {
+		_, cmd := wrapper.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return cmd
+	}
+
+	if root.IsDelegating() {
+		t.Fatal("delegating before a flow was opened")
+	}
+	if view := wrapper.View(); !strings.Contains(view, "Link Management") {
+		t.Errorf("menu view lacks the wrapper's header:\n%s", view)
+	}
+
+	key("3")
+	if !root.IsDelegating() {
+		t.Fatal("not delegating with a flow open")
+	}
+	if view := wrapper.View(); strings.Contains(view, "Link Management") || !strings.Contains(view, "stub flow") {
+		t.Errorf("flow view should be the flow's alone:\n%s", view)
+	}
+	// The menu's help and quit keys are left to the flow
+	key("?")
+	if wrapper.showHelp {
+		t.Error("? in a flow opened the root menu's help")
+	}
+	if _, cmd := wrapper.Update(tea.KeyMsg{Type: tea.KeyEsc}); quits(cmd) {
+		t.Error("esc in a flow quit the program")
+	}
+
+	wrapper.Update(MenuNavigationMsg{})
+	if root.IsDelegating() {
+		t.Fatal("still delegating after returning to the menu")
+	}
+	if _, cmd := wrapper.Update(tea.KeyMsg{Type: tea.KeyEsc}); !quits(cmd) {
+		t.Error("esc at the menu didn't quit")
+	}
+}
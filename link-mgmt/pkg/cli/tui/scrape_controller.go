@@ -0,0 +1,74 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultScrapeTimeoutSeconds applies when no scrape timeout is configured
+const defaultScrapeTimeoutSeconds = 30
+
+// scrapeMergePolicy decides how scraped content is merged into the fields a
+// link already has. The merge itself is done by the API.
+type scrapeMergePolicy int
+
+const (
+	// mergeFillEmpty only fills fields that are empty, keeping what the user entered
+	mergeFillEmpty scrapeMergePolicy = iota
+	// mergeOverwrite replaces fields with any non-empty scraped value
+	mergeOverwrite
+)
+
+// onlyFillEmpty returns the API's only_fill_empty option for the policy
+func (p scrapeMergePolicy) onlyFillEmpty() bool {
+	return p != mergeOverwrite
+}
+
+// scrapeController holds what the add and manage flows share around an
+// API-side scrape: the timeout, the merge policy, the elapsed-time display,
+// and turning a failure into an error for the user. Embed it in a model,
+// call Start with the command that runs the scrape, pass scrapeTickMsg to
+// Update, and call Finish with the scrape's result.
+type scrapeController struct {
+	timeoutSeconds int
+	policy         scrapeMergePolicy
+	timer          scrapeTimer
+}
+
+// newScrapeController creates a controller; timeoutSeconds <= 0 uses the default
+func newScrapeController(timeoutSeconds int, policy scrapeMergePolicy) scrapeController {
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaultScrapeTimeoutSeconds
+	}
+	return scrapeController{
+		timeoutSeconds: timeoutSeconds,
+		policy:         policy,
+	}
+}
+
+// Start runs the scrape command alongside the elapsed-time display
+func (c *scrapeController) Start(run tea.Cmd) tea.Cmd {
+	return tea.Batch(run, c.timer.Start(c.timeoutSeconds))
+}
+
+// Update advances the elapsed-time display
+func (c *scrapeController) Update(msg scrapeTickMsg) tea.Cmd {
+	return c.timer.Update(msg)
+}
+
+// Finish stops the timer and returns the error to show for err, or nil if
+// the scrape succeeded. Timeouts explain how to raise cli.scrape_timeout.
+func (c *scrapeController) Finish(err error) error {
+	c.timer.Stop()
+	if err == nil {
+		return nil
+	}
+	if c.timer.TimedOut() || isTimeoutError(err) {
+		return scrapeTimeoutError(c.timeoutSeconds)
+	}
+	return userFacingError(err)
+}
+
+// View renders the elapsed time and countdown while the scrape runs
+func (c *scrapeController) View() string {
+	return c.timer.View()
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/cli/client"
+	"link-mgmt/pkg/cli/tui/managelinks"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestManageLinksEnrichMergePolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want string // only_fill_empty sent to the API
+	}{
+		{"enrich fills empty fields", []string{"3"}, "true"},
+		{"s fills empty fields", []string{"s"}, "true"},
+		{"S overwrites", []string{"S"}, "false"},
+		{"retry keeps overwriting", []string{"S", "r"}, "false"},
+		{"retry keeps filling empty fields", []string{"3", "r"}, "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent = append(sent, r.URL.Query().Get("only_fill_empty"))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte(`{"error": "scraper unavailable"}`))
+			}))
+			t.Cleanup(server.Close)
+
+			m := newTestManageLinks(testLink("https://example.com/page", "Page"))
+			m.client = client.NewClient(server.URL, "test-key")
+			m.step = managelinks.StepActionMenu
+			for _, key := range tt.keys {
+				m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+				drainEnrich(t, m)
+			}
+
+			if len(sent) != len(tt.keys) {
+				t.Fatalf("sent %d enrich requests, want %d", len(sent), len(tt.keys))
+			}
+			for i, got := range sent {
+				if got != tt.want {
+					t.Errorf("request %d: only_fill_empty = %q, want %q", i+1, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewScrapeController(t *testing.T) {
+	tests := []struct {
+		timeoutSeconds int
+		want           int
+	}{
+		{0, defaultScrapeTimeoutSeconds},
+		{-5, defaultScrapeTimeoutSeconds},
+		{45, 45},
+	}
+	for _, tt := range tests {
+		c := newScrapeController(tt.timeoutSeconds, mergeOverwrite)
+		if c.timeoutSeconds != tt.want {
+			t.Errorf("newScrapeController(%d): timeout = %d, want %d", tt.timeoutSeconds, c.timeoutSeconds, tt.want)
+		}
+		if c.policy != mergeOverwrite {
+			t.Errorf("newScrapeController(%d): policy = %d, want %d", tt.timeoutSeconds, c.policy, mergeOverwrite)
+		}
+	}
+}
+
+func TestScrapeControllerFinish(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		elapsed time.Duration
+		want    string // substring of the error; "" for none
+	}{
+		{"success", nil, 0, ""},
+		{"deadline exceeded", context.DeadlineExceeded, 0, "scraping timed out after 20s"},
+		{"gateway timeout", &client.APIError{StatusCode: http.StatusGatewayTimeout, Message: "scrape timed out"}, 0, "scraping timed out after 20s"},
+		{"failure after the timeout", errors.New("connection reset"), 21 * time.Second, "scraping timed out after 20s"},
+		{"API error", &client.APIError{StatusCode: http.StatusNotFound, Message: "link not found"}, 0, "Not found"},
+		{"other error", errors.New("connection reset"), 0, "connection reset"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newScrapeController(20, mergeFillEmpty)
+			c.Start(nil)
+			c.timer.started = time.Now().Add(-tt.elapsed)
+
+			err := c.Finish(tt.err)
+			if c.timer.running {
+				t.Error("timer still running after Finish")
+			}
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Finish(nil) = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Finish(%v) = %v, want it to contain %q", tt.err, err, tt.want)
+			}
+		})
+	}
+}
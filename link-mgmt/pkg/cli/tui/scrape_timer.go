@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scrapeTimerInterval is how often a running scrapeTimer refreshes the view
+const scrapeTimerInterval = time.Second
+
+// scrapeTickMsg advances a running scrapeTimer
+type scrapeTickMsg struct {
+	timer *scrapeTimer
+	run   int // the Start call that scheduled the tick
+}
+
+// scrapeTimer shows elapsed time and a countdown against the scrape timeout
+// while a scrape is in flight. Embed it in a model, call Start when the
+// scrape begins, pass scrapeTickMsg to Update, and Stop when it finishes.
+type scrapeTimer struct {
+	started time.Time
+	timeout time.Duration
+	running bool
+	run     int // incremented by Start, so ticks from an earlier run die out
+}
+
+// Start resets the timer and returns the command that drives its ticks
+func (t *scrapeTimer) Start(timeoutSeconds int) tea.Cmd {
+	t.started = time.Now()
+	t.timeout = time.Duration(timeoutSeconds) * time.Second
+	t.running = true
+	t.run++
+	return t.tick()
+}
+
+// Stop freezes the timer; pending ticks are ignored
+func (t *scrapeTimer) Stop() {
+	t.running = false
+}
+
+// Update schedules the next tick for this timer's own tick messages
+func (t *scrapeTimer) Update(msg scrapeTickMsg) tea.Cmd {
+	if msg.timer != t || msg.run != t.run || !t.running {
+		return nil
+	}
+	return t.tick()
+}
+
+func (t *scrapeTimer) tick() tea.Cmd {
+	run := t.run
+	return tea.Tick(scrapeTimerInterval, func(time.Time) tea.Msg {
+		return scrapeTickMsg{timer: t, run: run}
+	})
+}
+
+// Elapsed returns the time since Start
+func (t *scrapeTimer) Elapsed() time.Duration {
+	return time.Since(t.started)
+}
+
+// TimedOut reports whether the scrape has run past its timeout
+func (t *scrapeTimer) TimedOut() bool {
+	return t.timeout > 0 && t.Elapsed() >= t.timeout
+}
+
+// View renders e.g. "Elapsed 0:07 · timeout in 0:23"
+func (t *scrapeTimer) View() string {
+	elapsed := t.Elapsed()
+	if t.timeout <= 0 {
+		return mutedStyle.Render("Elapsed " + formatElapsed(elapsed))
+	}
+	if t.TimedOut() {
+		return warningStyle.Render(fmt.Sprintf("Elapsed %s · timeout of %s reached, waiting for the server...",
+			formatElapsed(elapsed), formatElapsed(t.timeout)))
+	}
+	return mutedStyle.Render(fmt.Sprintf("Elapsed %s · timeout in %s",
+		formatElapsed(elapsed), formatElapsed(t.timeout-elapsed)))
+}
+
+// formatElapsed formats a duration as m:ss, rounding down to whole seconds
+func formatElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0:00"},
+		{-3 * time.Second, "0:00"},
+		{999 * time.Millisecond, "0:00"},
+		{time.Second, "0:01"},
+		{9*time.Second + 900*time.Millisecond, "0:09"},
+		{59 * time.Second, "0:59"},
+		{time.Minute, "1:00"},
+		{90 * time.Second, "1:30"},
+		{10*time.Minute + 5*time.Second, "10:05"},
+		{2 * time.Hour, "120:00"},
+	}
+	for _, tt := range tests {
+		if got := formatElapsed(tt.d); got != tt.want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeTimerView(t *testing.T) {
+	var timer scrapeTimer
+	timer.Start(30)
+	timer.started = time.Now().Add(-6500 * time.Millisecond)
+	if view := timer.View(); !strings.Contains(view, "Elapsed 0:06") || !strings.Contains(view, "timeout in 0:23") {
+		t.Errorf("View() = %q, want 6s elapsed with 23s left", view)
+	}
+
+	timer.started = time.Now().Add(-31 * time.Second)
+	if !timer.TimedOut() {
+		t.Error("TimedOut() = false past the timeout")
+	}
+	if view := timer.View(); !strings.Contains(view, "timeout of 0:30 reached") {
+		t.Errorf("View() = %q, want the timeout reached", view)
+	}
+
+	timer.Start(0)
+	if timer.TimedOut() {
+		t.Error("TimedOut() = true without a timeout")
+	}
+	if view := timer.View(); strings.Contains(view, "timeout") {
+		t.Errorf("View() = %q, want no countdown without a timeout", view)
+	}
+}
+
+func TestScrapeTimerIgnoresStaleTicks(t *testing.T) {
+	var timer scrapeTimer
+	timer.Start(30)
+	stale := scrapeTickMsg{timer: &timer, run: timer.run}
+
+	if cmd := timer.Update(stale); cmd == nil {
+		t.Error("a current tick didn't schedule the next one")
+	}
+	timer.Start(30)
+	if cmd := timer.Update(stale); cmd != nil {
+		t.Error("a tick from an earlier run scheduled another")
+	}
+	timer.Stop()
+	if cmd := timer.Update(scrapeTickMsg{timer: &timer, run: timer.run}); cmd != nil {
+		t.Error("a stopped timer scheduled another tick")
+	}
+	var other scrapeTimer
+	if cmd := timer.Update(scrapeTickMsg{timer: &other, run: timer.run}); cmd != nil {
+		t.Error("another timer's tick scheduled one")
+	}
+}
@@ -61,6 +61,10 @@ var (
 			Foreground(colorMuted).
 			Italic(true)
 
+	favoriteStyle = lipgloss.NewStyle().
+			Foreground(colorWarning).
+			Bold(true)
+
 	// Field label styles
 	fieldLabelStyle = lipgloss.NewStyle().
 			Foreground(colorPrimary).
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// textPager shows long text in a scrollable window. It owns its viewport and
+// sizes it to the space it is given, so the surrounding ViewportWrapper has
+// nothing left to scroll (see ScrollOwner).
+type textPager struct {
+	viewport viewport.Model
+	text     string
+}
+
+func newTextPager() textPager {
+	return textPager{viewport: viewport.New(0, 0)}
+}
+
+// Open shows text from the top
+func (p *textPager) Open(text string) {
+	p.text = text
+	p.rewrap()
+	p.viewport.GotoTop()
+}
+
+// SetSize fits the pager to width x height, re-wrapping the text for the new
+// width and keeping the scroll position where possible
+func (p *textPager) SetSize(width, height int) {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	if width == p.viewport.Width && height == p.viewport.Height {
+		return
+	}
+	p.viewport.Width = width
+	p.viewport.Height = height
+	p.rewrap()
+}
+
+// rewrap wraps the text to the viewport width, keeping paragraph breaks
+func (p *textPager) rewrap() {
+	p.viewport.SetContent(lipgloss.NewStyle().Width(p.viewport.Width).Render(p.text))
+}
+
+// Update scrolls on the viewport's keys: ↑/↓ or j/k, PgUp/PgDn or space,
+// Ctrl+U/Ctrl+D for half pages, and Home/End
+func (p *textPager) Update(msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "home", "g":
+			p.viewport.GotoTop()
+			return nil
+		case "end", "G":
+			p.viewport.GotoBottom()
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return cmd
+}
+
+// View renders the visible part of the text
+func (p *textPager) View() string {
+	return p.viewport.View()
+}
+
+// Status describes the visible lines, e.g. "lines 21-40 of 312 (12%)"
+func (p *textPager) Status() string {
+	total := p.viewport.TotalLineCount()
+	if total == 0 {
+		return "empty"
+	}
+	first, last := pagerWindow(p.viewport.YOffset, p.viewport.Height, total)
+	return fmt.Sprintf("lines %d-%d of %d (%d%%)", first+1, last, total, int(p.viewport.ScrollPercent()*100))
+}
+
+// pagerWindow returns the half-open range [first, last) of lines visible
+// with the given scroll offset and height, clamped to total lines
+func pagerWindow(offset, height, total int) (first, last int) {
+	if height < 1 {
+		height = 1
+	}
+	first = max(0, min(offset, total-height))
+	last = min(total, first+height)
+	return first, last
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"link-mgmt/pkg/cli/tui/managelinks"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPagerWindow(t *testing.T) {
+	tests := []struct {
+		name                  string
+		offset, height, total int
+		wantFirst, wantLast   int
+	}{
+		{"top", 0, 10, 100, 0, 10},
+		{"middle", 35, 10, 100, 35, 45},
+		{"bottom", 90, 10, 100, 90, 100},
+		{"offset past the end", 95, 10, 100, 90, 100},
+		{"negative offset", -3, 10, 100, 0, 10},
+		{"text shorter than the window", 0, 10, 4, 0, 4},
+		{"offset with short text", 2, 10, 4, 0, 4},
+		{"zero height shows one line", 5, 0, 100, 5, 6},
+		{"no text", 0, 10, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last := pagerWindow(tt.offset, tt.height, tt.total)
+			if first != tt.wantFirst || last != tt.wantLast {
+				t.Errorf("pagerWindow(%d, %d, %d) = [%d, %d), want [%d, %d)",
+					tt.offset, tt.height, tt.total, first, last, tt.wantFirst, tt.wantLast)
+			}
+		})
+	}
+}
+
+// numberedLines returns n lines "line 1" to "line n"
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestTextPagerScrolling(t *testing.T) {
+	p := newTextPager()
+	p.SetSize(40, 10)
+	p.Open(numberedLines(100))
+
+	steps := []struct {
+		key  tea.KeyMsg
+		want string
+	}{
+		{tea.KeyMsg{}, "lines 1-10 of 100"},
+		{tea.KeyMsg{Type: tea.KeyDown}, "lines 2-11 of 100"},
+		{tea.KeyMsg{Type: tea.KeyPgDown}, "lines 12-21 of 100"},
+		{tea.KeyMsg{Type: tea.KeyEnd}, "lines 91-100 of 100"},
+		{tea.KeyMsg{Type: tea.KeyDown}, "lines 91-100 of 100"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")}, "lines 1-10 of 100"},
+		{tea.KeyMsg{Type: tea.KeyUp}, "lines 1-10 of 100"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")}, "lines 91-100 of 100"},
+		{tea.KeyMsg{Type: tea.KeyHome}, "lines 1-10 of 100"},
+	}
+	for i, step := range steps {
+		if i > 0 {
+			p.Update(step.key)
+		}
+		if got := p.Status(); !strings.HasPrefix(got, step.want) {
+			t.Fatalf("step %d (%s): status = %q, want %q", i, step.key, got, step.want)
+		}
+	}
+
+	view := p.View()
+	if !strings.HasPrefix(view, "line 1 ") || !strings.Contains(view, "line 10") || strings.Contains(view, "line 11") {
+		t.Errorf("view at the top shows the wrong lines:\n%s", view)
+	}
+}
+
+func TestTextPagerRewrapsOnResize(t *testing.T) {
+	p := newTextPager()
+	p.SetSize(40, 5)
+	p.Open(strings.Repeat("word ", 40)) // 200 characters
+
+	if got := p.Status(); !strings.HasPrefix(got, "lines 1-5 of 5") {
+		t.Errorf("at width 40: status = %q, want 5 wrapped lines", got)
+	}
+	p.SetSize(20, 5)
+	if got := p.Status(); !strings.HasPrefix(got, "lines 1-5 of 10") {
+		t.Errorf("at width 20: status = %q, want 10 wrapped lines", got)
+	}
+
+	empty := newTextPager()
+	if got := empty.Status(); got != "empty" {
+		t.Errorf("empty pager status = %q, want \"empty\"", got)
+	}
+}
+
+func TestManageLinksReadText(t *testing.T) {
+	link := testLink("https://example.com/long", "Long Read")
+	text := numberedLines(200)
+	link.Text = &text
+	m := newTestManageLinks(link)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m.viewedLink = &link
+	m.step = managelinks.StepViewDetails
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if m.step != managelinks.StepReadText || !m.OwnsScrolling() {
+		t.Fatalf("after 't': step = %v, owns scrolling %v; want the pager", m.step, m.OwnsScrolling())
+	}
+	if h := m.pager.viewport.Height; h < 1 || h >= 30 {
+		t.Errorf("pager height = %d, want it to fit within the screen", h)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.pager.viewport.YOffset == 0 {
+		t.Error("PgDn didn't scroll the pager")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if m.step != managelinks.StepViewDetails || m.OwnsScrolling() {
+		t.Errorf("after 'b': step = %v, owns scrolling %v; want the details", m.step, m.OwnsScrolling())
+	}
+
+	// Without text there is nothing to read
+	empty := testLink("https://example.com/empty", "Empty")
+	m.viewedLink = &empty
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if m.step != managelinks.StepViewDetails {
+		t.Errorf("'t' without text: step = %v, want the details", m.step)
+	}
+}
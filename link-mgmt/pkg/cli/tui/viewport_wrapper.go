@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -26,6 +27,22 @@ type SelectableModel interface {
 	GetListHeaderHeight() int
 }
 
+// InputCapturer is an interface that models can implement to report that a
+// text input currently has focus. While capturing, the wrapper only handles
+// ctrl+c and forwards every other key (including 'q', 'm', '?' and Esc) to
+// the model so they can be typed.
+type InputCapturer interface {
+	IsCapturingInput() bool
+}
+
+// ScrollOwner is an interface that models can implement to report that they
+// are scrolling their own content (e.g. a text pager sized to fit). While they
+// are, the wrapper keeps its viewport at the top and leaves scrolling keys to
+// the model, so the two never scroll at once.
+type ScrollOwner interface {
+	OwnsScrolling() bool
+}
+
 // ViewportWrapper wraps a model with viewport and common command support
 type ViewportWrapper struct {
 	model    tea.Model
@@ -34,6 +51,11 @@ type ViewportWrapper struct {
 	height   int
 	config   ViewportConfig
 
+	// Actual terminal size from the last WindowSizeMsg, before width and
+	// height are clamped to the minimum; 0 until the first one arrives
+	termWidth  int
+	termHeight int
+
 	// Common commands
 	showHelp    bool
 	helpContent string
@@ -55,8 +77,8 @@ type ViewportConfig struct {
 	HeaderHeight int            // Fixed header height (0 = auto)
 	FooterHeight int            // Fixed footer height (0 = auto)
 	UseViewport  bool           // Enable scrolling (false = simple responsive)
-	MinWidth     int            // Minimum terminal width
-	MinHeight    int            // Minimum terminal height
+	MinWidth     int            // Minimum terminal width; smaller shows a resize message
+	MinHeight    int            // Minimum terminal height; smaller shows a resize message
 	EnableHelp   bool           // Enable '?' for help (proposed)
 	EnableMenu   bool           // Enable 'm' to return to menu (proposed)
 	HelpContent  func() string  // Function to generate help text
@@ -88,12 +110,12 @@ func (w *ViewportWrapper) Init() tea.Cmd {
 }
 
 func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	logger.Log("ViewportWrapper.Update() called: msg_type=%T", msg)
+	logger.Debug("ViewportWrapper.Update() called: msg_type=%T", msg)
 
 	// Handle window size first
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		logger.Log("ViewportWrapper.Update: WindowSizeMsg, width=%d, height=%d", msg.Width, msg.Height)
+		logger.Debug("ViewportWrapper.Update: WindowSizeMsg, width=%d, height=%d", msg.Width, msg.Height)
 		// Mark header/footer heights as dirty if dimensions changed significantly
 		// (header/footer might wrap differently with different widths)
 		if w.width != msg.Width {
@@ -101,8 +123,11 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		w.width = msg.Width
 		w.height = msg.Height
+		w.termWidth = msg.Width
+		w.termHeight = msg.Height
 
-		// Validate minimum size
+		// Lay out at no less than the minimum size; View shows a resize
+		// message instead while the terminal is smaller
 		if w.config.MinWidth > 0 && w.width < w.config.MinWidth {
 			w.width = w.config.MinWidth
 		}
@@ -112,13 +137,13 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Calculate layout and set viewport dimensions
 		w.calculateLayout()
-		logger.Log("ViewportWrapper.Update: calculated layout, viewport=%dx%d", w.viewport.Width, w.viewport.Height)
+		logger.Debug("ViewportWrapper.Update: calculated layout, viewport=%dx%d", w.viewport.Width, w.viewport.Height)
 
 		// Sync viewport with new dimensions
 		if w.config.UseViewport {
 			// Ensure viewport has valid dimensions before updating
 			if w.viewport.Width == 0 || w.viewport.Height == 0 {
-				logger.Log("ViewportWrapper.Update: viewport has invalid dimensions after calculateLayout, fixing...")
+				logger.Debug("ViewportWrapper.Update: viewport has invalid dimensions after calculateLayout, fixing...")
 				// Recalculate with fallback defaults if needed
 				if w.viewport.Width == 0 {
 					w.viewport.Width = w.width
@@ -140,7 +165,7 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						w.viewport.Height = 1
 					}
 				}
-				logger.Log("ViewportWrapper.Update: fixed viewport dimensions to %dx%d", w.viewport.Width, w.viewport.Height)
+				logger.Debug("ViewportWrapper.Update: fixed viewport dimensions to %dx%d", w.viewport.Width, w.viewport.Height)
 			}
 
 			// Update viewport with new dimensions
@@ -162,16 +187,28 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return w, cmd
 	}
 
+	// While rootModel runs a flow, the flow's own wrapper handles help, menu,
+	// and quit keys, so pass everything through (as View does)
+	if w.isDelegatingToWrappedModel() {
+		var cmd tea.Cmd
+		w.model, cmd = w.model.Update(msg)
+		return w, cmd
+	}
+
 	// Handle common commands
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		key := msg.String()
-		logger.Log("ViewportWrapper.Update: KeyMsg, key=%q, showHelp=%v", key, w.showHelp)
+		logger.Debug("ViewportWrapper.Update: KeyMsg, key=%q, showHelp=%v", key, w.showHelp)
+		if w.IsCapturingInput() && key != "ctrl+c" {
+			logger.Debug("ViewportWrapper.Update: wrapped model is capturing input, skipping common commands")
+			break
+		}
 		switch key {
 		case "?":
 			if w.config.EnableHelp {
 				w.showHelp = !w.showHelp
-				logger.Log("ViewportWrapper.Update: toggled help, showHelp=%v", w.showHelp)
+				logger.Debug("ViewportWrapper.Update: toggled help, showHelp=%v", w.showHelp)
 				if w.showHelp && w.config.HelpContent != nil {
 					w.helpContent = w.config.HelpContent()
 				}
@@ -180,7 +217,7 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "m":
 			if w.config.EnableMenu {
-				logger.Log("ViewportWrapper.Update: menu key pressed")
+				logger.Debug("ViewportWrapper.Update: menu key pressed")
 				if w.config.OnMenu != nil {
 					// Use the callback if provided
 					return w, w.config.OnMenu()
@@ -193,11 +230,11 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c", "q", "esc":
 			// Only quit if help is not showing
 			if !w.showHelp {
-				logger.Log("ViewportWrapper.Update: quit key pressed")
+				logger.Debug("ViewportWrapper.Update: quit key pressed")
 				return w, tea.Quit
 			}
 			// If help is showing, close it
-			logger.Log("ViewportWrapper.Update: closing help overlay")
+			logger.Debug("ViewportWrapper.Update: closing help overlay")
 			w.showHelp = false
 			return w, nil
 		}
@@ -225,11 +262,11 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Forward all other messages to wrapped model
 	var cmd tea.Cmd
 	if w.model != nil {
-		logger.Log("ViewportWrapper.Update: forwarding msg type=%T to wrapped model", msg)
+		logger.Debug("ViewportWrapper.Update: forwarding msg type=%T to wrapped model", msg)
 		w.model, cmd = w.model.Update(msg)
-		logger.Log("ViewportWrapper.Update: wrapped model updated, cmd=%v", cmd != nil)
+		logger.Debug("ViewportWrapper.Update: wrapped model updated, cmd=%v", cmd != nil)
 	} else {
-		logger.Log("ViewportWrapper.Update: WARNING - wrapped model is nil")
+		logger.Debug("ViewportWrapper.Update: WARNING - wrapped model is nil")
 	}
 
 	// Automatic scrolling: if model implements SelectableModel and selection changed,
@@ -241,11 +278,11 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// If transitioning from list view (prevSelectedIndex >= 0) to non-list view (currentSelected == -1),
 				// reset viewport scroll position to top
 				if w.prevSelectedIndex >= 0 && currentSelected == -1 {
-					logger.Log("ViewportWrapper.Update: transitioning from list to non-list view, resetting scroll position")
+					logger.Debug("ViewportWrapper.Update: transitioning from list to non-list view, resetting scroll position")
 					w.viewport.SetYOffset(0)
 				} else if currentSelected >= 0 {
 					// Only scroll to selected item if we're in a list view
-					logger.Log("ViewportWrapper.Update: selection changed from %d to %d, scrolling to keep visible",
+					logger.Debug("ViewportWrapper.Update: selection changed from %d to %d, scrolling to keep visible",
 						w.prevSelectedIndex, currentSelected)
 					w.scrollToSelected(selectable, currentSelected)
 				}
@@ -258,20 +295,26 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// This ensures viewport only handles scrolling when appropriate, allowing wrapped models
 	// to handle navigation keys (j/k, enter, etc.) without interference
 	if w.config.UseViewport {
+		if owner, ok := w.model.(ScrollOwner); ok && owner.OwnsScrolling() {
+			logger.Debug("ViewportWrapper.Update: wrapped model owns scrolling, pinning viewport to top")
+			w.viewport.SetYOffset(0)
+			return w, cmd
+		}
+
 		// Only forward scrolling keys to viewport
 		// This prevents viewport from intercepting navigation keys when scrolling isn't needed
 		if isScrollingKey(msg) {
 			if keyMsg, ok := msg.(tea.KeyMsg); ok {
-				logger.Log("ViewportWrapper.Update: forwarding scrolling key %q to viewport", keyMsg.String())
+				logger.Debug("ViewportWrapper.Update: forwarding scrolling key %q to viewport", keyMsg.String())
 			}
 			var vpCmd tea.Cmd
 			w.viewport, vpCmd = w.viewport.Update(msg)
 			if vpCmd != nil {
-				logger.Log("ViewportWrapper.Update: viewport returned a command")
+				logger.Debug("ViewportWrapper.Update: viewport returned a command")
 			}
 			cmd = tea.Batch(cmd, vpCmd)
 		} else if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			logger.Log("ViewportWrapper.Update: key %q is not a scrolling key, skipping viewport", keyMsg.String())
+			logger.Debug("ViewportWrapper.Update: key %q is not a scrolling key, skipping viewport", keyMsg.String())
 		}
 	}
 
@@ -279,41 +322,47 @@ func (w *ViewportWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (w *ViewportWrapper) View() string {
-	logger.Log("ViewportWrapper.View() called: showHelp=%v, UseViewport=%v, width=%d, height=%d, model=%v",
+	logger.Debug("ViewportWrapper.View() called: showHelp=%v, UseViewport=%v, width=%d, height=%d, model=%v",
 		w.showHelp, w.config.UseViewport, w.width, w.height, w.model != nil)
 
+	// A flow being delegated to has its own wrapper, which checks its own minimum
+	if !w.isDelegatingToWrappedModel() && w.tooSmall() {
+		logger.Debug("ViewportWrapper.View: terminal %dx%d below minimum, rendering resize message", w.termWidth, w.termHeight)
+		return w.renderTooSmall()
+	}
+
 	// If help is showing, render help overlay
 	if w.showHelp {
-		logger.Log("ViewportWrapper.View: rendering help overlay")
+		logger.Debug("ViewportWrapper.View: rendering help overlay")
 		return w.renderHelpOverlay()
 	}
 
 	// Get content from wrapped model
 	content := ""
 	if w.model != nil {
-		logger.Log("ViewportWrapper.View: getting content from wrapped model")
+		logger.Debug("ViewportWrapper.View: getting content from wrapped model")
 		content = w.model.View()
-		logger.Log("ViewportWrapper.View: wrapped model returned content, length=%d bytes", len(content))
+		logger.Debug("ViewportWrapper.View: wrapped model returned content, length=%d bytes", len(content))
 
 		// Check if wrapped model is delegating to another wrapped model (like rootModel -> manageLinksModel)
 		// If the wrapped model is a rootModel with an active flow, pass through without adding headers/footers
 		if w.isDelegatingToWrappedModel() {
-			logger.Log("ViewportWrapper.View: wrapped model is delegating, passing through without headers/footers")
+			logger.Debug("ViewportWrapper.View: wrapped model is delegating, passing through without headers/footers")
 			return content
 		}
 	} else {
-		logger.Log("ViewportWrapper.View: WARNING - wrapped model is nil")
+		logger.Debug("ViewportWrapper.View: WARNING - wrapped model is nil")
 	}
 
 	// Apply viewport if enabled
 	if w.config.UseViewport {
 		// Ensure viewport has valid dimensions (should be set in Update, but check as safety)
 		if w.viewport.Width == 0 || w.viewport.Height == 0 {
-			logger.Log("ViewportWrapper.View: viewport has invalid dimensions, recalculating...")
+			logger.Debug("ViewportWrapper.View: viewport has invalid dimensions, recalculating...")
 			w.calculateLayout()
 		}
 
-		logger.Log("ViewportWrapper.View: applying viewport, viewport size=%dx%d, wrapper size=%dx%d, content length=%d",
+		logger.Debug("ViewportWrapper.View: applying viewport, viewport size=%dx%d, wrapper size=%dx%d, content length=%d",
 			w.viewport.Width, w.viewport.Height, w.width, w.height, len(content))
 
 		// Constrain content width to viewport width for proper measurement
@@ -325,14 +374,14 @@ func (w *ViewportWrapper) View() string {
 			content = lipgloss.NewStyle().
 				Width(w.viewport.Width).
 				Render(content)
-			logger.Log("ViewportWrapper.View: constrained content width from %d to %d", contentWidth, w.viewport.Width)
+			logger.Debug("ViewportWrapper.View: constrained content width from %d to %d", contentWidth, w.viewport.Width)
 		}
 
 		// Set content - viewport will handle scrolling
 		// Note: SetContent should be called every render cycle, which is correct here
 		w.viewport.SetContent(content)
 		content = w.viewport.View()
-		logger.Log("ViewportWrapper.View: viewport returned content, length=%d bytes", len(content))
+		logger.Debug("ViewportWrapper.View: viewport returned content, length=%d bytes", len(content))
 	}
 
 	// Build layout
@@ -342,23 +391,23 @@ func (w *ViewportWrapper) View() string {
 	if w.config.ShowHeader {
 		header := w.renderHeader()
 		parts = append(parts, header)
-		logger.Log("ViewportWrapper.View: added header, length=%d bytes", len(header))
+		logger.Debug("ViewportWrapper.View: added header, length=%d bytes", len(header))
 	}
 
 	// Content
 	parts = append(parts, content)
-	logger.Log("ViewportWrapper.View: added content, length=%d bytes", len(content))
+	logger.Debug("ViewportWrapper.View: added content, length=%d bytes", len(content))
 
 	// Footer
 	if w.config.ShowFooter {
 		footer := w.renderFooter()
 		parts = append(parts, footer)
-		logger.Log("ViewportWrapper.View: added footer, length=%d bytes", len(footer))
+		logger.Debug("ViewportWrapper.View: added footer, length=%d bytes", len(footer))
 	}
 
 	// Join with proper spacing
 	result := lipgloss.JoinVertical(lipgloss.Left, parts...)
-	logger.Log("ViewportWrapper.View: final result length=%d bytes", len(result))
+	logger.Debug("ViewportWrapper.View: final result length=%d bytes", len(result))
 	return result
 }
 
@@ -369,13 +418,13 @@ func (w *ViewportWrapper) calculateLayout() {
 		// Use cached height if available and not dirty
 		if !w.headerFooterDirty && w.cachedHeaderHeight > 0 {
 			headerH = w.cachedHeaderHeight
-			logger.Log("ViewportWrapper.calculateLayout: using cached header height: %d", headerH)
+			logger.Debug("ViewportWrapper.calculateLayout: using cached header height: %d", headerH)
 		} else {
 			// Measure actual header height dynamically
 			header := w.renderHeader()
 			headerH = lipgloss.Height(header)
 			w.cachedHeaderHeight = headerH
-			logger.Log("ViewportWrapper.calculateLayout: measured header height: %d", headerH)
+			logger.Debug("ViewportWrapper.calculateLayout: measured header height: %d", headerH)
 		}
 	}
 
@@ -385,13 +434,13 @@ func (w *ViewportWrapper) calculateLayout() {
 		// Use cached height if available and not dirty
 		if !w.headerFooterDirty && w.cachedFooterHeight > 0 {
 			footerH = w.cachedFooterHeight
-			logger.Log("ViewportWrapper.calculateLayout: using cached footer height: %d", footerH)
+			logger.Debug("ViewportWrapper.calculateLayout: using cached footer height: %d", footerH)
 		} else {
 			// Measure actual footer height dynamically
 			footer := w.renderFooter()
 			footerH = lipgloss.Height(footer)
 			w.cachedFooterHeight = footerH
-			logger.Log("ViewportWrapper.calculateLayout: measured footer height: %d", footerH)
+			logger.Debug("ViewportWrapper.calculateLayout: measured footer height: %d", footerH)
 		}
 	}
 
@@ -417,7 +466,7 @@ func (w *ViewportWrapper) calculateLayout() {
 		}
 		w.viewport.Width = w.width
 		w.viewport.Height = contentH
-		logger.Log("ViewportWrapper.calculateLayout: set viewport to %dx%d (wrapper: %dx%d, headerH: %d, footerH: %d)",
+		logger.Debug("ViewportWrapper.calculateLayout: set viewport to %dx%d (wrapper: %dx%d, headerH: %d, footerH: %d)",
 			w.viewport.Width, w.viewport.Height, w.width, w.height, headerH, footerH)
 	}
 }
@@ -456,6 +505,15 @@ func (w *ViewportWrapper) renderFooter() string {
 	return helpStyle.Render(strings.Join(shortcuts, " • "))
 }
 
+// IsCapturingInput implements InputCapturer by asking the wrapped model.
+// This lets an outer wrapper (around rootModel) see through to the active flow.
+func (w *ViewportWrapper) IsCapturingInput() bool {
+	if capturer, ok := w.model.(InputCapturer); ok {
+		return capturer.IsCapturingInput()
+	}
+	return false
+}
+
 // isDelegatingToWrappedModel checks if the wrapped model is delegating to another wrapped model.
 // This happens when rootModel has an active flow - we should pass through without adding headers/footers.
 func (w *ViewportWrapper) isDelegatingToWrappedModel() bool {
@@ -472,6 +530,28 @@ func (w *ViewportWrapper) isDelegatingToWrappedModel() bool {
 	return false
 }
 
+// tooSmall reports whether the terminal is narrower or shorter than the
+// configured minimum. It is false until the terminal size is known.
+func (w *ViewportWrapper) tooSmall() bool {
+	if w.termWidth <= 0 || w.termHeight <= 0 {
+		return false
+	}
+	return (w.config.MinWidth > 0 && w.termWidth < w.config.MinWidth) ||
+		(w.config.MinHeight > 0 && w.termHeight < w.config.MinHeight)
+}
+
+// renderTooSmall renders the message asking to resize the terminal, centered
+// in it, in place of a layout that wouldn't fit
+func (w *ViewportWrapper) renderTooSmall() string {
+	message := lipgloss.JoinVertical(lipgloss.Center,
+		warningStyle.Render("Terminal too small"),
+		fmt.Sprintf("Please resize to at least %dx%d", w.config.MinWidth, w.config.MinHeight),
+		mutedStyle.Render(fmt.Sprintf("(currently %dx%d; Ctrl+C to quit)", w.termWidth, w.termHeight)),
+	)
+	message = lipgloss.NewStyle().Width(w.termWidth).Align(lipgloss.Center).Render(message)
+	return lipgloss.Place(w.termWidth, w.termHeight, lipgloss.Center, lipgloss.Center, message)
+}
+
 func (w *ViewportWrapper) renderHelpOverlay() string {
 	// Render help as overlay with semi-transparent background
 	helpText := w.helpContent
@@ -526,7 +606,7 @@ func (w *ViewportWrapper) scrollToSelected(selectable SelectableModel, selectedI
 
 	if viewportHeight <= 0 {
 		// Viewport not initialized yet, can't scroll
-		logger.Log("ViewportWrapper.scrollToSelected: viewport height is 0, skipping scroll")
+		logger.Debug("ViewportWrapper.scrollToSelected: viewport height is 0, skipping scroll")
 		return
 	}
 
@@ -548,7 +628,7 @@ func (w *ViewportWrapper) scrollToSelected(selectable SelectableModel, selectedI
 				newOffset -= 2
 			}
 			w.viewport.SetYOffset(newOffset)
-			logger.Log("ViewportWrapper.scrollToSelected: scrolled up, new offset=%d (selected at %d)",
+			logger.Debug("ViewportWrapper.scrollToSelected: scrolled up, new offset=%d (selected at %d)",
 				newOffset, selectedY)
 		} else {
 			// Item is below visible area, scroll down to show it
@@ -558,11 +638,11 @@ func (w *ViewportWrapper) scrollToSelected(selectable SelectableModel, selectedI
 				newOffset = 0
 			}
 			w.viewport.SetYOffset(newOffset)
-			logger.Log("ViewportWrapper.scrollToSelected: scrolled down, new offset=%d (selected at %d)",
+			logger.Debug("ViewportWrapper.scrollToSelected: scrolled down, new offset=%d (selected at %d)",
 				newOffset, selectedY)
 		}
 	} else {
-		logger.Log("ViewportWrapper.scrollToSelected: item already visible at Y=%d (offset=%d, height=%d)",
+		logger.Debug("ViewportWrapper.scrollToSelected: item already visible at Y=%d (offset=%d, height=%d)",
 			selectedY, currentYOffset, viewportHeight)
 	}
 }
@@ -586,11 +666,9 @@ func isScrollingKey(msg tea.Msg) bool {
 	case "ctrl+b", "ctrl+f":
 		// Page scrolling (alternative)
 		return true
-	case " ", "shift+space":
-		// Space for page down, shift+space for page up
-		return true
 	default:
-		// All other keys (including j/k, enter, etc.) are not scrolling keys
+		// All other keys (including j/k, enter, space, etc.) are not scrolling keys.
+		// Space is reserved for marking items in list views.
 		// This allows wrapped models to handle navigation without interference
 		return false
 	}
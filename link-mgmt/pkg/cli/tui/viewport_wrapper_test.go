@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestViewportWrapperTooSmall(t *testing.T) {
+	tests := []struct {
+		name          string
+		minW, minH    int
+		width, height int
+		want          bool
+	}{
+		{"fits", 80, 24, 80, 24, false},
+		{"larger", 80, 24, 120, 40, false},
+		{"too narrow", 80, 24, 79, 24, true},
+		{"too short", 80, 24, 80, 23, true},
+		{"both", 80, 24, 40, 10, true},
+		{"no minimum", 0, 0, 20, 5, false},
+		{"width minimum only", 80, 0, 100, 5, false},
+		{"size not yet known", 80, 24, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewViewportWrapper(stubFlow{}, ViewportConfig{MinWidth: tt.minW, MinHeight: tt.minH})
+			if tt.width > 0 {
+				w.Update(tea.WindowSizeMsg{Width: tt.width, Height: tt.height})
+			}
+			if got := w.tooSmall(); got != tt.want {
+				t.Errorf("tooSmall at %dx%d with minimum %dx%d = %v, want %v", tt.width, tt.height, tt.minW, tt.minH, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewportWrapperResizeMessage(t *testing.T) {
+	w := NewViewportWrapper(stubFlow{}, ViewportConfig{MinWidth: 80, MinHeight: 24})
+
+	w.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+	view := w.View()
+	for _, want := range []string{"Terminal too small", "at least 80x24", "currently 60x20"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("small terminal: view lacks %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "stub flow") {
+		t.Errorf("small terminal: view still renders the model:\n%s", view)
+	}
+	if lines := strings.Count(view, "\n") + 1; lines > 20 {
+		t.Errorf("message is %d lines, taller than the 20-line terminal", lines)
+	}
+	// Layout still uses the minimum, so the wrapped model sees no change
+	if w.width != 80 || w.height != 24 {
+		t.Errorf("layout size = %dx%d, want the 80x24 minimum", w.width, w.height)
+	}
+
+	w.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	if view := w.View(); strings.Contains(view, "Terminal too small") || !strings.Contains(view, "stub flow") {
+		t.Errorf("after resizing to fit: view should be the model's:\n%s", view)
+	}
+}
+
+func TestViewportWrapperResizeMessageLeftToFlow(t *testing.T) {
+	wrapper := NewRootModel(nil, nil, nil, nil, 0, 0, 0, func() tea.Model { return stubFlow{} }).(*ViewportWrapper)
+	wrapper.Update(tea.WindowSizeMsg{Width: 40, Height: 8})
+	if view := wrapper.View(); !strings.Contains(view, "Terminal too small") {
+		t.Fatalf("menu in a small terminal: view lacks the resize message:\n%s", view)
+	}
+
+	wrapper.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	if view := wrapper.View(); strings.Contains(view, "Terminal too small") || !strings.Contains(view, "stub flow") {
+		t.Errorf("delegating to a flow: view should be the flow's, which checks its own minimum:\n%s", view)
+	}
+}
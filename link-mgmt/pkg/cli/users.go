@@ -1,8 +1,13 @@
 package cli
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
+	"time"
 
 	"link-mgmt/pkg/cli/client"
 	"link-mgmt/pkg/config"
@@ -23,7 +28,12 @@ func (a *App) RegisterUser(email string) error {
 			return fmt.Errorf(`database table 'users' does not exist. Please run migrations first.
 
 To run migrations:
-  From project root (Docker):  make migrate`)
+  With the CLI (uses database.url):  --migrate
+  From project root (Docker):        make migrate`)
+		}
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			return fmt.Errorf("%s is already registered; use that account's API key (--config-set cli.api_key=<key>) or register a different email", email)
 		}
 		// Don't wrap the error again since it already contains "failed to register user"
 		return err
@@ -36,7 +46,13 @@ To run migrations:
 	}
 
 	// Update the client with the new API key
-	a.client = client.NewClient(a.cfg.CLI.BaseURL, user.APIKey)
+	a.client = a.newAPIClient(user.APIKey)
+
+	if a.quiet {
+		// The key is the one thing a script needs, and it isn't shown again
+		fmt.Println(user.APIKey)
+		return nil
+	}
 
 	fmt.Println("✓ User registered successfully!")
 	fmt.Printf("  Email: %s\n", user.Email)
@@ -47,3 +63,137 @@ To run migrations:
 
 	return nil
 }
+
+// WhoAmI prints the account that the configured API key belongs to
+func (a *App) WhoAmI() error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	user, err := apiClient.GetCurrentUser()
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			return fmt.Errorf("API key invalid or not configured: %s (register with --register <email> or set it with: --config-set cli.api_key=<key>)", apiErr.Message)
+		}
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	fmt.Printf("Email:   %s\n", user.Email)
+	fmt.Printf("User ID: %s\n", user.ID.String())
+	fmt.Printf("API key: %s\n", maskAPIKey(a.cfg.CLI.APIKey))
+	if user.IsReadOnly {
+		fmt.Printf("Access:  read-only\n")
+	}
+	fmt.Printf("Expires: %s\n", formatOptionalTime(user.ExpiresAt, "never"))
+	fmt.Printf("Last used: %s\n", formatOptionalTime(user.LastUsedAt, "never"))
+	return nil
+}
+
+// RotateAPIKey replaces the configured API key with a new one and saves it.
+// A nil expiresInDays uses the server's default expiry; 0 means never expire.
+func (a *App) RotateAPIKey(expiresInDays *int) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	user, err := apiClient.RotateAPIKey(expiresInDays)
+	if err != nil {
+		return err
+	}
+
+	// Save the new API key to config; the old key no longer works
+	a.cfg.CLI.APIKey = user.APIKey
+	if err := config.Save(a.cfg); err != nil {
+		return fmt.Errorf("API key rotated but failed to save it (new key: %s): %w", user.APIKey, err)
+	}
+	a.client = a.newAPIClient(user.APIKey)
+
+	fmt.Println("✓ API key rotated successfully!")
+	fmt.Printf("  Expires: %s\n", formatOptionalTime(user.ExpiresAt, "never"))
+	fmt.Printf("  API key saved to config automatically\n")
+	fmt.Println("\n⚠️  Save this API key securely (it won't be shown again):")
+	fmt.Printf("  %s\n", user.APIKey)
+
+	return nil
+}
+
+// CreateReadOnlyAPIKey creates a read-only API key for integrations and prints
+// it. The configured key is left unchanged.
+func (a *App) CreateReadOnlyAPIKey(expiresInDays *int) error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	key, err := apiClient.CreateReadOnlyAPIKey(expiresInDays)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("✓ Read-only API key created!")
+	fmt.Printf("  Expires: %s\n", formatOptionalTime(key.ExpiresAt, "never"))
+	fmt.Println("\n⚠️  Save this API key securely (it won't be shown again):")
+	fmt.Printf("  %s\n", key.APIKey)
+
+	return nil
+}
+
+// DeleteAccount permanently deletes the configured account and all of its
+// links. Since this cannot be undone, the user must type the account's email
+// to confirm; there is no way to skip the prompt.
+func (a *App) DeleteAccount() error {
+	apiClient, err := a.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	user, err := apiClient.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	fmt.Printf("⚠️  This permanently deletes the account %s, all of its links, and its API keys.\n", user.Email)
+	fmt.Printf("Type the account email to confirm: ")
+	answer, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if strings.TrimSpace(answer) != user.Email {
+		fmt.Println("Cancelled (email did not match)")
+		return nil
+	}
+
+	if err := apiClient.DeleteCurrentUser(); err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	// The key no longer authenticates, so don't leave it in the config
+	a.cfg.CLI.APIKey = ""
+	if err := config.Save(a.cfg); err != nil {
+		return fmt.Errorf("account deleted but failed to clear the API key from config: %w", err)
+	}
+	a.client = nil
+
+	fmt.Printf("✓ Deleted account %s\n", user.Email)
+	fmt.Println("  API key removed from config")
+	return nil
+}
+
+// formatOptionalTime formats t, or returns fallback when t is nil
+func formatOptionalTime(t *time.Time, fallback string) string {
+	if t == nil {
+		return fallback
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// maskAPIKey hides all but the first and last four characters of an API key
+func maskAPIKey(key string) string {
+	if len(key) <= 8 {
+		return strings.Repeat("*", len(key))
+	}
+	return key[:4] + strings.Repeat("*", len(key)-8) + key[len(key)-4:]
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWhoAmI(t *testing.T) {
+	api, app := newTestAPI(t)
+	app.cfg.CLI.APIKey = "abcd1234efgh5678"
+	api.Handle("GET /api/v1/users/me", respondJSON(http.StatusOK, map[string]interface{}{
+		"id":    "3f2a9c1e-0000-4000-8000-000000000001",
+		"email": "me@example.com",
+	}))
+
+	var err error
+	out := captureStdout(t, func() { err = app.WhoAmI() })
+	if err != nil {
+		t.Fatalf("WhoAmI: %v", err)
+	}
+	for _, want := range []string{"me@example.com", "3f2a9c1e-0000-4000-8000-000000000001", "abcd********5678"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "abcd1234efgh5678") {
+		t.Errorf("output shows the full API key:\n%s", out)
+	}
+}
+
+func TestWhoAmIUnauthorized(t *testing.T) {
+	api, app := newTestAPI(t)
+	api.Handle("GET /api/v1/users/me", respondJSON(http.StatusUnauthorized, map[string]string{"error": "invalid API key"}))
+
+	err := app.WhoAmI()
+	if err == nil || !strings.Contains(err.Error(), "API key invalid or not configured") {
+		t.Errorf("WhoAmI error = %v, want it to say the API key is invalid or not configured", err)
+	}
+}
+
+func TestMaskAPIKey(t *testing.T) {
+	tests := []struct {
+		key, want string
+	}{
+		{"", ""},
+		{"short", "*****"},
+		{"12345678", "********"},
+		{"123456789", "1234*6789"},
+		{"abcd1234efgh5678", "abcd********5678"},
+	}
+	for _, tt := range tests {
+		if got := maskAPIKey(tt.key); got != tt.want {
+			t.Errorf("maskAPIKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterUserQuiet(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	api, app := newTestAPI(t)
+	api.Handle("POST /api/v1/users", respondJSON(http.StatusCreated, map[string]interface{}{
+		"id":      "3f2a9c1e-0000-4000-8000-000000000001",
+		"email":   "new@example.com",
+		"api_key": "new-api-key",
+	}))
+	app.SetQuiet(true)
+
+	var err error
+	out := captureStdout(t, func() { err = app.RegisterUser("new@example.com") })
+	if err != nil {
+		t.Fatalf("RegisterUser: %v", err)
+	}
+	if out != "new-api-key\n" {
+		t.Errorf("output = %q, want only the API key", out)
+	}
+	if app.cfg.CLI.APIKey != "new-api-key" {
+		t.Errorf("configured API key = %q, want the new key saved", app.cfg.CLI.APIKey)
+	}
+}
+
+func TestAPIPrefixApplied(t *testing.T) {
+	api, app := newTestAPI(t)
+	app.cfg.CLI.APIPrefix = "/link-mgmt"
+	api.Handle("GET /link-mgmt/api/v1/users/me", respondJSON(http.StatusOK, map[string]interface{}{
+		"id":    "3f2a9c1e-0000-4000-8000-000000000001",
+		"email": "me@example.com",
+	}))
+
+	var err error
+	captureStdout(t, func() { err = app.WhoAmI() })
+	if err != nil {
+		t.Fatalf("WhoAmI: %v", err)
+	}
+	assertCalls(t, api, "GET /link-mgmt/api/v1/users/me")
+}
+
+func TestRegisterUserDuplicateEmail(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	api, app := newTestAPI(t)
+	api.Handle("POST /api/v1/users", respondJSON(http.StatusConflict, map[string]string{"error": "email already registered"}))
+
+	var err error
+	captureStdout(t, func() { err = app.RegisterUser("taken@example.com") })
+	if err == nil {
+		t.Fatal("RegisterUser succeeded for a registered email")
+	}
+	if !strings.Contains(err.Error(), "taken@example.com is already registered") {
+		t.Errorf("error = %q, want it to say the email is taken", err)
+	}
+	if app.cfg.CLI.APIKey != "test-key" {
+		t.Errorf("configured API key = %q, want it unchanged", app.cfg.CLI.APIKey)
+	}
+}
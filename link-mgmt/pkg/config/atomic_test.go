@@ -0,0 +1,181 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// tempFiles returns the leftover temp files in dir
+func tempFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matches
+}
+
+func TestWriteFileAtomicFailureKeepsExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	writeConfig(t, path, "[cli]\nbase_url = \"http://old\"\n")
+
+	renameFile = func(oldpath, newpath string) error { return errors.New("disk full") }
+	t.Cleanup(func() { renameFile = os.Rename })
+
+	if err := writeFileAtomic(path, []byte("[cli]\nbase_url = \"http://new\"\n"), 0644); err == nil {
+		t.Fatal("writeFileAtomic succeeded, want the rename error")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "http://old") {
+		t.Errorf("config after a failed write = %q, want the old contents", data)
+	}
+	if leftover := tempFiles(t, filepath.Dir(path)); len(leftover) != 0 {
+		t.Errorf("temp files left behind: %v", leftover)
+	}
+}
+
+func TestSaveFailureKeepsExistingConfig(t *testing.T) {
+	path := useHome(t)
+	cfg := DefaultConfig()
+	cfg.CLI.BaseURL = "http://old"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	renameFile = func(oldpath, newpath string) error { return errors.New("disk full") }
+	t.Cleanup(func() { renameFile = os.Rename })
+	cfg.CLI.BaseURL = "http://new"
+	if err := Save(cfg); err == nil {
+		t.Fatal("Save succeeded, want the write error")
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load after a failed save: %v", err)
+	}
+	if loaded.CLI.BaseURL != "http://old" {
+		t.Errorf("base_url = %q, want the previously saved %q", loaded.CLI.BaseURL, "http://old")
+	}
+	if leftover := tempFiles(t, filepath.Dir(path)); len(leftover) != 0 {
+		t.Errorf("temp files left behind: %v", leftover)
+	}
+}
+
+func TestWriteFileAtomicConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	const writers = 8
+	var wg sync.WaitGroup
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Long enough that interleaved writes would be noticeable
+			data := strings.Repeat(fmt.Sprintf("writer %d\n", i), 1000)
+			if err := writeFileAtomic(path, []byte(data), 0644); err != nil {
+				t.Errorf("writer %d: %v", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 1000 {
+		t.Fatalf("config has %d lines, want one writer's 1000", len(lines))
+	}
+	for _, line := range lines {
+		if line != lines[0] {
+			t.Fatalf("config mixes %q and %q, want one writer's contents", lines[0], line)
+		}
+	}
+	if leftover := tempFiles(t, filepath.Dir(path)); len(leftover) != 0 {
+		t.Errorf("temp files left behind: %v", leftover)
+	}
+}
+
+// fileMode returns the permission bits of path
+func fileMode(t *testing.T, path string) os.FileMode {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Mode().Perm()
+}
+
+func TestSaveKeepsFileMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing os.FileMode // 0 for no existing file
+		want     os.FileMode
+	}{
+		{"new file is private", 0, 0600},
+		{"restricted file stays restricted", 0600, 0600},
+		{"group-readable file stays group-readable", 0640, 0640},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := useHome(t)
+			if tt.existing != 0 {
+				writeConfig(t, path, "[cli]\nbase_url = \"http://old\"\n")
+				if err := os.Chmod(path, tt.existing); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg := DefaultConfig()
+			cfg.CLI.APIKey = "secret"
+			if err := Save(cfg); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			if got := fileMode(t, path); got != tt.want {
+				t.Errorf("mode after Save = %o, want %o", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveKeepsSymlink(t *testing.T) {
+	path := useHome(t)
+	target := filepath.Join(t.TempDir(), "dotfiles", "config.toml")
+	writeConfig(t, target, "[cli]\nbase_url = \"http://old\"\n")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, path); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.CLI.BaseURL = "http://new"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if info, err := os.Lstat(path); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("config path is no longer a symlink (err %v)", err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "http://new") {
+		t.Errorf("symlink target = %q, want the saved config", data)
+	}
+	if leftover := tempFiles(t, filepath.Dir(path)); len(leftover) != 0 {
+		t.Errorf("temp files left next to the link: %v", leftover)
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import "testing"
+
+func TestScraperBaseURL(t *testing.T) {
+	tests := []struct {
+		name              string
+		cliURL, scrapeURL string
+		want              string
+	}{
+		{"scraper URL set", "http://api", "http://scraper", "http://scraper"},
+		{"falls back to the CLI URL", "http://api", "", "http://api"},
+		{"neither set", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.CLI.BaseURL = tt.cliURL
+			cfg.Scraper.BaseURL = tt.scrapeURL
+			if got := cfg.ScraperBaseURL(); got != tt.want {
+				t.Errorf("ScraperBaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMaxConcurrentScrapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     int
+	}{
+		{"unset uses the default", "", DefaultConfig().Scraper.MaxConcurrent},
+		{"explicit limit", "[scraper]\nmax_concurrent = 3\n", 3},
+		{"negative disables the limit", "[scraper]\nmax_concurrent = -1\n", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := useHome(t)
+			t.Setenv("DATABASE_URL", "")
+			t.Setenv("SCRAPER_BASE_URL", "")
+			writeConfig(t, path, tt.contents)
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.Scraper.MaxConcurrent != tt.want {
+				t.Errorf("max_concurrent = %d, want %d", cfg.Scraper.MaxConcurrent, tt.want)
+			}
+		})
+	}
+}
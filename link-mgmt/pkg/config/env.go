@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// expandEnv replaces ${VAR} and $VAR with the value of the environment
+// variable (empty if unset), following os.ExpandEnv. $$ is a literal $.
+func expandEnv(s string) string {
+	return os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		return os.Getenv(name)
+	})
+}
+
+// expandEnvFields expands environment variables in every string field of cfg.
+// The original text of changed values is kept so Save can write the ${VAR}
+// references back instead of the resolved values (which may be secrets).
+func (cfg *Config) expandEnvFields() {
+	cfg.raw = make(map[string]string)
+	walkStrings(reflect.ValueOf(cfg).Elem(), "", func(key string, v reflect.Value) {
+		orig := v.String()
+		if expanded := expandEnv(orig); expanded != orig {
+			cfg.raw[key] = orig
+			v.SetString(expanded)
+		}
+	})
+}
+
+// withEnvRefs returns a copy of cfg with expanded values restored to their
+// original ${VAR} form, unless they were changed after loading
+func (cfg *Config) withEnvRefs() *Config {
+	out := *cfg
+	walkStrings(reflect.ValueOf(&out).Elem(), "", func(key string, v reflect.Value) {
+		if orig, ok := cfg.raw[key]; ok && v.String() == expandEnv(orig) {
+			v.SetString(orig)
+		}
+	})
+	return &out
+}
+
+// walkStrings calls fn for each string (or []string element) in the struct v,
+// keyed by its dotted TOML path. String slices are copied before visiting so
+// modifying a shallow copy of a Config never touches the original.
+func walkStrings(v reflect.Value, path string, fn func(key string, v reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := strings.Split(field.Tag.Get("toml"), ",")[0]
+		if path != "" {
+			key = path + "." + key
+		}
+
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.Struct:
+			walkStrings(fv, key, fn)
+		case reflect.String:
+			fn(key, fv)
+		case reflect.Slice:
+			if fv.Type().Elem().Kind() != reflect.String {
+				continue
+			}
+			cp := reflect.MakeSlice(fv.Type(), fv.Len(), fv.Len())
+			reflect.Copy(cp, fv)
+			fv.Set(cp)
+			for j := 0; j < fv.Len(); j++ {
+				fn(fmt.Sprintf("%s[%d]", key, j), fv.Index(j))
+			}
+		}
+	}
+}
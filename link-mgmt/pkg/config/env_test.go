@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useHome points the config path at a fresh temporary home directory and
+// returns the config file path there
+func useHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path, err := ConfigPath()
+	if err != nil {
+		t.Fatalf("ConfigPath: %v", err)
+	}
+	return path
+}
+
+// writeConfig writes a config file with the given contents to path
+func writeConfig(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("LINK_MGMT_TEST_KEY", "secret")
+	t.Setenv("LINK_MGMT_TEST_HOST", "example.com")
+	os.Unsetenv("LINK_MGMT_TEST_UNSET")
+
+	tests := []struct {
+		in, want string
+	}{
+		{"${LINK_MGMT_TEST_KEY}", "secret"},
+		{"$LINK_MGMT_TEST_KEY", "secret"},
+		{"https://${LINK_MGMT_TEST_HOST}/api", "https://example.com/api"},
+		{"${LINK_MGMT_TEST_UNSET}", ""},
+		{"key-$LINK_MGMT_TEST_UNSET-end", "key--end"},
+		{"pa$$word", "pa$word"},
+		{"$${LINK_MGMT_TEST_KEY}", "${LINK_MGMT_TEST_KEY}"},
+		{"no references", "no references"},
+	}
+	for _, tt := range tests {
+		if got := expandEnv(tt.in); got != tt.want {
+			t.Errorf("expandEnv(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadExpandsEnv(t *testing.T) {
+	path := useHome(t)
+	t.Setenv("LINK_MGMT_TEST_KEY", "secret")
+	t.Setenv("DATABASE_URL", "")
+	t.Setenv("SCRAPER_BASE_URL", "")
+	writeConfig(t, path, `
+[cli]
+api_key = "${LINK_MGMT_TEST_KEY}"
+base_url = "http://$LINK_MGMT_TEST_UNSET/links"
+log_sinks = ["file", "$LINK_MGMT_TEST_KEY"]
+
+[scraper]
+user_agent = "agent $$1"
+`)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.CLI.APIKey != "secret" {
+		t.Errorf("api_key = %q, want %q", cfg.CLI.APIKey, "secret")
+	}
+	if cfg.CLI.BaseURL != "http:///links" {
+		t.Errorf("base_url = %q, want the unset variable expanded to empty", cfg.CLI.BaseURL)
+	}
+	if got := strings.Join(cfg.CLI.LogSinks, ","); got != "file,secret" {
+		t.Errorf("log_sinks = %q, want %q", got, "file,secret")
+	}
+	if cfg.Scraper.UserAgent != "agent $1" {
+		t.Errorf("user_agent = %q, want %q", cfg.Scraper.UserAgent, "agent $1")
+	}
+
+	// Saving writes the references back, not the secret
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("saved config contains the expanded secret:\n%s", data)
+	}
+	if !strings.Contains(string(data), "${LINK_MGMT_TEST_KEY}") {
+		t.Errorf("saved config lost the ${LINK_MGMT_TEST_KEY} reference:\n%s", data)
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ErrConfigExists is returned by Init when the config file already exists
+var ErrConfigExists = errors.New("config file already exists")
+
+// commentedTemplate renders a default config with an explanation of every key.
+// Keep in sync with the Config struct.
+var commentedTemplate = template.Must(template.New("config").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+	"list": func(items []string) string {
+		quoted := make([]string, len(items))
+		for i, item := range items {
+			quoted[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	},
+}).Parse(`# link-mgmt configuration
+#
+# String values may reference environment variables as ${VAR} or $VAR
+# (unset variables expand to ""); write $$ for a literal $.
+
+[database]
+# PostgreSQL connection string used by the API server
+url = {{quote .Database.URL}}
+# Per-query timeout in seconds
+query_timeout = {{.Database.QueryTimeout}}
+# Most connections the API server keeps open to the database
+max_conns = {{.Database.MaxConns}}
+# Connections kept open even when idle
+min_conns = {{.Database.MinConns}}
+# Seconds an unused connection stays open before it is closed
+max_conn_idle_time = {{.Database.MaxConnIdleTime}}
+
+[api]
+# Address and port the API server listens on
+host = {{quote .API.Host}}
+port = {{.API.Port}}
+# Requests allowed per minute for each API key; negative disables rate limiting
+rate_limit_per_minute = {{.API.RateLimitPerMinute}}
+# Request log format: text or json
+log_format = {{quote .API.LogFormat}}
+# Lifetime of new and rotated API keys in days; 0 = keys never expire
+key_expiry_days = {{.API.KeyExpiryDays}}
+# Longest link text accepted on create and update, in characters; longer
+# scraped text is cut to fit
+max_text_length = {{.API.MaxTextLength}}
+
+[cli]
+# Base URL for all services (nginx reverse proxy)
+base_url = {{quote .CLI.BaseURL}}
+# Path the API is mounted under when a reverse proxy serves it from a subpath,
+# e.g. "/link-mgmt" for /link-mgmt/api/v1/...; leave empty for the root
+api_prefix = {{quote .CLI.APIPrefix}}
+# API key issued by --register (or reference one, e.g. "${LINK_MGMT_API_KEY}")
+api_key = {{quote .CLI.APIKey}}
+# Timeout for scraping operations in seconds
+scrape_timeout = {{.CLI.ScrapeTimeout}}
+# Characters of scraped text shown by --scrape and the link detail view
+preview_length = {{.CLI.PreviewLength}}
+# Width URLs are truncated to in the --list table
+url_display_width = {{.CLI.URLDisplayWidth}}
+# Log level: debug, info, or error
+log_level = {{quote .CLI.LogLevel}}
+# Log outputs, any of: file, stderr, syslog
+log_sinks = {{list .CLI.LogSinks}}
+# Deleting more links than this at once (TUI bulk delete, --dedupe --prune)
+# asks you to type the number of links instead of y/N. Negative always asks y/N
+bulk_confirm_threshold = {{.CLI.BulkConfirmThreshold}}
+
+[scraper]
+# Base URL for the scraper service; leave empty to use cli.base_url
+base_url = {{quote .Scraper.BaseURL}}
+# Seconds to cache successful scrape results per URL; 0 disables caching
+cache_ttl = {{.Scraper.CacheTTL}}
+# Response format of the scraper service: "default" for the bundled scraper, or
+# "readability" for services returning Mozilla Readability articles
+adapter = {{quote .Scraper.Adapter}}
+# Most scrapes the API server runs at once; enrich requests beyond this get
+# 429 Too Many Requests. Negative removes the limit
+max_concurrent = {{.Scraper.MaxConcurrent}}
+# User-Agent the scraper fetches pages with, for sites that block the
+# default one; leave empty for a built-in browser-compatible agent
+user_agent = {{quote .Scraper.UserAgent}}
+`))
+
+// Init writes a fully commented default config file and returns its path.
+// It returns ErrConfigExists if the file exists, unless overwrite is true.
+func Init(overwrite bool) (string, error) {
+	configPath, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(configPath); err == nil && !overwrite {
+		return configPath, fmt.Errorf("%w: %s", ErrConfigExists, configPath)
+	}
+
+	if err := writeDefault(configPath); err != nil {
+		return configPath, err
+	}
+	return configPath, nil
+}
+
+// EnsureExists creates the config directory and a commented default config
+// file if they are missing. It is safe to call repeatedly.
+func EnsureExists() error {
+	configPath, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(configPath); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to check config file: %w", err)
+	}
+
+	return writeDefault(configPath)
+}
+
+// writeDefault renders the commented default config to configPath
+func writeDefault(configPath string) error {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := commentedTemplate.Execute(&buf, DefaultConfig()); err != nil {
+		return fmt.Errorf("failed to render default config: %w", err)
+	}
+
+	if err := writeFileAtomic(configPath, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
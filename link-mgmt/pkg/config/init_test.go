@@ -0,0 +1,84 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+func TestInitWritesEverySection(t *testing.T) {
+	path := useHome(t)
+
+	got, err := Init(false)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if got != path {
+		t.Errorf("Init path = %q, want %q", got, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+
+	var file map[string]map[string]interface{}
+	if err := toml.Unmarshal(data, &file); err != nil {
+		t.Fatalf("written config doesn't parse: %v\n%s", err, data)
+	}
+
+	// Every field of Config appears under its section
+	cfgType := reflect.TypeOf(Config{})
+	for i := 0; i < cfgType.NumField(); i++ {
+		section := cfgType.Field(i)
+		if !section.IsExported() {
+			continue
+		}
+		name := strings.Split(section.Tag.Get("toml"), ",")[0]
+		keys, ok := file[name]
+		if !ok {
+			t.Errorf("section [%s] missing", name)
+			continue
+		}
+		for j := 0; j < section.Type.NumField(); j++ {
+			key := strings.Split(section.Type.Field(j).Tag.Get("toml"), ",")[0]
+			if _, ok := keys[key]; !ok {
+				t.Errorf("key %s.%s missing", name, key)
+			}
+		}
+	}
+
+	// and holds the defaults
+	var cfg Config
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&cfg, DefaultConfig()) {
+		t.Errorf("written config = %+v, want the defaults %+v", cfg, *DefaultConfig())
+	}
+}
+
+func TestInitExisting(t *testing.T) {
+	path := useHome(t)
+	writeConfig(t, path, "[cli]\napi_key = \"keep-me\"\n")
+
+	if _, err := Init(false); !errors.Is(err, ErrConfigExists) {
+		t.Fatalf("Init over an existing file: err = %v, want ErrConfigExists", err)
+	}
+	if err := EnsureExists(); err != nil {
+		t.Fatalf("EnsureExists: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "keep-me") {
+		t.Fatalf("existing config was replaced:\n%s", data)
+	}
+
+	if _, err := Init(true); err != nil {
+		t.Fatalf("Init with overwrite: %v", err)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "keep-me") || !strings.Contains(string(data), "[scraper]") {
+		t.Errorf("Init with overwrite didn't write the defaults:\n%s", data)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !(linux || darwin || freebsd || openbsd || netbsd || dragonfly)
+
+package config
+
+// lockFile is a no-op on this platform; writes are still atomic, but
+// concurrent writers are not serialized
+func lockFile(path string) (func(), error) {
+	return func() {}, nil
+}
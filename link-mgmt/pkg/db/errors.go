@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrLinkNotFound is returned when a link doesn't exist or belongs to another user
+	ErrLinkNotFound = errors.New("link not found")
+
+	// ErrDuplicateLink is returned when the user already has a link with the same URL
+	ErrDuplicateLink = errors.New("link with this URL already exists")
+
+	// ErrDuplicateEmail is returned when a user with the same email already exists
+	ErrDuplicateEmail = errors.New("email already registered")
+
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent again
+	// with a different request
+	ErrIdempotencyKeyReused = errors.New("Idempotency-Key was already used with a different request")
+
+	// ErrUserNotFound is returned when no user matches the lookup
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrAPIKeyExpired is returned when an API key exists but has expired
+	ErrAPIKeyExpired = errors.New("API key expired")
+
+	// ErrQueryTimeout is returned when a query exceeds the configured query
+	// timeout, or its context is cancelled before it completes
+	ErrQueryTimeout = errors.New("database query timed out")
+)
+
+// withTimeout bounds ctx by the configured per-query timeout (if any)
+func (db *DB) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, db.QueryTimeout)
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// queryError wraps a query error with msg, converting an expired or cancelled
+// context into ErrQueryTimeout so callers can tell a slow database (or an
+// abandoned request) apart from a real failure
+func queryError(ctx context.Context, err error, msg string) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) || ctx.Err() != nil {
+		return fmt.Errorf("%s: %w", msg, ErrQueryTimeout)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
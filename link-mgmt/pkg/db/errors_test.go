@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// unreachableDB returns a DB whose pool points at a closed port. pgxpool
+// connects lazily, so queries fail only once they try to connect.
+func unreachableDB(t *testing.T, queryTimeout time.Duration) *DB {
+	t.Helper()
+
+	pool, err := pgxpool.New(context.Background(), "postgres://test@127.0.0.1:1/test?connect_timeout=1")
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return &DB{Pool: pool, QueryTimeout: queryTimeout}
+}
+
+func TestQueryErrorMapsContextErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	other := errors.New("connection reset")
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		err         error
+		wantTimeout bool
+	}{
+		{"deadline error", context.Background(), context.DeadlineExceeded, true},
+		{"cancelled error", context.Background(), context.Canceled, true},
+		{"wrapped deadline error", context.Background(), errors.Join(other, context.DeadlineExceeded), true},
+		{"expired context", expired, other, true},
+		{"cancelled context", cancelled, other, true},
+		{"other error", context.Background(), other, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := queryError(tt.ctx, tt.err, "failed to query")
+			if got := errors.Is(err, ErrQueryTimeout); got != tt.wantTimeout {
+				t.Errorf("errors.Is(%v, ErrQueryTimeout) = %v, want %v", err, got, tt.wantTimeout)
+			}
+			if errors.Is(err, ErrLinkNotFound) {
+				t.Errorf("%v reads as not found", err)
+			}
+		})
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	db := &DB{QueryTimeout: time.Minute}
+	ctx, cancel := db.withTimeout(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("deadline = %v, %v; want one within a minute", deadline, ok)
+	}
+
+	db.QueryTimeout = 0
+	ctx, cancel = db.withTimeout(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("zero QueryTimeout set a deadline")
+	}
+}
+
+func TestQueriesWithCancelledContextTimeOut(t *testing.T) {
+	db := unreachableDB(t, time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	userID := uuid.New()
+	checks := map[string]func() error{
+		"GetLinksByUserID": func() error {
+			_, err := db.GetLinksByUserID(ctx, userID, models.LinkFilter{}, models.ListOptions{})
+			return err
+		},
+		"GetLinkByID": func() error {
+			_, err := db.GetLinkByID(ctx, uuid.New(), userID)
+			return err
+		},
+		"CreateLink": func() error {
+			_, err := db.CreateLink(ctx, userID, models.LinkCreate{URL: "https://example.com"})
+			return err
+		},
+		"GetUserByAPIKey": func() error {
+			_, err := db.GetUserByAPIKey(ctx, "key")
+			return err
+		},
+		"DeleteLink": func() error {
+			return db.DeleteLink(ctx, uuid.New(), userID)
+		},
+	}
+	for name, check := range checks {
+		err := check()
+		if !errors.Is(err, ErrQueryTimeout) {
+			t.Errorf("%s: err = %v, want ErrQueryTimeout", name, err)
+		}
+	}
+}
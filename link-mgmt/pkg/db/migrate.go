@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// migrationLockID is the advisory lock key held while applying a migration,
+// so two runners don't apply the same one at once
+const migrationLockID = 7_386_104_221
+
+// MigrationStatus describes one migration file and whether it has been applied
+type MigrationStatus struct {
+	Version   string     // File name without the .sql extension, e.g. "001_create_users"
+	AppliedAt *time.Time // nil while pending
+}
+
+// migrationVersions lists the .sql files in fsys in the order they apply
+func migrationVersions(fsys fs.FS) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		versions = append(versions, strings.TrimSuffix(entry.Name(), ".sql"))
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+// ensureMigrationsTable creates the table recording applied migrations
+func (db *DB) ensureMigrationsTable(ctx context.Context) error {
+	_, err := db.Pool.Exec(ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+		     version VARCHAR(255) PRIMARY KEY,
+		     applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		 )`,
+	)
+	if err != nil {
+		return queryError(ctx, err, "failed to create schema_migrations table")
+	}
+	return nil
+}
+
+// MigrationStatus reports every migration in fsys with when it was applied.
+// Migrations are not bounded by the per-query timeout.
+func (db *DB) MigrationStatus(ctx context.Context, fsys fs.FS) ([]MigrationStatus, error) {
+	versions, err := migrationVersions(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.ensureMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Pool.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to list applied migrations")
+	}
+	defer rows.Close()
+
+	applied := make(map[string]time.Time)
+	for rows.Next() {
+		var version string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, queryError(ctx, err, "failed to scan applied migration")
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, err, "failed to list applied migrations")
+	}
+
+	statuses := make([]MigrationStatus, len(versions))
+	for i, version := range versions {
+		statuses[i].Version = version
+		if appliedAt, ok := applied[version]; ok {
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// Migrate applies the migrations in fsys that haven't been applied yet, in
+// filename order, each in its own transaction, and returns the versions it
+// applied. It stops at the first migration that fails.
+func (db *DB) Migrate(ctx context.Context, fsys fs.FS) ([]string, error) {
+	versions, err := migrationVersions(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.ensureMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var applied []string
+	for _, version := range versions {
+		sql, err := fs.ReadFile(fsys, version+".sql")
+		if err != nil {
+			return applied, fmt.Errorf("failed to read migration %s: %w", version, err)
+		}
+		ran, err := db.applyMigration(ctx, version, string(sql))
+		if err != nil {
+			return applied, err
+		}
+		if ran {
+			applied = append(applied, version)
+		}
+	}
+	return applied, nil
+}
+
+// applyMigration runs one migration and records it, unless it was already
+// applied. Reports whether it ran.
+func (db *DB) applyMigration(ctx context.Context, version, sql string) (bool, error) {
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return false, queryError(ctx, err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	// Checked under the lock so a concurrent runner can't apply it twice
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockID); err != nil {
+		return false, queryError(ctx, err, "failed to lock migrations")
+	}
+	var done bool
+	if err := tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`,
+		version,
+	).Scan(&done); err != nil {
+		return false, queryError(ctx, err, "failed to check migration")
+	}
+	if done {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return false, queryError(ctx, err, fmt.Sprintf("migration %s failed", version))
+	}
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO schema_migrations (version) VALUES ($1)`,
+		version,
+	); err != nil {
+		return false, queryError(ctx, err, "failed to record migration")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, queryError(ctx, err, fmt.Sprintf("failed to commit migration %s", version))
+	}
+	return true, nil
+}
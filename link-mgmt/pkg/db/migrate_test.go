@@ -0,0 +1,121 @@
+package db_test
+
+import (
+	"context"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"link-mgmt/migrations"
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/db/dbtest"
+)
+
+// migrationFS builds a migrations directory from version → SQL
+func migrationFS(files map[string]string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for version, sql := range files {
+		fsys[version+".sql"] = &fstest.MapFile{Data: []byte(sql)}
+	}
+	return fsys
+}
+
+// pendingVersions returns the versions MigrationStatus reports, split by
+// whether they are applied
+func pendingVersions(t *testing.T, database *db.DB, fsys fs.FS) (applied, pending []string) {
+	t.Helper()
+
+	statuses, err := database.MigrationStatus(context.Background(), fsys)
+	if err != nil {
+		t.Fatalf("MigrationStatus: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Version)
+		} else {
+			applied = append(applied, status.Version)
+		}
+	}
+	return applied, pending
+}
+
+func TestMigrate(t *testing.T) {
+	database := dbtest.NewEmpty(t)
+	ctx := context.Background()
+
+	fsys := migrationFS(map[string]string{
+		"002_seed_notes":   `INSERT INTO notes (body) VALUES ('hello')`,
+		"001_create_notes": `CREATE TABLE notes (body TEXT NOT NULL)`,
+	})
+	fsys["README.md"] = &fstest.MapFile{Data: []byte("not a migration")}
+
+	// Migrations are listed in filename order; other files are ignored
+	applied, pending := pendingVersions(t, database, fsys)
+	if len(applied) != 0 || !slices.Equal(pending, []string{"001_create_notes", "002_seed_notes"}) {
+		t.Fatalf("before migrating: applied %v, pending %v; want both pending in order", applied, pending)
+	}
+
+	ran, err := database.Migrate(ctx, fsys)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if !slices.Equal(ran, []string{"001_create_notes", "002_seed_notes"}) {
+		t.Errorf("Migrate applied %v, want both in order", ran)
+	}
+
+	// Running again applies nothing
+	ran, err = database.Migrate(ctx, fsys)
+	if err != nil || len(ran) != 0 {
+		t.Errorf("second Migrate = %v, %v; want nothing applied", ran, err)
+	}
+	var notes int
+	if err := database.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM notes`).Scan(&notes); err != nil || notes != 1 {
+		t.Errorf("notes = %d, %v; want the seed row once", notes, err)
+	}
+
+	// A failing migration is rolled back and stops the run
+	fsys = migrationFS(map[string]string{
+		"001_create_notes":  `CREATE TABLE notes (body TEXT NOT NULL)`,
+		"002_seed_notes":    `INSERT INTO notes (body) VALUES ('hello')`,
+		"003_broken":        `INSERT INTO notes (body) VALUES ('partial'); SELECT * FROM missing_table`,
+		"004_after_failure": `INSERT INTO notes (body) VALUES ('after')`,
+	})
+	ran, err = database.Migrate(ctx, fsys)
+	if err == nil || !strings.Contains(err.Error(), "migration 003_broken failed") {
+		t.Errorf("Migrate with a broken migration: err = %v, want it named", err)
+	}
+	if len(ran) != 0 {
+		t.Errorf("Migrate with a broken migration applied %v, want nothing", ran)
+	}
+	if err := database.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM notes`).Scan(&notes); err != nil || notes != 1 {
+		t.Errorf("notes = %d, %v; want the failed migration's insert rolled back", notes, err)
+	}
+	_, pending = pendingVersions(t, database, fsys)
+	if !slices.Equal(pending, []string{"003_broken", "004_after_failure"}) {
+		t.Errorf("pending %v, want the broken migration and the one after it", pending)
+	}
+}
+
+func TestMigrateBundled(t *testing.T) {
+	database := dbtest.NewEmpty(t)
+	ctx := context.Background()
+
+	ran, err := database.Migrate(ctx, migrations.FS)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	_, pending := pendingVersions(t, database, migrations.FS)
+	if len(ran) == 0 || len(pending) != 0 {
+		t.Fatalf("applied %v with %v still pending; want every bundled migration applied", ran, pending)
+	}
+
+	// The schema works: a user can be created and given a link
+	user := dbtest.CreateUser(t, database)
+	dbtest.CreateLink(t, database, user.ID, "https://example.com/migrated", nil)
+
+	if ran, err := database.Migrate(ctx, migrations.FS); err != nil || len(ran) != 0 {
+		t.Errorf("second Migrate = %v, %v; want nothing applied", ran, err)
+	}
+}
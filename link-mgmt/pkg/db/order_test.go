@@ -0,0 +1,40 @@
+package db
+
+import (
+	"testing"
+
+	"link-mgmt/pkg/models"
+)
+
+func TestOrderByClause(t *testing.T) {
+	tests := []struct {
+		opts models.ListOptions
+		want string
+	}{
+		{models.ListOptions{}, " ORDER BY created_at DESC, id DESC"},
+		{models.ListOptions{SortBy: "title", Order: "asc"}, " ORDER BY title ASC, id ASC"},
+		{models.ListOptions{SortBy: "url", Order: "DESC"}, " ORDER BY url DESC, id DESC"},
+		{models.ListOptions{SortBy: "updated_at", Order: "asc"}, " ORDER BY updated_at ASC, id ASC"},
+		{models.ListOptions{SortBy: "visit_count"}, " ORDER BY visit_count DESC, id DESC"},
+		{models.ListOptions{SortBy: "position"}, " ORDER BY position ASC NULLS LAST, created_at DESC, id DESC"},
+		{models.ListOptions{SortBy: "position", Order: "desc"}, " ORDER BY position DESC NULLS LAST, created_at DESC, id DESC"},
+	}
+	for _, tt := range tests {
+		got, err := orderByClause(tt.opts)
+		if err != nil {
+			t.Errorf("orderByClause(%+v): %v", tt.opts, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("orderByClause(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestOrderByClauseRejectsUnlistedColumns(t *testing.T) {
+	for _, sortBy := range []string{"email", "api_key", "title; DROP TABLE links", "1", "title DESC, (SELECT 1)"} {
+		if clause, err := orderByClause(models.ListOptions{SortBy: sortBy}); err == nil {
+			t.Errorf("orderByClause(SortBy: %q) = %q, want an error", sortBy, clause)
+		}
+	}
+}
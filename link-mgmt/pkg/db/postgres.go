@@ -3,16 +3,57 @@ package db
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type DB struct {
 	Pool *pgxpool.Pool
+
+	// QueryTimeout bounds every query; zero means no per-query deadline
+	QueryTimeout time.Duration
+
+	// unaccent is set when the unaccent extension is installed, so search
+	// ignores accents; checked once at connect time
+	unaccent bool
+}
+
+// PoolOptions tunes the connection pool; zero values keep pgx's defaults
+type PoolOptions struct {
+	MaxConns        int32
+	MinConns        int32
+	MaxConnIdleTime time.Duration
 }
 
-func New(ctx context.Context, connString string) (*DB, error) {
-	pool, err := pgxpool.New(ctx, connString)
+// poolConfig parses connString and applies the non-zero pool options
+func poolConfig(connString string, opts PoolOptions) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+	if opts.MaxConns > 0 {
+		poolCfg.MaxConns = opts.MaxConns
+	}
+	if opts.MinConns > 0 {
+		poolCfg.MinConns = opts.MinConns
+	}
+	if opts.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = opts.MaxConnIdleTime
+	}
+	if poolCfg.MinConns > poolCfg.MaxConns {
+		return nil, fmt.Errorf("min_conns (%d) must not exceed max_conns (%d)", poolCfg.MinConns, poolCfg.MaxConns)
+	}
+	return poolCfg, nil
+}
+
+func New(ctx context.Context, connString string, queryTimeout time.Duration, opts PoolOptions) (*DB, error) {
+	poolCfg, err := poolConfig(connString, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -22,7 +63,26 @@ func New(ctx context.Context, connString string) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{Pool: pool}, nil
+	db := &DB{Pool: pool, QueryTimeout: queryTimeout}
+	if err := pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'unaccent')`,
+	).Scan(&db.unaccent); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to check database extensions: %w", err)
+	}
+
+	return db, nil
+}
+
+// Ping checks that the database is reachable, bounded by the query timeout
+func (db *DB) Ping(ctx context.Context) error {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	if err := db.Pool.Ping(ctx); err != nil {
+		return queryError(ctx, err, "failed to ping database")
+	}
+	return nil
 }
 
 func (db *DB) Close() {
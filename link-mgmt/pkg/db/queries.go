@@ -2,7 +2,13 @@ package db
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"link-mgmt/pkg/models"
 
@@ -10,147 +16,623 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
-// GetUserByAPIKey retrieves a user by their API key
+// lastUsedInterval is how old a key's last_used_at must be before an
+// authentication records it again, so a busy key isn't written on every request
+const lastUsedInterval = time.Minute
+
+// GetUserByAPIKey retrieves a user by their primary or an additional API key
+// and records the key as used, at most once per lastUsedInterval. For an
+// additional key, the returned user carries that key and its expiry, and
+// IsReadOnly is set for read-only keys.
+// Returns ErrAPIKeyExpired if the key exists but has passed its expiry.
 func (db *DB) GetUserByAPIKey(ctx context.Context, apiKey string) (*models.User, error) {
-	var user models.User
-	err := db.Pool.QueryRow(ctx,
-		`SELECT id, email, api_key, created_at, updated_at
-		 FROM users WHERE api_key = $1`,
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	user, primary, err := db.findUserByAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE api_keys SET last_used_at = NOW()
+		 WHERE api_key = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - make_interval(secs => $2))
+		 RETURNING last_used_at`
+	if primary {
+		query = `UPDATE users SET last_used_at = NOW()
+		 WHERE api_key = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - make_interval(secs => $2))
+		 RETURNING last_used_at`
+	}
+	var lastUsed time.Time
+	err = db.Pool.QueryRow(ctx, query, apiKey, lastUsedInterval.Seconds()).Scan(&lastUsed)
+	switch {
+	case err == nil:
+		user.LastUsedAt = &lastUsed
+	case errors.Is(err, pgx.ErrNoRows):
+		// Used within lastUsedInterval; nothing to record
+	default:
+		return nil, queryError(ctx, err, "failed to record API key use")
+	}
+	return user, nil
+}
+
+// LookupUserByAPIKey retrieves the user owning a primary or additional API
+// key, like GetUserByAPIKey, but without recording the key as used: holding a
+// key someone presents is not the same as it being used to authenticate.
+// Returns ErrAPIKeyExpired if the key has passed its expiry and
+// ErrUserNotFound if no user has it.
+func (db *DB) LookupUserByAPIKey(ctx context.Context, apiKey string) (*models.User, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	user, _, err := db.findUserByAPIKey(ctx, apiKey)
+	return user, err
+}
+
+// findUserByAPIKey retrieves the user owning a primary or additional API key
+// without recording its use. primary reports whether apiKey is the user's
+// primary key. Returns ErrAPIKeyExpired if the key has passed its expiry and
+// ErrUserNotFound if no user has it.
+func (db *DB) findUserByAPIKey(ctx context.Context, apiKey string) (user *models.User, primary bool, err error) {
+	var found models.User
+	var expired bool
+	err = db.Pool.QueryRow(ctx,
+		`SELECT id, email, api_key, expires_at, last_used_at, created_at, updated_at,
+		        FALSE, TRUE, expires_at IS NOT NULL AND expires_at <= NOW()
+		 FROM users WHERE api_key = $1
+		 UNION ALL
+		 SELECT u.id, u.email, k.api_key, k.expires_at, k.last_used_at, u.created_at, u.updated_at,
+		        k.is_readonly, FALSE, k.expires_at IS NOT NULL AND k.expires_at <= NOW()
+		 FROM api_keys k JOIN users u ON u.id = k.user_id WHERE k.api_key = $1
+		 LIMIT 1`,
 		apiKey,
 	).Scan(
-		&user.ID,
-		&user.Email,
-		&user.APIKey,
-		&user.CreatedAt,
-		&user.UpdatedAt,
+		&found.ID,
+		&found.Email,
+		&found.APIKey,
+		&found.ExpiresAt,
+		&found.LastUsedAt,
+		&found.CreatedAt,
+		&found.UpdatedAt,
+		&found.IsReadOnly,
+		&primary,
+		&expired,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, false, queryError(ctx, err, "failed to get user")
+	}
+	if expired {
+		return nil, false, ErrAPIKeyExpired
+	}
+	return &found, primary, nil
+}
+
+// GetUserByEmail retrieves a user by email, matched exactly.
+// Returns ErrUserNotFound if no user has that email.
+func (db *DB) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	var user models.User
+	err := scanUser(db.Pool.QueryRow(ctx,
+		`SELECT `+userColumns+` FROM users WHERE email = $1`,
+		email,
+	), &user)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to get user")
+	}
+
+	return &user, nil
+}
+
+// CreateUser creates a new user. expiryDays > 0 makes the API key expire
+// that many days from now; otherwise the key never expires.
+// Returns ErrDuplicateEmail if the email is already registered.
+func (db *DB) CreateUser(ctx context.Context, email, apiKey string, expiryDays int) (*models.User, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	var user models.User
+	err := scanUser(db.Pool.QueryRow(ctx,
+		`INSERT INTO users (email, api_key, expires_at)
+		 VALUES ($1, $2, `+expiresAtExpr(3)+`)
+		 RETURNING `+userColumns,
+		email, apiKey, expiryDays,
+	), &user)
 
-	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("user not found")
+	if isUniqueViolation(err) {
+		// The email is the only unique column a caller can collide on
+		return nil, ErrDuplicateEmail
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, queryError(ctx, err, "failed to create user")
 	}
 
 	return &user, nil
 }
 
-// CreateUser creates a new user
-func (db *DB) CreateUser(ctx context.Context, email, apiKey string) (*models.User, error) {
+// RotateAPIKey replaces a user's API key and resets its expiry (see CreateUser)
+func (db *DB) RotateAPIKey(ctx context.Context, userID uuid.UUID, apiKey string, expiryDays int) (*models.User, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
 	var user models.User
+	err := scanUser(db.Pool.QueryRow(ctx,
+		`UPDATE users
+		 SET api_key = $2, expires_at = `+expiresAtExpr(3)+`, last_used_at = NULL, updated_at = NOW()
+		 WHERE id = $1
+		 RETURNING `+userColumns,
+		userID, apiKey, expiryDays,
+	), &user)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to rotate API key")
+	}
+
+	return &user, nil
+}
+
+// CreateReadOnlyAPIKey adds a read-only API key for a user alongside the
+// primary key. Expiry works as for CreateUser.
+func (db *DB) CreateReadOnlyAPIKey(ctx context.Context, userID uuid.UUID, apiKey string, expiryDays int) (*models.APIKey, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	var key models.APIKey
 	err := db.Pool.QueryRow(ctx,
-		`INSERT INTO users (email, api_key)
-		 VALUES ($1, $2)
-		 RETURNING id, email, api_key, created_at, updated_at`,
-		email, apiKey,
+		`INSERT INTO api_keys (user_id, api_key, is_readonly, expires_at)
+		 VALUES ($1, $2, TRUE, `+expiresAtExpr(3)+`)
+		 RETURNING id, user_id, api_key, is_readonly, expires_at, created_at`,
+		userID, apiKey, expiryDays,
 	).Scan(
+		&key.ID,
+		&key.UserID,
+		&key.APIKey,
+		&key.IsReadOnly,
+		&key.ExpiresAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to create API key")
+	}
+
+	return &key, nil
+}
+
+// DeleteUser deletes a user. Their links and API keys go with them through
+// ON DELETE CASCADE, so the user's keys stop authenticating immediately.
+func (db *DB) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	result, err := db.Pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return queryError(ctx, err, "failed to delete user")
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// expiresAtExpr computes a key expiry from a day count parameter in SQL, so
+// expiry comparisons all use the database clock
+func expiresAtExpr(param int) string {
+	return fmt.Sprintf(`CASE WHEN $%[1]d::int > 0 THEN NOW() + make_interval(days => $%[1]d::int) END`, param)
+}
+
+// userColumns is the column list selected/returned for every user query.
+// Keep in sync with scanUser.
+const userColumns = `id, email, api_key, expires_at, last_used_at, created_at, updated_at`
+
+// scanUser scans a row selected with userColumns into user
+func scanUser(row rowScanner, user *models.User) error {
+	return row.Scan(
 		&user.ID,
 		&user.Email,
 		&user.APIKey,
+		&user.ExpiresAt,
+		&user.LastUsedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
+// linkColumns is the column list selected/returned for every link query.
+// Keep in sync with scanLink.
+const linkColumns = `id, user_id, url, title, description, text, notes, is_favorite, is_read, favicon, site_name, image_url, last_scraped_at, content_hash, position, visit_count, created_at, updated_at`
 
-	return &user, nil
+// rowScanner is satisfied by both pgx.Row and pgx.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
-// GetLinksByUserID retrieves all links for a user
-func (db *DB) GetLinksByUserID(ctx context.Context, userID uuid.UUID) ([]models.Link, error) {
-	rows, err := db.Pool.Query(ctx,
-		`SELECT id, user_id, url, title, description, text, created_at, updated_at
-		 FROM links
-		 WHERE user_id = $1
-		 ORDER BY created_at DESC`,
-		userID,
+// scanLink scans a row selected with linkColumns into a link
+func scanLink(row rowScanner, link *models.Link) error {
+	return row.Scan(
+		&link.ID,
+		&link.UserID,
+		&link.URL,
+		&link.Title,
+		&link.Description,
+		&link.Text,
+		&link.Notes,
+		&link.IsFavorite,
+		&link.IsRead,
+		&link.Favicon,
+		&link.SiteName,
+		&link.ImageURL,
+		&link.LastScrapedAt,
+		&link.ContentHash,
+		&link.Position,
+		&link.VisitCount,
+		&link.CreatedAt,
+		&link.UpdatedAt,
 	)
+}
+
+// GetLinksByUserID retrieves links for a user, narrowed by the optional
+// filter, ordered by opts (newest first by default), and paged by opts.Limit
+// and opts.Offset
+func (db *DB) GetLinksByUserID(ctx context.Context, userID uuid.UUID, filter models.LinkFilter, opts models.ListOptions) ([]models.Link, error) {
+	orderBy, err := orderByClause(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query links: %w", err)
+		return nil, err
+	}
+
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	query, args := db.linkFilterQuery(`SELECT `+linkColumns, userID, filter)
+
+	query += orderBy
+	if opts.Limit > 0 {
+		args = append(args, opts.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+	if opts.Offset > 0 {
+		args = append(args, opts.Offset)
+		query += fmt.Sprintf(` OFFSET $%d`, len(args))
+	}
+
+	rows, err := db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to query links")
 	}
 	defer rows.Close()
 
 	var links []models.Link
 	for rows.Next() {
 		var link models.Link
-		err := rows.Scan(
-			&link.ID,
-			&link.UserID,
-			&link.URL,
-			&link.Title,
-			&link.Description,
-			&link.Text,
-			&link.CreatedAt,
-			&link.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan link: %w", err)
+		if err := scanLink(rows, &link); err != nil {
+			return nil, queryError(ctx, err, "failed to scan link")
 		}
 		links = append(links, link)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, err, "failed to read links")
+	}
 
-	return links, rows.Err()
+	return links, nil
+}
+
+// CountLinksByUserID counts a user's links matching the filter, for paging
+func (db *DB) CountLinksByUserID(ctx context.Context, userID uuid.UUID, filter models.LinkFilter) (int64, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	query, args := db.linkFilterQuery(`SELECT COUNT(*)`, userID, filter)
+
+	var count int64
+	if err := db.Pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, queryError(ctx, err, "failed to count links")
+	}
+	return count, nil
+}
+
+// linkFilterQuery appends FROM links and the WHERE clause for a user's links
+// matching filter to selectClause, returning the query and its arguments
+func (db *DB) linkFilterQuery(selectClause string, userID uuid.UUID, filter models.LinkFilter) (string, []interface{}) {
+	query := selectClause + `
+		 FROM links
+		 WHERE user_id = $1`
+	args := []interface{}{userID}
+
+	if filter.FavoritesOnly {
+		query += ` AND is_favorite = TRUE`
+	}
+	if filter.UnreadOnly {
+		query += ` AND NOT is_read`
+	}
+	if filter.UntitledOnly {
+		query += ` AND NULLIF(btrim(title), '') IS NULL`
+	}
+	if filter.CreatedAfter != nil {
+		args = append(args, *filter.CreatedAfter)
+		query += fmt.Sprintf(` AND created_at >= $%d`, len(args))
+	}
+	if filter.CreatedBefore != nil {
+		args = append(args, *filter.CreatedBefore)
+		query += fmt.Sprintf(` AND created_at < $%d`, len(args))
+	}
+	if filter.CreatedWithinDays != nil {
+		args = append(args, *filter.CreatedWithinDays)
+		query += fmt.Sprintf(` AND created_at >= NOW() - make_interval(days => $%d::int)`, len(args))
+	}
+	if filter.StaleDays != nil {
+		args = append(args, *filter.StaleDays)
+		query += fmt.Sprintf(` AND (last_scraped_at IS NULL OR last_scraped_at < NOW() - make_interval(days => $%d::int))`, len(args))
+	}
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		args = append(args, "%"+escapeLike(search)+"%")
+		pattern := db.foldAccents(fmt.Sprintf("$%d", len(args)))
+		query += fmt.Sprintf(` AND (%s ILIKE %s OR %s ILIKE %s OR %s ILIKE %s)`,
+			db.foldAccents("title"), pattern,
+			db.foldAccents("url"), pattern,
+			db.foldAccents("description"), pattern,
+		)
+	}
+
+	return query, args
+}
+
+// foldAccents wraps a SQL expression in unaccent() when the extension is
+// installed, so comparisons ignore accents; otherwise it is returned as-is
+func (db *DB) foldAccents(expr string) string {
+	if !db.unaccent {
+		return expr
+	}
+	return "unaccent(" + expr + ")"
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// orderByClause builds the ORDER BY clause for opts. The sort column comes
+// from the validated allowlist only; id is a stable tiebreaker.
+func orderByClause(opts models.ListOptions) (string, error) {
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
+
+	column := opts.SortBy
+	if column == "" {
+		column = "created_at"
+	}
+	if column == "position" {
+		direction := "ASC"
+		if strings.EqualFold(opts.Order, "desc") {
+			direction = "DESC"
+		}
+		return fmt.Sprintf(` ORDER BY position %s NULLS LAST, created_at DESC, id DESC`, direction), nil
+	}
+	direction := "DESC"
+	if strings.EqualFold(opts.Order, "asc") {
+		direction = "ASC"
+	}
+	return fmt.Sprintf(` ORDER BY %s %s, id %s`, column, direction, direction), nil
 }
 
 // CreateLink creates a new link
 func (db *DB) CreateLink(ctx context.Context, userID uuid.UUID, link models.LinkCreate) (*models.Link, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
 	var created models.Link
-	err := db.Pool.QueryRow(ctx,
-		`INSERT INTO links (user_id, url, title, description, text)
-		 VALUES ($1, $2, $3, $4, $5)
-		 RETURNING id, user_id, url, title, description, text, created_at, updated_at`,
-		userID, link.URL, link.Title, link.Description, link.Text,
-	).Scan(
-		&created.ID,
-		&created.UserID,
-		&created.URL,
-		&created.Title,
-		&created.Description,
-		&created.Text,
-		&created.CreatedAt,
-		&created.UpdatedAt,
+	row := db.Pool.QueryRow(ctx,
+		`INSERT INTO links (user_id, url, title, description, text, notes)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING `+linkColumns,
+		userID, link.URL, link.Title, link.Description, link.Text, link.Notes,
 	)
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to create link: %w", err)
+	if err := scanLink(row, &created); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateLink
+		}
+		return nil, queryError(ctx, err, "failed to create link")
 	}
 
 	return &created, nil
 }
 
+// CreateLinkIdempotent creates a link like CreateLink and records key as the
+// user's idempotency key for it. If the key was already recorded, the link
+// created then is returned with replayed set and nothing new is created:
+// ErrIdempotencyKeyReused if the earlier request had a different body, and
+// ErrLinkNotFound if that link has since been deleted. Concurrent requests
+// with the same key wait for the first to finish.
+func (db *DB) CreateLinkIdempotent(ctx context.Context, userID uuid.UUID, key string, link models.LinkCreate) (created *models.Link, replayed bool, err error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, false, queryError(ctx, err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	// Claiming the key first makes a concurrent request with the same key
+	// block here until this transaction commits or rolls back
+	hash, err := requestHash(link)
+	if err != nil {
+		return nil, false, err
+	}
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO idempotency_keys (user_id, key, request_hash)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT (user_id, key) DO NOTHING`,
+		userID, key, hash,
+	)
+	if err != nil {
+		return nil, false, queryError(ctx, err, "failed to record idempotency key")
+	}
+
+	var result models.Link
+	if tag.RowsAffected() == 0 {
+		var recordedHash *string
+		var linkID *uuid.UUID
+		if err := tx.QueryRow(ctx,
+			`SELECT request_hash, link_id FROM idempotency_keys WHERE user_id = $1 AND key = $2`,
+			userID, key,
+		).Scan(&recordedHash, &linkID); err != nil {
+			return nil, false, queryError(ctx, err, "failed to get idempotency key")
+		}
+		// Keys recorded before request hashes were kept have none to compare
+		if recordedHash != nil && *recordedHash != hash {
+			return nil, false, ErrIdempotencyKeyReused
+		}
+		if linkID == nil {
+			return nil, false, ErrLinkNotFound
+		}
+
+		row := tx.QueryRow(ctx,
+			`SELECT `+linkColumns+` FROM links WHERE id = $1 AND user_id = $2`,
+			*linkID, userID,
+		)
+		if err := scanLink(row, &result); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, false, ErrLinkNotFound
+			}
+			return nil, false, queryError(ctx, err, "failed to get link")
+		}
+		return &result, true, nil
+	}
+
+	row := tx.QueryRow(ctx,
+		`INSERT INTO links (user_id, url, title, description, text, notes)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING `+linkColumns,
+		userID, link.URL, link.Title, link.Description, link.Text, link.Notes,
+	)
+	if err := scanLink(row, &result); err != nil {
+		if isUniqueViolation(err) {
+			return nil, false, ErrDuplicateLink
+		}
+		return nil, false, queryError(ctx, err, "failed to create link")
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE idempotency_keys SET link_id = $3 WHERE user_id = $1 AND key = $2`,
+		userID, key, result.ID,
+	); err != nil {
+		return nil, false, queryError(ctx, err, "failed to record idempotency key")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, queryError(ctx, err, "failed to commit link")
+	}
+
+	return &result, false, nil
+}
+
+// requestHash fingerprints a create request, so a replayed idempotency key
+// can be checked against the request it was first used with
+func requestHash(link models.LinkCreate) (string, error) {
+	body, err := json.Marshal(link)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CreateLinks inserts links in a single transaction. Items whose URL the user
+// already has (including earlier in the same batch) are skipped and left nil
+// in the result, which is in input order.
+func (db *DB) CreateLinks(ctx context.Context, userID uuid.UUID, links []models.LinkCreate) ([]*models.Link, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	for _, link := range links {
+		batch.Queue(
+			`INSERT INTO links (user_id, url, title, description, text, notes)
+			 VALUES ($1, $2, $3, $4, $5, $6)
+			 ON CONFLICT (user_id, url) DO NOTHING
+			 RETURNING `+linkColumns,
+			userID, link.URL, link.Title, link.Description, link.Text, link.Notes,
+		)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	created := make([]*models.Link, len(links))
+	for i := range links {
+		var link models.Link
+		err := scanLink(results.QueryRow(), &link)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue // duplicate URL
+		}
+		if err != nil {
+			results.Close()
+			return nil, queryError(ctx, err, "failed to create links")
+		}
+		created[i] = &link
+	}
+	if err := results.Close(); err != nil {
+		return nil, queryError(ctx, err, "failed to create links")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, queryError(ctx, err, "failed to commit links")
+	}
+
+	return created, nil
+}
+
 // GetLinkByID retrieves a link by ID
 func (db *DB) GetLinkByID(ctx context.Context, linkID, userID uuid.UUID) (*models.Link, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
 	var link models.Link
-	err := db.Pool.QueryRow(ctx,
-		`SELECT id, user_id, url, title, description, text, created_at, updated_at
+	row := db.Pool.QueryRow(ctx,
+		`SELECT `+linkColumns+`
 		 FROM links
 		 WHERE id = $1 AND user_id = $2`,
 		linkID, userID,
-	).Scan(
-		&link.ID,
-		&link.UserID,
-		&link.URL,
-		&link.Title,
-		&link.Description,
-		&link.Text,
-		&link.CreatedAt,
-		&link.UpdatedAt,
 	)
 
-	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("link not found")
+	err := scanLink(row, &link)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLinkNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get link: %w", err)
+		return nil, queryError(ctx, err, "failed to get link")
 	}
 
 	return &link, nil
 }
 
-// UpdateLink updates an existing link
+// UpdateLink updates an existing link. Nil fields are left unchanged and
+// update.ClearFields are set to NULL.
 func (db *DB) UpdateLink(ctx context.Context, linkID, userID uuid.UUID, update models.LinkUpdate) (*models.Link, error) {
+	// ClearFields become column names, so they must come from the allowlist
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
 	// Build dynamic update query based on provided fields
 	query := `UPDATE links SET updated_at = NOW()`
 	args := []interface{}{linkID, userID}
@@ -174,28 +656,149 @@ func (db *DB) UpdateLink(ctx context.Context, linkID, userID uuid.UUID, update m
 	if update.Text != nil {
 		query += fmt.Sprintf(", text = $%d", argPos)
 		args = append(args, *update.Text)
+		argPos++
+	}
+	if update.Notes != nil {
+		query += fmt.Sprintf(", notes = $%d", argPos)
+		args = append(args, *update.Notes)
+		argPos++
+	}
+	if update.IsFavorite != nil {
+		query += fmt.Sprintf(", is_favorite = $%d", argPos)
+		args = append(args, *update.IsFavorite)
+		argPos++
+	}
+	if update.IsRead != nil {
+		query += fmt.Sprintf(", is_read = $%d", argPos)
+		args = append(args, *update.IsRead)
+		argPos++
+	}
+	if update.Favicon != nil {
+		query += fmt.Sprintf(", favicon = $%d", argPos)
+		args = append(args, *update.Favicon)
+		argPos++
+	}
+	if update.SiteName != nil {
+		query += fmt.Sprintf(", site_name = $%d", argPos)
+		args = append(args, *update.SiteName)
+		argPos++
+	}
+	if update.ImageURL != nil {
+		query += fmt.Sprintf(", image_url = $%d", argPos)
+		args = append(args, *update.ImageURL)
+	}
+	for _, field := range update.ClearFields {
+		query += fmt.Sprintf(", %s = NULL", field)
 	}
 
 	query += ` WHERE id = $1 AND user_id = $2
-		RETURNING id, user_id, url, title, description, text, created_at, updated_at`
+		RETURNING ` + linkColumns
 
 	var link models.Link
-	err := db.Pool.QueryRow(ctx, query, args...).Scan(
-		&link.ID,
-		&link.UserID,
-		&link.URL,
-		&link.Title,
-		&link.Description,
-		&link.Text,
-		&link.CreatedAt,
-		&link.UpdatedAt,
+	err := scanLink(db.Pool.QueryRow(ctx, query, args...), &link)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to update link")
+	}
+
+	return &link, nil
+}
+
+// ToggleFavorite flips the favorite flag on a link and returns the updated link
+func (db *DB) ToggleFavorite(ctx context.Context, linkID, userID uuid.UUID) (*models.Link, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	var link models.Link
+	row := db.Pool.QueryRow(ctx,
+		`UPDATE links SET is_favorite = NOT is_favorite, updated_at = NOW()
+		 WHERE id = $1 AND user_id = $2
+		 RETURNING `+linkColumns,
+		linkID, userID,
+	)
+
+	err := scanLink(row, &link)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to toggle favorite")
+	}
+
+	return &link, nil
+}
+
+// RecordVisit counts an opening of a link and returns its new visit count.
+// updated_at is left alone, since the link itself doesn't change.
+func (db *DB) RecordVisit(ctx context.Context, linkID, userID uuid.UUID) (int, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	var count int
+	err := db.Pool.QueryRow(ctx,
+		`UPDATE links SET visit_count = visit_count + 1
+		 WHERE id = $1 AND user_id = $2
+		 RETURNING visit_count`,
+		linkID, userID,
+	).Scan(&count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrLinkNotFound
+	}
+	if err != nil {
+		return 0, queryError(ctx, err, "failed to record visit")
+	}
+
+	return count, nil
+}
+
+// SetLinkRead marks a link read or unread and returns the updated link. A nil
+// read toggles the current status.
+func (db *DB) SetLinkRead(ctx context.Context, linkID, userID uuid.UUID, read *bool) (*models.Link, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	var link models.Link
+	row := db.Pool.QueryRow(ctx,
+		`UPDATE links SET is_read = COALESCE($3, NOT is_read), updated_at = NOW()
+		 WHERE id = $1 AND user_id = $2
+		 RETURNING `+linkColumns,
+		linkID, userID, read,
 	)
 
-	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("link not found")
+	err := scanLink(row, &link)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLinkNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to update link: %w", err)
+		return nil, queryError(ctx, err, "failed to update read status")
+	}
+
+	return &link, nil
+}
+
+// MarkLinkScraped records a successful scrape of a link and the hash of the
+// scraped content, without touching updated_at
+func (db *DB) MarkLinkScraped(ctx context.Context, linkID, userID uuid.UUID, contentHash string) (*models.Link, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	var link models.Link
+	row := db.Pool.QueryRow(ctx,
+		`UPDATE links SET last_scraped_at = NOW(), content_hash = $3
+		 WHERE id = $1 AND user_id = $2
+		 RETURNING `+linkColumns,
+		linkID, userID, contentHash,
+	)
+
+	err := scanLink(row, &link)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to mark link scraped")
 	}
 
 	return &link, nil
@@ -203,17 +806,195 @@ func (db *DB) UpdateLink(ctx context.Context, linkID, userID uuid.UUID, update m
 
 // DeleteLink deletes a link
 func (db *DB) DeleteLink(ctx context.Context, linkID, userID uuid.UUID) error {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
 	result, err := db.Pool.Exec(ctx,
 		`DELETE FROM links WHERE id = $1 AND user_id = $2`,
 		linkID, userID,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to delete link: %w", err)
+		return queryError(ctx, err, "failed to delete link")
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("link not found")
+		return ErrLinkNotFound
 	}
 
 	return nil
 }
+
+// DeleteLinksByIDs deletes multiple links in a single statement, scoped to the user.
+// Returns the number of links actually deleted; IDs belonging to other users are ignored.
+func (db *DB) DeleteLinksByIDs(ctx context.Context, ids []uuid.UUID, userID uuid.UUID) (int64, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := db.Pool.Exec(ctx,
+		`DELETE FROM links WHERE id = ANY($1) AND user_id = $2`,
+		ids, userID,
+	)
+	if err != nil {
+		return 0, queryError(ctx, err, "failed to delete links")
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ReorderLinks sets the user's manual link ordering in a single transaction.
+// The given links take the first positions in order; the user's other links
+// follow in their current order. Returns ErrLinkNotFound, changing nothing,
+// if any ID is not one of the user's links. ids must not contain duplicates.
+func (db *DB) ReorderLinks(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return queryError(ctx, err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var owned int
+	if err := tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM links WHERE user_id = $1 AND id = ANY($2)`,
+		userID, ids,
+	).Scan(&owned); err != nil {
+		return queryError(ctx, err, "failed to check links")
+	}
+	if owned != len(ids) {
+		return ErrLinkNotFound
+	}
+
+	_, err = tx.Exec(ctx,
+		`WITH given AS (
+		     SELECT id, ord FROM unnest($2::uuid[]) WITH ORDINALITY AS t(id, ord)
+		 ), ranked AS (
+		     SELECT l.id, ROW_NUMBER() OVER (
+		         ORDER BY g.ord NULLS LAST, l.position NULLS LAST, l.created_at DESC, l.id DESC
+		     ) AS position
+		     FROM links l
+		     LEFT JOIN given g ON g.id = l.id
+		     WHERE l.user_id = $1
+		 )
+		 UPDATE links SET position = ranked.position
+		 FROM ranked
+		 WHERE links.id = ranked.id`,
+		userID, ids,
+	)
+	if err != nil {
+		return queryError(ctx, err, "failed to reorder links")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return queryError(ctx, err, "failed to commit link order")
+	}
+
+	return nil
+}
+
+// TransferLinks moves links from one user to another in a single transaction
+// and returns how many moved. Moved links drop out of the manual ordering.
+// Returns ErrLinkNotFound, moving nothing, if any ID is not one of fromUserID's
+// links, and ErrDuplicateLink if toUserID already has one of the URLs.
+func (db *DB) TransferLinks(ctx context.Context, ids []uuid.UUID, fromUserID, toUserID uuid.UUID) (int64, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, queryError(ctx, err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var owned int
+	if err := tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM links WHERE user_id = $1 AND id = ANY($2)`,
+		fromUserID, ids,
+	).Scan(&owned); err != nil {
+		return 0, queryError(ctx, err, "failed to check links")
+	}
+	if owned != len(ids) {
+		return 0, ErrLinkNotFound
+	}
+
+	result, err := tx.Exec(ctx,
+		`UPDATE links SET user_id = $3, position = NULL, updated_at = NOW()
+		 WHERE user_id = $1 AND id = ANY($2)`,
+		fromUserID, ids, toUserID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, ErrDuplicateLink
+		}
+		return 0, queryError(ctx, err, "failed to transfer links")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, queryError(ctx, err, "failed to commit link transfer")
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// similarityDocument is the full-text document links are compared by: the
+// title, description, and the start of the text, stemmed as English
+const similarityDocument = `to_tsvector('english',
+	coalesce(title, '') || ' ' || coalesce(description, '') || ' ' || left(coalesce(text, ''), 5000))`
+
+// GetSimilarLinks returns up to limit of the user's other links sharing terms
+// with the given link's title, description, or text, most similar first.
+// Returns ErrLinkNotFound if the link isn't the user's.
+func (db *DB) GetSimilarLinks(ctx context.Context, linkID, userID uuid.UUID, limit int) ([]models.Link, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	// OR together the link's own terms; quoting keeps each lexeme literal
+	var terms string
+	err := db.Pool.QueryRow(ctx,
+		`SELECT coalesce((SELECT string_agg(quote_literal(lexeme), ' | ') FROM unnest(`+similarityDocument+`)), '')
+		 FROM links
+		 WHERE id = $1 AND user_id = $2`,
+		linkID, userID,
+	).Scan(&terms)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to get link terms")
+	}
+	if terms == "" {
+		return []models.Link{}, nil
+	}
+
+	rows, err := db.Pool.Query(ctx,
+		`SELECT `+linkColumns+`
+		 FROM links
+		 WHERE user_id = $1 AND id <> $2 AND `+similarityDocument+` @@ $3::tsquery
+		 ORDER BY ts_rank(`+similarityDocument+`, $3::tsquery) DESC, created_at DESC, id DESC
+		 LIMIT $4`,
+		userID, linkID, terms, limit,
+	)
+	if err != nil {
+		return nil, queryError(ctx, err, "failed to query similar links")
+	}
+	defer rows.Close()
+
+	links := []models.Link{}
+	for rows.Next() {
+		var link models.Link
+		if err := scanLink(rows, &link); err != nil {
+			return nil, queryError(ctx, err, "failed to scan link")
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, err, "failed to read links")
+	}
+
+	return links, nil
+}
@@ -0,0 +1,881 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+func TestDeleteLinksByIDs(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	owner := dbtest.CreateUser(t, database)
+	other := dbtest.CreateUser(t, database)
+	a1 := dbtest.CreateLink(t, database, owner.ID, "https://example.com/1", nil)
+	a2 := dbtest.CreateLink(t, database, owner.ID, "https://example.com/2", nil)
+	a3 := dbtest.CreateLink(t, database, owner.ID, "https://example.com/3", nil)
+	b1 := dbtest.CreateLink(t, database, other.ID, "https://example.com/1", nil)
+
+	deleted, err := database.DeleteLinksByIDs(ctx, []uuid.UUID{a1.ID, a2.ID, b1.ID, uuid.New()}, owner.ID)
+	if err != nil {
+		t.Fatalf("DeleteLinksByIDs: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted = %d, want 2 (only the owner's links)", deleted)
+	}
+
+	for _, id := range []uuid.UUID{a1.ID, a2.ID} {
+		if _, err := database.GetLinkByID(ctx, id, owner.ID); !errors.Is(err, db.ErrLinkNotFound) {
+			t.Errorf("GetLinkByID(%s) after delete: err = %v, want ErrLinkNotFound", id, err)
+		}
+	}
+	if _, err := database.GetLinkByID(ctx, a3.ID, owner.ID); err != nil {
+		t.Errorf("unlisted link was deleted: %v", err)
+	}
+	if _, err := database.GetLinkByID(ctx, b1.ID, other.ID); err != nil {
+		t.Errorf("another user's link was deleted: %v", err)
+	}
+
+	deleted, err = database.DeleteLinksByIDs(ctx, nil, owner.ID)
+	if err != nil || deleted != 0 {
+		t.Errorf("DeleteLinksByIDs(nil) = %d, %v; want 0, nil", deleted, err)
+	}
+}
+
+func TestToggleFavorite(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/fav", nil)
+	if link.IsFavorite {
+		t.Fatal("new link is a favorite")
+	}
+
+	for i, want := range []bool{true, false} {
+		toggled, err := database.ToggleFavorite(ctx, link.ID, user.ID)
+		if err != nil {
+			t.Fatalf("ToggleFavorite #%d: %v", i+1, err)
+		}
+		if toggled.IsFavorite != want {
+			t.Errorf("after toggle #%d: IsFavorite = %v, want %v", i+1, toggled.IsFavorite, want)
+		}
+	}
+
+	other := dbtest.CreateUser(t, database)
+	if _, err := database.ToggleFavorite(ctx, link.ID, other.ID); !errors.Is(err, db.ErrLinkNotFound) {
+		t.Errorf("toggling another user's link: err = %v, want ErrLinkNotFound", err)
+	}
+}
+
+func TestGetLinksByUserIDFavoritesOnly(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	fav := dbtest.CreateLink(t, database, user.ID, "https://example.com/fav", nil)
+	dbtest.CreateLink(t, database, user.ID, "https://example.com/plain", nil)
+	if _, err := database.ToggleFavorite(ctx, fav.ID, user.ID); err != nil {
+		t.Fatalf("ToggleFavorite: %v", err)
+	}
+
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{FavoritesOnly: true}, models.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, fav.ID)
+
+	all, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{}, models.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("unfiltered: got %d links, want 2", len(all))
+	}
+}
+
+func TestSetLinkRead(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/article", nil)
+	if link.IsRead {
+		t.Fatal("new link is read")
+	}
+
+	read, unread := true, false
+	tests := []struct {
+		name string
+		read *bool
+		want bool
+	}{
+		{"toggle", nil, true},
+		{"toggle back", nil, false},
+		{"mark read", &read, true},
+		{"mark read again", &read, true},
+		{"mark unread", &unread, false},
+	}
+	for _, tt := range tests {
+		updated, err := database.SetLinkRead(ctx, link.ID, user.ID, tt.read)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if updated.IsRead != tt.want {
+			t.Errorf("%s: IsRead = %v, want %v", tt.name, updated.IsRead, tt.want)
+		}
+	}
+
+	other := dbtest.CreateUser(t, database)
+	if _, err := database.SetLinkRead(ctx, link.ID, other.ID, &read); !errors.Is(err, db.ErrLinkNotFound) {
+		t.Errorf("marking another user's link: err = %v, want ErrLinkNotFound", err)
+	}
+}
+
+func TestRecordVisit(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/article", nil)
+	if link.VisitCount != 0 {
+		t.Fatalf("new link has %d visits", link.VisitCount)
+	}
+
+	for want := 1; want <= 3; want++ {
+		count, err := database.RecordVisit(ctx, link.ID, user.ID)
+		if err != nil {
+			t.Fatalf("RecordVisit: %v", err)
+		}
+		if count != want {
+			t.Errorf("RecordVisit = %d, want %d", count, want)
+		}
+	}
+
+	got, err := database.GetLinkByID(ctx, link.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetLinkByID: %v", err)
+	}
+	if got.VisitCount != 3 {
+		t.Errorf("stored visit_count = %d, want 3", got.VisitCount)
+	}
+	if !got.UpdatedAt.Equal(link.UpdatedAt) {
+		t.Errorf("updated_at moved from %v to %v; a visit doesn't change the link", link.UpdatedAt, got.UpdatedAt)
+	}
+
+	other := dbtest.CreateUser(t, database)
+	if _, err := database.RecordVisit(ctx, link.ID, other.ID); !errors.Is(err, db.ErrLinkNotFound) {
+		t.Errorf("visiting another user's link: err = %v, want ErrLinkNotFound", err)
+	}
+}
+
+func TestGetLinksByUserIDSortByVisitCount(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	never := dbtest.CreateLink(t, database, user.ID, "https://example.com/never", nil)
+	often := dbtest.CreateLink(t, database, user.ID, "https://example.com/often", nil)
+	once := dbtest.CreateLink(t, database, user.ID, "https://example.com/once", nil)
+	for _, id := range []uuid.UUID{often.ID, often.ID, once.ID} {
+		if _, err := database.RecordVisit(ctx, id, user.ID); err != nil {
+			t.Fatalf("RecordVisit: %v", err)
+		}
+	}
+
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{}, models.ListOptions{SortBy: "visit_count"})
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, often.ID, once.ID, never.ID)
+
+	links, err = database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{}, models.ListOptions{SortBy: "visit_count", Order: "asc"})
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, never.ID, once.ID, often.ID)
+}
+
+func TestGetLinksByUserIDSortByTitleUntitledLast(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	untitled := dbtest.CreateLink(t, database, user.ID, "https://example.com/untitled", nil)
+	beta := dbtest.CreateLink(t, database, user.ID, "https://example.com/beta", strPtr("Beta"))
+	alpha := dbtest.CreateLink(t, database, user.ID, "https://example.com/alpha", strPtr("Alpha"))
+
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{}, models.ListOptions{SortBy: "title", Order: "asc"})
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, alpha.ID, beta.ID, untitled.ID)
+}
+
+func TestGetLinksByUserIDUnreadOnly(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	read := dbtest.CreateLink(t, database, user.ID, "https://example.com/read", nil)
+	unread := dbtest.CreateLink(t, database, user.ID, "https://example.com/unread", nil)
+	unreadFav := dbtest.CreateLink(t, database, user.ID, "https://example.com/unread-fav", nil)
+	if _, err := database.SetLinkRead(ctx, read.ID, user.ID, nil); err != nil {
+		t.Fatalf("SetLinkRead: %v", err)
+	}
+	if _, err := database.ToggleFavorite(ctx, unreadFav.ID, user.ID); err != nil {
+		t.Fatalf("ToggleFavorite: %v", err)
+	}
+	byURL := models.ListOptions{SortBy: "url", Order: "asc"}
+
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{UnreadOnly: true}, byURL)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, unread.ID, unreadFav.ID)
+
+	links, err = database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{UnreadOnly: true, FavoritesOnly: true}, byURL)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, unreadFav.ID)
+
+	count, err := database.CountLinksByUserID(ctx, user.ID, models.LinkFilter{UnreadOnly: true})
+	if err != nil {
+		t.Fatalf("CountLinksByUserID: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("CountLinksByUserID(unread) = %d, want 2", count)
+	}
+}
+
+func TestGetLinksByUserIDCreatedRange(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	createdAt := func(url, at string) *models.Link {
+		link := dbtest.CreateLink(t, database, user.ID, url, nil)
+		dbtest.Exec(t, database, `UPDATE links SET created_at = $1 WHERE id = $2`, at, link.ID)
+		return link
+	}
+	createdAt("https://example.com/before", "2024-02-29 23:59:59")
+	first := createdAt("https://example.com/first", "2024-03-01 00:00:00")
+	last := createdAt("https://example.com/last", "2024-03-04 23:59:59")
+	createdAt("https://example.com/after", "2024-03-05 00:00:00")
+
+	date := func(s string) *time.Time {
+		d, err := time.Parse(models.LinkFilterDateLayout, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &d
+	}
+	oldestFirst := models.ListOptions{SortBy: "created_at", Order: "asc"}
+
+	// created_after is inclusive and created_before exclusive
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{
+		CreatedAfter:  date("2024-03-01"),
+		CreatedBefore: date("2024-03-05"),
+	}, oldestFirst)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, first.ID, last.ID)
+
+	// Either bound works alone
+	links, err = database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{CreatedBefore: date("2024-03-01")}, oldestFirst)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	if len(links) != 1 || links[0].URL != "https://example.com/before" {
+		t.Errorf("created_before=2024-03-01: got %d links, want only the one before", len(links))
+	}
+	links, err = database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{CreatedAfter: date("2024-03-05")}, oldestFirst)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	if len(links) != 1 || links[0].URL != "https://example.com/after" {
+		t.Errorf("created_after=2024-03-05: got %d links, want only the one after", len(links))
+	}
+}
+
+func TestGetLinksByUserIDStale(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	never := dbtest.CreateLink(t, database, user.ID, "https://example.com/never", nil)
+	scrapedAgo := func(url, interval string) *models.Link {
+		link := dbtest.CreateLink(t, database, user.ID, url, nil)
+		dbtest.Exec(t, database, `UPDATE links SET last_scraped_at = NOW() - $1::interval WHERE id = $2`, interval, link.ID)
+		return link
+	}
+	old := scrapedAgo("https://example.com/old", "7 days 1 minute")
+	scrapedAgo("https://example.com/recent", "6 days 23 hours")
+
+	// Just scraped, so never stale
+	fresh := dbtest.CreateLink(t, database, user.ID, "https://example.com/fresh", nil)
+	marked, err := database.MarkLinkScraped(ctx, fresh.ID, user.ID, "hash")
+	if err != nil {
+		t.Fatalf("MarkLinkScraped: %v", err)
+	}
+	if marked.LastScrapedAt == nil {
+		t.Fatal("MarkLinkScraped didn't set last_scraped_at")
+	}
+
+	oldestFirst := models.ListOptions{SortBy: "created_at", Order: "asc"}
+	days := 7
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{StaleDays: &days}, oldestFirst)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, never.ID, old.ID)
+
+	count, err := database.CountLinksByUserID(ctx, user.ID, models.LinkFilter{StaleDays: &days})
+	if err != nil || count != 2 {
+		t.Errorf("CountLinksByUserID(stale_days=7) = %d, %v; want 2, nil", count, err)
+	}
+
+	// stale_days=0 keeps everything scraped before now, plus the never-scraped
+	days = 0
+	links, err = database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{StaleDays: &days}, oldestFirst)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	if len(links) != 4 {
+		t.Errorf("stale_days=0: got %d links, want 4", len(links))
+	}
+}
+
+func TestGetLinksByUserIDSortOrders(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	title := func(s string) *string { return &s }
+	// Created oldest first, with titles and URLs in other orders
+	a := dbtest.CreateLink(t, database, user.ID, "https://c.example.com", title("beta"))
+	b := dbtest.CreateLink(t, database, user.ID, "https://a.example.com", title("gamma"))
+	c := dbtest.CreateLink(t, database, user.ID, "https://b.example.com", title("alpha"))
+	for i, link := range []*models.Link{a, b, c} {
+		dbtest.Exec(t, database, `UPDATE links SET created_at = NOW() - make_interval(days => $1), updated_at = NOW() - make_interval(hours => $2) WHERE id = $3`,
+			3-i, i+1, link.ID)
+	}
+
+	tests := []struct {
+		sortBy, order string
+		want          []uuid.UUID
+	}{
+		{"", "", []uuid.UUID{c.ID, b.ID, a.ID}},
+		{"created_at", "asc", []uuid.UUID{a.ID, b.ID, c.ID}},
+		{"updated_at", "desc", []uuid.UUID{a.ID, b.ID, c.ID}},
+		{"updated_at", "asc", []uuid.UUID{c.ID, b.ID, a.ID}},
+		{"title", "asc", []uuid.UUID{c.ID, a.ID, b.ID}},
+		{"title", "desc", []uuid.UUID{b.ID, a.ID, c.ID}},
+		{"url", "asc", []uuid.UUID{b.ID, c.ID, a.ID}},
+		{"url", "desc", []uuid.UUID{a.ID, c.ID, b.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy+" "+tt.order, func(t *testing.T) {
+			links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{}, models.ListOptions{SortBy: tt.sortBy, Order: tt.order})
+			if err != nil {
+				t.Fatalf("GetLinksByUserID: %v", err)
+			}
+			assertLinkIDs(t, links, tt.want...)
+		})
+	}
+
+	if _, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{}, models.ListOptions{SortBy: "email"}); err == nil {
+		t.Error("sorting by an unlisted column succeeded, want an error")
+	}
+}
+
+// assertLinkIDs fails the test unless links has exactly the given IDs, in order
+func assertLinkIDs(t *testing.T, links []models.Link, want ...uuid.UUID) {
+	t.Helper()
+
+	got := make([]uuid.UUID, len(links))
+	for i, link := range links {
+		got[i] = link.ID
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got links %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got links %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCreateLinksMixedBatch(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	other := dbtest.CreateUser(t, database)
+	existing := dbtest.CreateLink(t, database, user.ID, "https://example.com/existing", nil)
+	dbtest.CreateLink(t, database, other.ID, "https://example.com/theirs", nil)
+
+	title := "New One"
+	created, err := database.CreateLinks(ctx, user.ID, []models.LinkCreate{
+		{URL: "https://example.com/new", Title: &title},
+		{URL: "https://example.com/existing"},  // the user already has it
+		{URL: "https://example.com/theirs"},    // only another user has it
+		{URL: "https://example.com/new"},       // repeated within the batch
+		{URL: "https://example.com/new-again"}, // after the skipped items
+	})
+	if err != nil {
+		t.Fatalf("CreateLinks: %v", err)
+	}
+
+	wantCreated := []string{"https://example.com/new", "", "https://example.com/theirs", "", "https://example.com/new-again"}
+	if len(created) != len(wantCreated) {
+		t.Fatalf("got %d results, want %d", len(created), len(wantCreated))
+	}
+	for i, want := range wantCreated {
+		switch {
+		case want == "" && created[i] != nil:
+			t.Errorf("item %d: created %s, want it skipped as a duplicate", i, created[i].URL)
+		case want != "" && (created[i] == nil || created[i].URL != want || created[i].UserID != user.ID):
+			t.Errorf("item %d: got %+v, want %s created for the user", i, created[i], want)
+		}
+	}
+	if created[0] != nil && (created[0].Title == nil || *created[0].Title != title) {
+		t.Errorf("item 0 title = %v, want %q", created[0].Title, title)
+	}
+
+	count, err := database.CountLinksByUserID(ctx, user.ID, models.LinkFilter{})
+	if err != nil || count != 4 {
+		t.Errorf("CountLinksByUserID = %d, %v; want 4 (1 existing + 3 created), nil", count, err)
+	}
+	if got, err := database.GetLinkByID(ctx, existing.ID, user.ID); err != nil || got.Title != nil {
+		t.Errorf("existing link = %+v, %v; want it untouched", got, err)
+	}
+}
+
+func TestUpdateLinkClearFields(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	title, description, notes := "Title", "Description", "Notes"
+	link, err := database.CreateLink(ctx, user.ID, models.LinkCreate{
+		URL: "https://example.com/clear", Title: &title, Description: &description, Notes: &notes,
+	})
+	if err != nil {
+		t.Fatalf("CreateLink: %v", err)
+	}
+
+	// Nil fields are left alone
+	favorite := true
+	updated, err := database.UpdateLink(ctx, link.ID, user.ID, models.LinkUpdate{IsFavorite: &favorite})
+	if err != nil {
+		t.Fatalf("UpdateLink: %v", err)
+	}
+	if updated.Title == nil || *updated.Title != title || updated.Description == nil || *updated.Description != description {
+		t.Errorf("after an unrelated update: title %v, description %v; want both unchanged", updated.Title, updated.Description)
+	}
+
+	// Cleared fields become NULL, not an empty string
+	updated, err = database.UpdateLink(ctx, link.ID, user.ID, models.LinkUpdate{ClearFields: []string{"title", "description"}})
+	if err != nil {
+		t.Fatalf("UpdateLink clearing fields: %v", err)
+	}
+	if updated.Title != nil || updated.Description != nil {
+		t.Errorf("after clearing: title %v, description %v; want both nil", updated.Title, updated.Description)
+	}
+	if updated.Notes == nil || *updated.Notes != notes {
+		t.Errorf("after clearing: notes %v, want %q unchanged", updated.Notes, notes)
+	}
+
+	// An empty string is still stored as one
+	empty := ""
+	updated, err = database.UpdateLink(ctx, link.ID, user.ID, models.LinkUpdate{Title: &empty})
+	if err != nil {
+		t.Fatalf("UpdateLink with an empty title: %v", err)
+	}
+	if updated.Title == nil || *updated.Title != "" {
+		t.Errorf("after setting \"\": title %v, want an empty string", updated.Title)
+	}
+
+	// Clearing a column outside the allowlist fails before reaching the database
+	if _, err := database.UpdateLink(ctx, link.ID, user.ID, models.LinkUpdate{ClearFields: []string{"url"}}); err == nil {
+		t.Error("clearing url succeeded, want an error")
+	}
+}
+
+func TestGetLinksByUserIDUntitled(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	title, empty, blank := "Has a Title", "", "   "
+	dbtest.CreateLink(t, database, user.ID, "https://example.com/titled", &title)
+	noTitle := dbtest.CreateLink(t, database, user.ID, "https://example.com/null", nil)
+	emptyTitle := dbtest.CreateLink(t, database, user.ID, "https://example.com/empty", &empty)
+	blankTitle := dbtest.CreateLink(t, database, user.ID, "https://example.com/blank", &blank)
+	other := dbtest.CreateUser(t, database)
+	dbtest.CreateLink(t, database, other.ID, "https://example.com/theirs", nil)
+
+	oldestFirst := models.ListOptions{SortBy: "created_at", Order: "asc"}
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{UntitledOnly: true}, oldestFirst)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, noTitle.ID, emptyTitle.ID, blankTitle.ID)
+
+	// Combines with other filters
+	if _, err := database.ToggleFavorite(ctx, emptyTitle.ID, user.ID); err != nil {
+		t.Fatalf("ToggleFavorite: %v", err)
+	}
+	filter := models.LinkFilter{UntitledOnly: true, FavoritesOnly: true}
+	links, err = database.GetLinksByUserID(ctx, user.ID, filter, oldestFirst)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, emptyTitle.ID)
+	count, err := database.CountLinksByUserID(ctx, user.ID, filter)
+	if err != nil || count != 1 {
+		t.Errorf("CountLinksByUserID(untitled, favorites) = %d, %v; want 1, nil", count, err)
+	}
+
+	links, err = database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{UntitledOnly: true, Search: "blank"}, oldestFirst)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, blankTitle.ID)
+}
+
+func TestReorderLinks(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	other := dbtest.CreateUser(t, database)
+	a := dbtest.CreateLink(t, database, user.ID, "https://example.com/a", nil)
+	b := dbtest.CreateLink(t, database, user.ID, "https://example.com/b", nil)
+	c := dbtest.CreateLink(t, database, user.ID, "https://example.com/c", nil)
+	d := dbtest.CreateLink(t, database, user.ID, "https://example.com/d", nil)
+	foreign := dbtest.CreateLink(t, database, other.ID, "https://example.com/foreign", nil)
+	byPosition := models.ListOptions{SortBy: "position", Order: "asc"}
+
+	// The given links come first, then the rest
+	if err := database.ReorderLinks(ctx, user.ID, []uuid.UUID{c.ID, a.ID}); err != nil {
+		t.Fatalf("ReorderLinks: %v", err)
+	}
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{}, byPosition)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, c.ID, a.ID, d.ID, b.ID)
+	for i, link := range links {
+		if link.Position == nil || *link.Position != i+1 {
+			t.Errorf("link %s: position = %v, want %d", link.URL, link.Position, i+1)
+		}
+	}
+
+	// An ID that isn't the user's fails the whole reorder
+	if err := database.ReorderLinks(ctx, user.ID, []uuid.UUID{b.ID, foreign.ID}); !errors.Is(err, db.ErrLinkNotFound) {
+		t.Fatalf("ReorderLinks with a foreign link: err = %v, want ErrLinkNotFound", err)
+	}
+	links, err = database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{}, byPosition)
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, c.ID, a.ID, d.ID, b.ID)
+
+	got, err := database.GetLinkByID(ctx, foreign.ID, other.ID)
+	if err != nil {
+		t.Fatalf("GetLinkByID: %v", err)
+	}
+	if got.Position != nil {
+		t.Errorf("other user's link position = %d, want unset", *got.Position)
+	}
+}
+
+func TestCreateLinkIdempotent(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	title := "Retried"
+	create := models.LinkCreate{URL: "https://example.com/retried", Title: &title}
+
+	first, replayed, err := database.CreateLinkIdempotent(ctx, user.ID, "key-1", create)
+	if err != nil || replayed {
+		t.Fatalf("first create = %v, %v; want a new link", replayed, err)
+	}
+
+	// Repeating the request returns the same link without creating another
+	again, replayed, err := database.CreateLinkIdempotent(ctx, user.ID, "key-1", create)
+	if err != nil || !replayed {
+		t.Fatalf("repeat = %v, %v; want a replay", replayed, err)
+	}
+	if again.ID != first.ID {
+		t.Errorf("repeat returned link %s, want %s", again.ID, first.ID)
+	}
+	if count, err := database.CountLinksByUserID(ctx, user.ID, models.LinkFilter{}); err != nil || count != 1 {
+		t.Errorf("CountLinksByUserID = %d, %v; want 1, nil", count, err)
+	}
+
+	// The key belongs to the user; another user may use the same one
+	other := dbtest.CreateUser(t, database)
+	theirs, replayed, err := database.CreateLinkIdempotent(ctx, other.ID, "key-1", create)
+	if err != nil || replayed || theirs.ID == first.ID {
+		t.Errorf("other user's create = %v, %v; want their own new link", replayed, err)
+	}
+
+	// A different body under the same key is rejected, creating nothing
+	changed := models.LinkCreate{URL: "https://example.com/something-else"}
+	if _, _, err := database.CreateLinkIdempotent(ctx, user.ID, "key-1", changed); !errors.Is(err, db.ErrIdempotencyKeyReused) {
+		t.Errorf("different body: err = %v, want ErrIdempotencyKeyReused", err)
+	}
+	if count, err := database.CountLinksByUserID(ctx, user.ID, models.LinkFilter{}); err != nil || count != 1 {
+		t.Errorf("CountLinksByUserID after a reused key = %d, %v; want 1, nil", count, err)
+	}
+
+	// Once the link is deleted, replaying its key doesn't bring it back
+	if err := database.DeleteLink(ctx, first.ID, user.ID); err != nil {
+		t.Fatalf("DeleteLink: %v", err)
+	}
+	if _, _, err := database.CreateLinkIdempotent(ctx, user.ID, "key-1", create); !errors.Is(err, db.ErrLinkNotFound) {
+		t.Errorf("replay after delete: err = %v, want ErrLinkNotFound", err)
+	}
+	if count, err := database.CountLinksByUserID(ctx, user.ID, models.LinkFilter{}); err != nil || count != 0 {
+		t.Errorf("CountLinksByUserID after replaying a deleted link's key = %d, %v; want 0, nil", count, err)
+	}
+}
+
+func TestGetLinksByUserIDSearch(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	var unaccent bool
+	if err := database.Pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'unaccent')`,
+	).Scan(&unaccent); err != nil {
+		t.Fatalf("checking for unaccent: %v", err)
+	}
+
+	user := dbtest.CreateUser(t, database)
+	title := func(s string) *string { return &s }
+	accented := dbtest.CreateLink(t, database, user.ID, "https://example.com/1", title("Café Culture"))
+	plain := dbtest.CreateLink(t, database, user.ID, "https://example.com/2", title("CAFE menu"))
+	naive := dbtest.CreateLink(t, database, user.ID, "https://example.com/3", title("naive bayes"))
+	percent := dbtest.CreateLink(t, database, user.ID, "https://example.com/4", title("50% off"))
+	dbtest.CreateLink(t, database, user.ID, "https://example.com/5", title("500 deals"))
+	snake := dbtest.CreateLink(t, database, user.ID, "https://example.com/snake_case", nil)
+	other := dbtest.CreateUser(t, database)
+	dbtest.CreateLink(t, database, other.ID, "https://example.com/cafe", title("Café"))
+
+	tests := []struct {
+		search        string
+		want          []uuid.UUID
+		needsUnaccent bool
+	}{
+		{"culture", []uuid.UUID{accented.ID}, false},
+		{"café", []uuid.UUID{accented.ID, plain.ID}, true},
+		{"cafe", []uuid.UUID{accented.ID, plain.ID}, true},
+		{"CAFÉ", []uuid.UUID{accented.ID, plain.ID}, true},
+		{"NAÏVE", []uuid.UUID{naive.ID}, true},
+		{"50%", []uuid.UUID{percent.ID}, false},
+		{"snake_case", []uuid.UUID{snake.ID}, false},
+		{"nothing like it", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			if tt.needsUnaccent && !unaccent {
+				t.Skip("unaccent extension not installed")
+			}
+			links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{Search: tt.search}, models.ListOptions{SortBy: "url", Order: "asc"})
+			if err != nil {
+				t.Fatalf("GetLinksByUserID: %v", err)
+			}
+			assertLinkIDs(t, links, tt.want...)
+		})
+	}
+}
+
+func TestGetLinksByUserIDCreatedWithinDays(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	createdAgo := func(url, ago string) *models.Link {
+		link := dbtest.CreateLink(t, database, user.ID, url, nil)
+		dbtest.Exec(t, database, `UPDATE links SET created_at = NOW() - $1::interval WHERE id = $2`, ago, link.ID)
+		return link
+	}
+	today := dbtest.CreateLink(t, database, user.ID, "https://example.com/today", nil)
+	inside := createdAgo("https://example.com/inside", "6 days 23 hours")
+	createdAgo("https://example.com/outside", "7 days 1 hour")
+
+	days := 7
+	links, err := database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{CreatedWithinDays: &days}, models.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, today.ID, inside.ID)
+
+	days = 1
+	links, err = database.GetLinksByUserID(ctx, user.ID, models.LinkFilter{CreatedWithinDays: &days}, models.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetLinksByUserID: %v", err)
+	}
+	assertLinkIDs(t, links, today.ID)
+}
+
+func TestTransferLinks(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	from := dbtest.CreateUser(t, database)
+	to := dbtest.CreateUser(t, database)
+	a := dbtest.CreateLink(t, database, from.ID, "https://example.com/a", nil)
+	b := dbtest.CreateLink(t, database, from.ID, "https://example.com/b", nil)
+	shared := dbtest.CreateLink(t, database, from.ID, "https://example.com/shared", nil)
+	dbtest.CreateLink(t, database, to.ID, "https://example.com/shared", nil)
+	foreign := dbtest.CreateLink(t, database, to.ID, "https://example.com/foreign", nil)
+
+	// A link that isn't the sender's moves nothing
+	if _, err := database.TransferLinks(ctx, []uuid.UUID{a.ID, foreign.ID}, from.ID, to.ID); !errors.Is(err, db.ErrLinkNotFound) {
+		t.Fatalf("with a foreign link: err = %v, want ErrLinkNotFound", err)
+	}
+	// nor does a URL the target already has
+	if _, err := database.TransferLinks(ctx, []uuid.UUID{a.ID, shared.ID}, from.ID, to.ID); !errors.Is(err, db.ErrDuplicateLink) {
+		t.Fatalf("with a duplicate URL: err = %v, want ErrDuplicateLink", err)
+	}
+	if _, err := database.GetLinkByID(ctx, a.ID, from.ID); err != nil {
+		t.Fatalf("after failed transfers: link a no longer the sender's: %v", err)
+	}
+
+	moved, err := database.TransferLinks(ctx, []uuid.UUID{a.ID, b.ID}, from.ID, to.ID)
+	if err != nil {
+		t.Fatalf("TransferLinks: %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("moved %d links, want 2", moved)
+	}
+	for _, link := range []*models.Link{a, b} {
+		if _, err := database.GetLinkByID(ctx, link.ID, to.ID); err != nil {
+			t.Errorf("link %s not the target's: %v", link.URL, err)
+		}
+		if _, err := database.GetLinkByID(ctx, link.ID, from.ID); !errors.Is(err, db.ErrLinkNotFound) {
+			t.Errorf("link %s still the sender's: err = %v", link.URL, err)
+		}
+	}
+}
+
+func TestGetSimilarLinks(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	title := func(s string) *string { return &s }
+	source := dbtest.CreateLink(t, database, user.ID, "https://example.com/go-concurrency", title("Concurrency patterns in Go: goroutines and channels"))
+	closest := dbtest.CreateLink(t, database, user.ID, "https://example.com/channels", title("Go channels and goroutines explained"))
+	related := dbtest.CreateLink(t, database, user.ID, "https://example.com/patterns", title("Concurrency patterns"))
+	dbtest.CreateLink(t, database, user.ID, "https://example.com/bread", title("Baking sourdough bread at home"))
+	untitled := dbtest.CreateLink(t, database, user.ID, "https://example.com/untitled", nil)
+	other := dbtest.CreateUser(t, database)
+	dbtest.CreateLink(t, database, other.ID, "https://example.com/go-concurrency", title("Concurrency patterns in Go: goroutines and channels"))
+
+	// Related links, the closest first; the link itself, the unrelated link,
+	// and other users' links are left out
+	links, err := database.GetSimilarLinks(ctx, source.ID, user.ID, 10)
+	if err != nil {
+		t.Fatalf("GetSimilarLinks: %v", err)
+	}
+	assertLinkIDs(t, links, closest.ID, related.ID)
+
+	links, err = database.GetSimilarLinks(ctx, source.ID, user.ID, 1)
+	if err != nil {
+		t.Fatalf("GetSimilarLinks with limit 1: %v", err)
+	}
+	assertLinkIDs(t, links, closest.ID)
+
+	// A link without any terms has nothing similar
+	links, err = database.GetSimilarLinks(ctx, untitled.ID, user.ID, 10)
+	if err != nil {
+		t.Fatalf("GetSimilarLinks for an untitled link: %v", err)
+	}
+	if links == nil || len(links) != 0 {
+		t.Errorf("untitled link: got %v, want an empty list", links)
+	}
+
+	if _, err := database.GetSimilarLinks(ctx, source.ID, other.ID, 10); !errors.Is(err, db.ErrLinkNotFound) {
+		t.Errorf("another user's link: err = %v, want ErrLinkNotFound", err)
+	}
+}
+
+func TestLinkNotesRoundTrip(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	notes := "Read the second half; the benchmarks are flawed"
+	link, err := database.CreateLink(ctx, user.ID, models.LinkCreate{URL: "https://example.com/notes", Notes: &notes})
+	if err != nil {
+		t.Fatalf("CreateLink: %v", err)
+	}
+	got, err := database.GetLinkByID(ctx, link.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetLinkByID: %v", err)
+	}
+	if deref(got.Notes) != notes {
+		t.Errorf("created: notes = %s, want %q", deref(got.Notes), notes)
+	}
+
+	edited := "Benchmarks fixed in v2"
+	updated, err := database.UpdateLink(ctx, link.ID, user.ID, models.LinkUpdate{Notes: &edited})
+	if err != nil {
+		t.Fatalf("UpdateLink: %v", err)
+	}
+	if deref(updated.Notes) != edited {
+		t.Errorf("updated: notes = %s, want %q", deref(updated.Notes), edited)
+	}
+
+	updated, err = database.UpdateLink(ctx, link.ID, user.ID, models.LinkUpdate{ClearFields: []string{"notes"}})
+	if err != nil {
+		t.Fatalf("UpdateLink clearing notes: %v", err)
+	}
+	if updated.Notes != nil {
+		t.Errorf("cleared: notes = %s, want nil", deref(updated.Notes))
+	}
+
+	// Batch creation keeps each link's notes
+	batchNotes := "from the batch"
+	created, err := database.CreateLinks(ctx, user.ID, []models.LinkCreate{
+		{URL: "https://example.com/batch-a", Notes: &batchNotes},
+		{URL: "https://example.com/batch-b"},
+	})
+	if err != nil {
+		t.Fatalf("CreateLinks: %v", err)
+	}
+	if len(created) != 2 || deref(created[0].Notes) != batchNotes || created[1].Notes != nil {
+		t.Fatalf("CreateLinks: got %d links, want 2 with notes %q then none", len(created), batchNotes)
+	}
+}
+
+// deref returns *s, or "<nil>" for a nil pointer
+func deref(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
+
+// strPtr returns a pointer to s
+func strPtr(s string) *string {
+	return &s
+}
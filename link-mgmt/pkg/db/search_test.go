@@ -0,0 +1,56 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"cafe", "cafe"},
+		{"50%", `50\%`},
+		{"snake_case", `snake\_case`},
+		{`C:\path`, `C:\\path`},
+	}
+	for _, tt := range tests {
+		if got := escapeLike(tt.in); got != tt.want {
+			t.Errorf("escapeLike(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLinkFilterQuerySearch(t *testing.T) {
+	filter := models.LinkFilter{Search: "  Café_50% "}
+
+	tests := []struct {
+		name     string
+		unaccent bool
+		want     string
+	}{
+		{"with unaccent", true, `unaccent(title) ILIKE unaccent($2) OR unaccent(url) ILIKE unaccent($2) OR unaccent(description) ILIKE unaccent($2)`},
+		{"falls back to ILIKE", false, `title ILIKE $2 OR url ILIKE $2 OR description ILIKE $2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &DB{unaccent: tt.unaccent}
+			query, args := database.linkFilterQuery(`SELECT COUNT(*)`, uuid.New(), filter)
+			if !strings.Contains(query, tt.want) {
+				t.Errorf("query = %q, want it to contain %q", query, tt.want)
+			}
+			if len(args) != 2 || args[1] != `%Café\_50\%%` {
+				t.Errorf("args = %v, want the trimmed, escaped pattern", args)
+			}
+		})
+	}
+
+	query, args := (&DB{unaccent: true}).linkFilterQuery(`SELECT COUNT(*)`, uuid.New(), models.LinkFilter{Search: "   "})
+	if strings.Contains(query, "ILIKE") || len(args) != 1 {
+		t.Errorf("blank search: query = %q, args = %v; want no search clause", query, args)
+	}
+}
@@ -0,0 +1,243 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/models"
+
+	"github.com/google/uuid"
+)
+
+// lastUsedAt reads the stored last_used_at of a primary or additional key
+func lastUsedAt(t *testing.T, database *db.DB, apiKey string) *time.Time {
+	t.Helper()
+
+	var lastUsed *time.Time
+	err := database.Pool.QueryRow(context.Background(),
+		`SELECT last_used_at FROM users WHERE api_key = $1
+		 UNION ALL
+		 SELECT last_used_at FROM api_keys WHERE api_key = $1`,
+		apiKey,
+	).Scan(&lastUsed)
+	if err != nil {
+		t.Fatalf("reading last_used_at: %v", err)
+	}
+	return lastUsed
+}
+
+func TestGetUserByAPIKey(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	readOnlyKey := dbtest.CreateReadOnlyKey(t, database, user.ID)
+
+	got, err := database.GetUserByAPIKey(ctx, user.APIKey)
+	if err != nil {
+		t.Fatalf("primary key: %v", err)
+	}
+	if got.ID != user.ID || got.IsReadOnly {
+		t.Errorf("primary key: got user %s (read-only %v), want %s with full access", got.ID, got.IsReadOnly, user.ID)
+	}
+
+	got, err = database.GetUserByAPIKey(ctx, readOnlyKey)
+	if err != nil {
+		t.Fatalf("read-only key: %v", err)
+	}
+	if got.ID != user.ID || got.APIKey != readOnlyKey || !got.IsReadOnly {
+		t.Errorf("read-only key: got user %s with key %q (read-only %v), want %s with the read-only key", got.ID, got.APIKey, got.IsReadOnly, user.ID)
+	}
+
+	if _, err := database.GetUserByAPIKey(ctx, dbtest.NewAPIKey(t)); !errors.Is(err, db.ErrUserNotFound) {
+		t.Errorf("unknown key: err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestGetUserByAPIKeyExpired(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	readOnlyKey := dbtest.CreateReadOnlyKey(t, database, user.ID)
+	dbtest.Exec(t, database, `UPDATE users SET expires_at = NOW() - interval '1 second' WHERE id = $1`, user.ID)
+	dbtest.Exec(t, database, `UPDATE api_keys SET expires_at = NOW() - interval '1 second' WHERE api_key = $1`, readOnlyKey)
+
+	for name, key := range map[string]string{"primary": user.APIKey, "read-only": readOnlyKey} {
+		_, err := database.GetUserByAPIKey(ctx, key)
+		if !errors.Is(err, db.ErrAPIKeyExpired) {
+			t.Errorf("%s key: err = %v, want ErrAPIKeyExpired", name, err)
+		}
+		if lastUsedAt(t, database, key) != nil {
+			t.Errorf("%s key: an expired key was recorded as used", name)
+		}
+	}
+}
+
+func TestGetUserByAPIKeyRecordsUse(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	readOnlyKey := dbtest.CreateReadOnlyKey(t, database, user.ID)
+
+	for name, key := range map[string]string{"primary": user.APIKey, "read-only": readOnlyKey} {
+		t.Run(name, func(t *testing.T) {
+			table := "users"
+			if key == readOnlyKey {
+				table = "api_keys"
+			}
+
+			// First use is recorded
+			got, err := database.GetUserByAPIKey(ctx, key)
+			if err != nil {
+				t.Fatalf("GetUserByAPIKey: %v", err)
+			}
+			first := lastUsedAt(t, database, key)
+			if first == nil || got.LastUsedAt == nil || !got.LastUsedAt.Equal(*first) {
+				t.Fatalf("after first use: stored %v, returned %v; want both set and equal", first, got.LastUsedAt)
+			}
+
+			// A recent use isn't written again
+			dbtest.Exec(t, database, `UPDATE `+table+` SET last_used_at = NOW() - interval '10 seconds' WHERE api_key = $1`, key)
+			recent := lastUsedAt(t, database, key)
+			if _, err := database.GetUserByAPIKey(ctx, key); err != nil {
+				t.Fatalf("GetUserByAPIKey: %v", err)
+			}
+			if now := lastUsedAt(t, database, key); !now.Equal(*recent) {
+				t.Errorf("used 10s ago: last_used_at changed from %v to %v, want it left alone", recent, now)
+			}
+
+			// A stale one advances
+			dbtest.Exec(t, database, `UPDATE `+table+` SET last_used_at = NOW() - interval '2 minutes' WHERE api_key = $1`, key)
+			stale := lastUsedAt(t, database, key)
+			got, err = database.GetUserByAPIKey(ctx, key)
+			if err != nil {
+				t.Fatalf("GetUserByAPIKey: %v", err)
+			}
+			now := lastUsedAt(t, database, key)
+			if !now.After(*stale) {
+				t.Errorf("used 2m ago: last_used_at = %v, want it advanced past %v", now, stale)
+			}
+			if got.LastUsedAt == nil || !got.LastUsedAt.Equal(*now) {
+				t.Errorf("returned LastUsedAt = %v, want the stored %v", got.LastUsedAt, now)
+			}
+		})
+	}
+}
+
+// countRows counts the rows of table that belong to userID
+func countRows(t *testing.T, database *db.DB, table string, userID uuid.UUID) int {
+	t.Helper()
+
+	var count int
+	err := database.Pool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM `+table+` WHERE user_id = $1`, userID,
+	).Scan(&count)
+	if err != nil {
+		t.Fatalf("counting %s: %v", table, err)
+	}
+	return count
+}
+
+func TestDeleteUser(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	readOnlyKey := dbtest.CreateReadOnlyKey(t, database, user.ID)
+	dbtest.CreateLink(t, database, user.ID, "https://example.com/1", nil)
+	if _, _, err := database.CreateLinkIdempotent(ctx, user.ID, "retry-1", models.LinkCreate{URL: "https://example.com/2"}); err != nil {
+		t.Fatalf("CreateLinkIdempotent: %v", err)
+	}
+	other := dbtest.CreateUser(t, database)
+	dbtest.CreateLink(t, database, other.ID, "https://example.com/1", nil)
+
+	if err := database.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	for _, table := range []string{"links", "api_keys", "idempotency_keys"} {
+		if got := countRows(t, database, table, user.ID); got != 0 {
+			t.Errorf("%d %s rows left, want 0", got, table)
+		}
+	}
+	for name, key := range map[string]string{"primary": user.APIKey, "read-only": readOnlyKey} {
+		if _, err := database.GetUserByAPIKey(ctx, key); !errors.Is(err, db.ErrUserNotFound) {
+			t.Errorf("%s key after delete: err = %v, want ErrUserNotFound", name, err)
+		}
+	}
+
+	// Other users are untouched
+	if got := countRows(t, database, "links", other.ID); got != 1 {
+		t.Errorf("other user has %d links, want 1", got)
+	}
+	if _, err := database.GetUserByAPIKey(ctx, other.APIKey); err != nil {
+		t.Errorf("other user's key: %v", err)
+	}
+
+	if err := database.DeleteUser(ctx, user.ID); !errors.Is(err, db.ErrUserNotFound) {
+		t.Errorf("second DeleteUser: err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestLookupUserByAPIKey(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	readOnlyKey := dbtest.CreateReadOnlyKey(t, database, user.ID)
+
+	for name, key := range map[string]string{"primary": user.APIKey, "read-only": readOnlyKey} {
+		got, err := database.LookupUserByAPIKey(ctx, key)
+		if err != nil {
+			t.Fatalf("%s key: %v", name, err)
+		}
+		if got.ID != user.ID || got.IsReadOnly != (key == readOnlyKey) {
+			t.Errorf("%s key: got user %s (read-only %v), want %s", name, got.ID, got.IsReadOnly, user.ID)
+		}
+		if lastUsedAt(t, database, key) != nil {
+			t.Errorf("%s key: a lookup was recorded as a use", name)
+		}
+	}
+
+	if _, err := database.LookupUserByAPIKey(ctx, dbtest.NewAPIKey(t)); !errors.Is(err, db.ErrUserNotFound) {
+		t.Errorf("unknown key: err = %v, want ErrUserNotFound", err)
+	}
+	dbtest.Exec(t, database, `UPDATE api_keys SET expires_at = NOW() - interval '1 second' WHERE api_key = $1`, readOnlyKey)
+	if _, err := database.LookupUserByAPIKey(ctx, readOnlyKey); !errors.Is(err, db.ErrAPIKeyExpired) {
+		t.Errorf("expired key: err = %v, want ErrAPIKeyExpired", err)
+	}
+}
+
+func TestGetUserByEmail(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	got, err := database.GetUserByEmail(ctx, user.Email)
+	if err != nil {
+		t.Fatalf("GetUserByEmail: %v", err)
+	}
+	if got.ID != user.ID {
+		t.Errorf("got user %s, want %s", got.ID, user.ID)
+	}
+
+	if _, err := database.GetUserByEmail(ctx, uuid.NewString()+"@example.com"); !errors.Is(err, db.ErrUserNotFound) {
+		t.Errorf("unknown email: err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestCreateUserDuplicateEmail(t *testing.T) {
+	database := dbtest.New(t)
+
+	user := dbtest.CreateUser(t, database)
+	_, err := database.CreateUser(context.Background(), user.Email, dbtest.NewAPIKey(t), 0)
+	if !errors.Is(err, db.ErrDuplicateEmail) {
+		t.Errorf("err = %v, want ErrDuplicateEmail", err)
+	}
+}
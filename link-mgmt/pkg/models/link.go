@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,8 +16,23 @@ type Link struct {
 	Title       *string   `db:"title" json:"title,omitempty"`
 	Description *string   `db:"description" json:"description,omitempty"`
 	Text        *string   `db:"text" json:"text,omitempty"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	Notes       *string   `db:"notes" json:"notes,omitempty"` // The user's own annotations; scraping never sets them
+	IsFavorite  bool      `db:"is_favorite" json:"is_favorite"`
+	IsRead      bool      `db:"is_read" json:"is_read"`
+	Favicon     *string   `db:"favicon" json:"favicon,omitempty"`
+	SiteName    *string   `db:"site_name" json:"site_name,omitempty"`
+	// ImageURL is the page's preview image (og:image); nil if it declares none
+	ImageURL *string `db:"image_url" json:"image_url,omitempty"`
+	// LastScrapedAt is set whenever a scrape of the link succeeds; nil if never scraped
+	LastScrapedAt *time.Time `db:"last_scraped_at" json:"last_scraped_at,omitempty"`
+	// ContentHash fingerprints the last scraped content, to detect unchanged re-scrapes
+	ContentHash *string `db:"content_hash" json:"-"`
+	// Position is the link's place in the user's manual ordering; nil if never reordered
+	Position *int `db:"position" json:"position,omitempty"`
+	// VisitCount is how many times the link was opened (POST .../visit)
+	VisitCount int       `db:"visit_count" json:"visit_count"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
 }
 
 // LinkCreate represents data for creating a new link
@@ -23,12 +41,141 @@ type LinkCreate struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Text        *string `json:"text,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
 }
 
-// LinkUpdate represents data for updating a link
+// Field length limits for created and updated links, counted in characters.
+// The text limit is configurable (api.max_text_length); DefaultMaxTextLength
+// applies when it isn't set.
+const (
+	MaxURLLength         = 2048
+	MaxTitleLength       = 255
+	MaxDescriptionLength = 1000
+	MaxNotesLength       = 10000
+	DefaultMaxTextLength = 100000
+)
+
+// MaxLinkBatchSize is the most links accepted by one batch create request
+const MaxLinkBatchSize = 1000
+
+// MaxIdempotencyKeyLength is the longest Idempotency-Key header accepted
+const MaxIdempotencyKeyLength = 255
+
+// LinkBatchResult is the outcome of one item of a batch create, in request order.
+// Exactly one of Link and Error is set.
+type LinkBatchResult struct {
+	Index int    `json:"index"`
+	Link  *Link  `json:"link,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// LinkUpdate represents data for updating a link. Nil fields are left
+// unchanged; fields named in ClearFields are set to NULL.
 type LinkUpdate struct {
-	URL         *string `json:"url,omitempty"`
-	Title       *string `json:"title,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Text        *string `json:"text,omitempty"`
+	URL         *string  `json:"url,omitempty"`
+	Title       *string  `json:"title,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Text        *string  `json:"text,omitempty"`
+	Notes       *string  `json:"notes,omitempty"`
+	IsFavorite  *bool    `json:"is_favorite,omitempty"`
+	IsRead      *bool    `json:"is_read,omitempty"`
+	Favicon     *string  `json:"favicon,omitempty"`
+	SiteName    *string  `json:"site_name,omitempty"`
+	ImageURL    *string  `json:"image_url,omitempty"`
+	ClearFields []string `json:"clear_fields,omitempty"` // any of LinkClearableFields
+}
+
+// LinkClearableFields are the optional columns a LinkUpdate may clear to NULL
+var LinkClearableFields = []string{"title", "description", "text", "notes", "favicon", "site_name", "image_url"}
+
+// Validate checks ClearFields against LinkClearableFields and rejects
+// clearing a field that the same update also sets
+func (u LinkUpdate) Validate() error {
+	set := map[string]bool{
+		"title":       u.Title != nil,
+		"description": u.Description != nil,
+		"text":        u.Text != nil,
+		"notes":       u.Notes != nil,
+		"favicon":     u.Favicon != nil,
+		"site_name":   u.SiteName != nil,
+		"image_url":   u.ImageURL != nil,
+	}
+	for _, field := range u.ClearFields {
+		if !slices.Contains(LinkClearableFields, field) {
+			return fmt.Errorf("invalid clear field: %q (expected one of %s)", field, strings.Join(LinkClearableFields, ", "))
+		}
+		if set[field] {
+			return fmt.Errorf("field %q cannot be both set and cleared", field)
+		}
+	}
+	return nil
+}
+
+// LinkSortFields are the columns links may be sorted by. Sorting by position
+// follows the manual ordering (ascending unless desc is asked for), with
+// links that were never reordered after the rest, newest first. Sorting by
+// visit_count puts the most opened links first unless asc is asked for.
+var LinkSortFields = []string{"created_at", "updated_at", "title", "url", "position", "visit_count"}
+
+// MaxListLimit is the largest page size accepted by the list API
+const MaxListLimit = 1000
+
+// ListOptions controls the ordering and paging of a list query. Empty values
+// mean created_at, newest first, all links.
+type ListOptions struct {
+	SortBy string `form:"sort"`   // one of LinkSortFields
+	Order  string `form:"order"`  // asc or desc
+	Limit  int    `form:"limit"`  // max links to return; 0 means no limit
+	Offset int    `form:"offset"` // links to skip before the first returned
+}
+
+// Paginated reports whether the options select a page rather than all links
+func (o ListOptions) Paginated() bool {
+	return o.Limit > 0 || o.Offset > 0
+}
+
+// Validate checks SortBy and Order against their allowlists and the paging
+// bounds. SortBy is interpolated into SQL, so it must never be used without
+// validation.
+func (o ListOptions) Validate() error {
+	if o.SortBy != "" && !slices.Contains(LinkSortFields, o.SortBy) {
+		return fmt.Errorf("invalid sort field: %q (expected one of %s)", o.SortBy, strings.Join(LinkSortFields, ", "))
+	}
+	if o.Limit < 0 || o.Limit > MaxListLimit {
+		return fmt.Errorf("invalid limit: %d (expected 0 to %d)", o.Limit, MaxListLimit)
+	}
+	if o.Offset < 0 {
+		return fmt.Errorf("invalid offset: %d (must not be negative)", o.Offset)
+	}
+	switch strings.ToLower(o.Order) {
+	case "", "asc", "desc":
+		return nil
+	default:
+		return fmt.Errorf("invalid sort order: %q (expected asc or desc)", o.Order)
+	}
+}
+
+// LinkFilterDateLayout is the date format accepted by the created_after and
+// created_before filters (YYYY-MM-DD, interpreted as midnight UTC)
+const LinkFilterDateLayout = "2006-01-02"
+
+// LinkFilter narrows the set of links returned by a list query
+type LinkFilter struct {
+	FavoritesOnly bool `form:"favorites"`
+	// UnreadOnly keeps links not yet marked as read
+	UnreadOnly bool `form:"unread"`
+	// UntitledOnly keeps links whose title is null or blank (e.g. not yet scraped)
+	UntitledOnly bool `form:"untitled"`
+	// CreatedAfter keeps links created on or after this date (inclusive)
+	CreatedAfter *time.Time `form:"created_after" time_format:"2006-01-02" time_utc:"1"`
+	// CreatedBefore keeps links created before this date (exclusive)
+	CreatedBefore *time.Time `form:"created_before" time_format:"2006-01-02" time_utc:"1"`
+	// CreatedWithinDays keeps links created in the last this many days, by the
+	// database clock; set by GET /links/recent rather than a query parameter
+	CreatedWithinDays *int `form:"-"`
+	// StaleDays keeps links never scraped or last scraped more than this many days ago
+	StaleDays *int `form:"stale_days"`
+	// Search keeps links whose title, URL, or description contains this text,
+	// ignoring case and, where the database supports it, accents
+	Search string `form:"q"`
 }
@@ -0,0 +1,60 @@
+package models
+
+import "testing"
+
+func TestListOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ListOptions
+		wantErr bool
+	}{
+		{"defaults", ListOptions{}, false},
+		{"title asc", ListOptions{SortBy: "title", Order: "asc"}, false},
+		{"order is case-insensitive", ListOptions{SortBy: "url", Order: "DESC"}, false},
+		{"every sort field", ListOptions{SortBy: "visit_count"}, false},
+		{"largest page", ListOptions{Limit: MaxListLimit, Offset: 10}, false},
+		{"unknown field", ListOptions{SortBy: "email"}, true},
+		{"field case matters", ListOptions{SortBy: "Title"}, true},
+		{"injected expression", ListOptions{SortBy: "title; DROP TABLE links"}, true},
+		{"injected subquery", ListOptions{SortBy: "(SELECT api_key FROM users LIMIT 1)"}, true},
+		{"injected order", ListOptions{SortBy: "title", Order: "asc, (SELECT 1)"}, true},
+		{"unknown order", ListOptions{Order: "up"}, true},
+		{"negative limit", ListOptions{Limit: -1}, true},
+		{"limit too large", ListOptions{Limit: MaxListLimit + 1}, true},
+		{"negative offset", ListOptions{Offset: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%+v) = %v, wantErr %v", tt.opts, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLinkUpdateValidate(t *testing.T) {
+	title := "Title"
+	tests := []struct {
+		name    string
+		update  LinkUpdate
+		wantErr bool
+	}{
+		{"nothing", LinkUpdate{}, false},
+		{"clear title and description", LinkUpdate{ClearFields: []string{"title", "description"}}, false},
+		{"every clearable field", LinkUpdate{ClearFields: LinkClearableFields}, false},
+		{"set one field, clear another", LinkUpdate{Title: &title, ClearFields: []string{"description"}}, false},
+		{"set and clear the same field", LinkUpdate{Title: &title, ClearFields: []string{"title"}}, true},
+		{"required column", LinkUpdate{ClearFields: []string{"url"}}, true},
+		{"unknown column", LinkUpdate{ClearFields: []string{"user_id"}}, true},
+		{"injected expression", LinkUpdate{ClearFields: []string{"title = NULL, user_id"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.update.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%+v) = %v, wantErr %v", tt.update, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,9 +7,23 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID `db:"id" json:"id"`
-	Email     string    `db:"email" json:"email"`
-	APIKey    string    `db:"api_key" json:"api_key"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	ID         uuid.UUID  `db:"id" json:"id"`
+	Email      string     `db:"email" json:"email"`
+	APIKey     string     `db:"api_key" json:"api_key"`
+	ExpiresAt  *time.Time `db:"expires_at" json:"expires_at,omitempty"`     // nil = never expires
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at,omitempty"` // last successful authentication, to the minute
+	IsReadOnly bool       `db:"-" json:"is_readonly,omitempty"`             // authenticated with a read-only key
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// APIKey is an additional API key for a user, such as a read-only key for
+// integrations. The primary key is User.APIKey.
+type APIKey struct {
+	ID         uuid.UUID  `db:"id" json:"id"`
+	UserID     uuid.UUID  `db:"user_id" json:"user_id"`
+	APIKey     string     `db:"api_key" json:"api_key"`
+	IsReadOnly bool       `db:"is_readonly" json:"is_readonly"`
+	ExpiresAt  *time.Time `db:"expires_at" json:"expires_at,omitempty"` // nil = never expires
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
 }
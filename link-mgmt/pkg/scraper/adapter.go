@@ -0,0 +1,107 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResponseAdapter maps a scraper backend's response body into the common
+// ScrapeResponse, so ScraperService can target services with different JSON
+// shapes. Adapters are selected by name with the scraper.adapter config key.
+type ResponseAdapter interface {
+	// Name is the adapter's config name
+	Name() string
+	// Parse decodes a response body for the requested URL. A failed scrape
+	// reported by the service is returned as a ScrapeResponse with Success
+	// false; an error means the body couldn't be decoded.
+	Parse(body []byte, url string) (*ScrapeResponse, error)
+}
+
+// DefaultAdapterName selects the adapter for the bundled scraper service
+const DefaultAdapterName = "default"
+
+// adapters holds the available adapters by name
+var adapters = map[string]ResponseAdapter{
+	DefaultAdapterName: defaultAdapter{},
+	"readability":      readabilityAdapter{},
+}
+
+// AdapterByName returns the adapter for a scraper.adapter config value; an
+// empty name selects the default adapter
+func AdapterByName(name string) (ResponseAdapter, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultAdapterName
+	}
+	adapter, ok := adapters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown scraper adapter %q (expected one of: %s)", name, strings.Join(AdapterNames(), ", "))
+	}
+	return adapter, nil
+}
+
+// AdapterNames lists the available adapter names, sorted
+func AdapterNames() []string {
+	names := make([]string, 0, len(adapters))
+	for name := range adapters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// defaultAdapter reads the bundled scraper service's format, which is
+// ScrapeResponse itself
+type defaultAdapter struct{}
+
+func (defaultAdapter) Name() string { return DefaultAdapterName }
+
+func (defaultAdapter) Parse(body []byte, url string) (*ScrapeResponse, error) {
+	var result ScrapeResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// readabilityAdapter reads services that return Mozilla Readability's
+// article object (title, excerpt, textContent, siteName, ...), with an
+// "error" field on failure
+type readabilityAdapter struct{}
+
+// readabilityArticle is the subset of a Readability article that we use
+type readabilityArticle struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Excerpt     string `json:"excerpt"`
+	TextContent string `json:"textContent"`
+	SiteName    string `json:"siteName"`
+	Favicon     string `json:"favicon"`
+	Error       string `json:"error"`
+}
+
+func (readabilityAdapter) Name() string { return "readability" }
+
+func (readabilityAdapter) Parse(body []byte, url string) (*ScrapeResponse, error) {
+	var article readabilityArticle
+	if err := json.Unmarshal(body, &article); err != nil {
+		return nil, err
+	}
+
+	result := &ScrapeResponse{
+		Success:     article.Error == "",
+		URL:         article.URL,
+		Title:       strings.TrimSpace(article.Title),
+		Text:        strings.TrimSpace(article.TextContent),
+		Description: strings.TrimSpace(article.Excerpt),
+		Favicon:     article.Favicon,
+		SiteName:    strings.TrimSpace(article.SiteName),
+		Error:       article.Error,
+	}
+	if result.URL == "" {
+		result.URL = url
+	}
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// sameArticle is one scraped page in each adapter's JSON shape
+var sameArticle = map[string]string{
+	DefaultAdapterName: `{
+		"success": true,
+		"url": "https://example.com/article",
+		"title": "An Article",
+		"text": "The body text.",
+		"description": "A short summary",
+		"favicon": "https://example.com/favicon.ico",
+		"site_name": "Example"
+	}`,
+	"readability": `{
+		"url": "https://example.com/article",
+		"title": "  An Article ",
+		"textContent": "\nThe body text.\n",
+		"excerpt": "A short summary",
+		"favicon": "https://example.com/favicon.ico",
+		"siteName": "Example",
+		"byline": "ignored"
+	}`,
+}
+
+func TestAdaptersProduceSameResponse(t *testing.T) {
+	want := &ScrapeResponse{
+		Success:     true,
+		URL:         "https://example.com/article",
+		Title:       "An Article",
+		Text:        "The body text.",
+		Description: "A short summary",
+		Favicon:     "https://example.com/favicon.ico",
+		SiteName:    "Example",
+	}
+
+	for _, name := range AdapterNames() {
+		t.Run(name, func(t *testing.T) {
+			adapter, err := AdapterByName(name)
+			if err != nil {
+				t.Fatalf("AdapterByName(%q): %v", name, err)
+			}
+			body, ok := sameArticle[name]
+			if !ok {
+				t.Fatalf("no test body for adapter %q", name)
+			}
+
+			got, err := adapter.Parse([]byte(body), "https://example.com/article")
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Parse = %+v, want %+v", got, want)
+			}
+
+			// and the same through the service
+			service := newTestService(t, respondJSON(http.StatusOK, body))
+			service.SetAdapter(adapter)
+			scraped, err := service.ScrapeWithContext(context.Background(), "https://example.com/article", 0)
+			if err != nil {
+				t.Fatalf("ScrapeWithContext: %v", err)
+			}
+			if !reflect.DeepEqual(scraped, want) {
+				t.Errorf("ScrapeWithContext = %+v, want %+v", scraped, want)
+			}
+		})
+	}
+}
+
+func TestReadabilityAdapterFailure(t *testing.T) {
+	adapter, _ := AdapterByName("readability")
+	got, err := adapter.Parse([]byte(`{"error": "page not found"}`), "https://example.com/missing")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got.Success || got.Error != "page not found" || got.URL != "https://example.com/missing" {
+		t.Errorf("Parse = %+v, want a failed scrape of the requested URL", got)
+	}
+
+	if _, err := adapter.Parse([]byte(`<html>`), "https://example.com"); err == nil {
+		t.Error("Parse of a non-JSON body succeeded, want an error")
+	}
+}
+
+func TestAdapterByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"", DefaultAdapterName, false},
+		{"default", DefaultAdapterName, false},
+		{" Readability ", "readability", false},
+		{"mercury", "", true},
+	}
+	for _, tt := range tests {
+		adapter, err := AdapterByName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("AdapterByName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && adapter.Name() != tt.want {
+			t.Errorf("AdapterByName(%q) = %q, want %q", tt.name, adapter.Name(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package scraper
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// scrapeCache is an in-memory TTL cache of successful scrape results keyed by URL
+type scrapeCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	result  ScrapeResponse
+	expires time.Time
+}
+
+func newScrapeCache(ttl time.Duration) *scrapeCache {
+	return &scrapeCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get returns a copy of the cached result for url, if present and not expired
+func (c *scrapeCache) get(url string) (*ScrapeResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, url)
+		return nil, false
+	}
+	result := entry.result
+	return &result, true
+}
+
+// put stores a successful result; failures are never cached
+func (c *scrapeCache) put(url string, result *ScrapeResponse) {
+	if result == nil || !result.Success {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	// Drop expired entries so the cache doesn't grow without bound
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[url] = cacheEntry{result: *result, expires: now.Add(c.ttl)}
+}
+
+func (c *scrapeCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cacheEntry)
+}
+
+type bypassCacheKey struct{}
+
+// BypassCache returns a context that makes scrape calls skip the result cache
+// and always hit the scraper service. The fresh result still refreshes the cache.
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(bool)
+	return bypass
+}
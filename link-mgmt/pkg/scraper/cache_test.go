@@ -0,0 +1,155 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingHandler wraps handler, counting the requests it serves in hits
+func countingHandler(hits *atomic.Int32, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		handler(w, r)
+	}
+}
+
+const cachedPage = `{"success": true, "url": "https://example.com/a", "title": "A"}`
+
+func TestScrapeCacheHit(t *testing.T) {
+	var hits atomic.Int32
+	service := newTestService(t, countingHandler(&hits, respondJSON(http.StatusOK, cachedPage)))
+	service.EnableCache(time.Minute)
+	ctx := context.Background()
+
+	first, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0)
+	if err != nil {
+		t.Fatalf("first scrape: %v", err)
+	}
+
+	var stages []ScrapeStage
+	second, err := service.ScrapeWithProgress(ctx, "https://example.com/a", 0, func(stage ScrapeStage, message string) {
+		stages = append(stages, stage)
+	})
+	if err != nil {
+		t.Fatalf("second scrape: %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("scraper service hit %d times, want 1", got)
+	}
+	if second.Title != first.Title {
+		t.Errorf("cached title = %q, want %q", second.Title, first.Title)
+	}
+	if len(stages) != 1 || stages[0] != StageComplete {
+		t.Errorf("cached scrape reported stages %v, want only %q", stages, StageComplete)
+	}
+
+	// The cache hands out copies, so a caller can't change what it holds
+	second.Title = "changed"
+	third, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0)
+	if err != nil {
+		t.Fatalf("third scrape: %v", err)
+	}
+	if third.Title != "A" {
+		t.Errorf("cached title = %q after a caller changed its copy, want %q", third.Title, "A")
+	}
+
+	// Another URL isn't served from the cache
+	if _, err := service.ScrapeWithContext(ctx, "https://example.com/b", 0); err != nil {
+		t.Fatalf("scrape of another URL: %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("scraper service hit %d times, want 2", got)
+	}
+}
+
+func TestScrapeCacheMisses(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		var hits atomic.Int32
+		service := newTestService(t, countingHandler(&hits, respondJSON(http.StatusOK, cachedPage)))
+		for range 2 {
+			if _, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := hits.Load(); got != 2 {
+			t.Errorf("scraper service hit %d times, want 2", got)
+		}
+	})
+
+	t.Run("bypass", func(t *testing.T) {
+		var hits atomic.Int32
+		service := newTestService(t, countingHandler(&hits, respondJSON(http.StatusOK, cachedPage)))
+		service.EnableCache(time.Minute)
+		for _, ctx := range []context.Context{ctx, BypassCache(ctx)} {
+			if _, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := hits.Load(); got != 2 {
+			t.Errorf("scraper service hit %d times, want 2", got)
+		}
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		var hits atomic.Int32
+		service := newTestService(t, countingHandler(&hits, respondJSON(http.StatusOK, cachedPage)))
+		service.EnableCache(time.Minute)
+		if _, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0); err != nil {
+			t.Fatal(err)
+		}
+		service.ClearCache()
+		if _, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0); err != nil {
+			t.Fatal(err)
+		}
+		if got := hits.Load(); got != 2 {
+			t.Errorf("scraper service hit %d times, want 2", got)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		var hits atomic.Int32
+		service := newTestService(t, countingHandler(&hits, respondJSON(http.StatusOK, cachedPage)))
+		service.EnableCache(time.Millisecond)
+		if _, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+		if _, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0); err != nil {
+			t.Fatal(err)
+		}
+		if got := hits.Load(); got != 2 {
+			t.Errorf("scraper service hit %d times, want 2", got)
+		}
+	})
+
+	t.Run("failures aren't cached", func(t *testing.T) {
+		var hits atomic.Int32
+		service := newTestService(t, countingHandler(&hits, respondJSON(http.StatusOK,
+			`{"success": false, "url": "https://example.com/a", "error": "page not found"}`)))
+		service.EnableCache(time.Minute)
+		for range 2 {
+			if _, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0); err == nil {
+				t.Fatal("failed scrape returned no error")
+			}
+		}
+		if got := hits.Load(); got != 2 {
+			t.Errorf("scraper service hit %d times, want 2", got)
+		}
+	})
+}
+
+func TestScrapeCachePutSkipsFailures(t *testing.T) {
+	cache := newScrapeCache(time.Minute)
+	cache.put("https://example.com/a", &ScrapeResponse{Success: false, Title: "A"})
+	cache.put("https://example.com/b", nil)
+	for _, url := range []string{"https://example.com/a", "https://example.com/b"} {
+		if _, ok := cache.get(url); ok {
+			t.Errorf("get(%q) found an entry, want failures not cached", url)
+		}
+	}
+}
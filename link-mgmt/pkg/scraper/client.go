@@ -4,18 +4,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // ScraperService provides methods to interact with the scraper HTTP service
 type ScraperService struct {
-	baseURL string
-	client  *http.Client
+	baseURL   string
+	client    *http.Client
+	cache     *scrapeCache    // nil when caching is disabled
+	adapter   ResponseAdapter // decodes the service's response format
+	userAgent string          // User-Agent the service fetches pages with
 }
 
+// DefaultUserAgent identifies scrapes when scraper.user_agent is unset. It
+// reads as a browser-compatible agent, since some sites refuse requests from
+// the headless browser's default.
+const DefaultUserAgent = "Mozilla/5.0 (compatible; link-mgmt/1.0)"
+
 // NewScraperService creates a new scraper service client
 func NewScraperService(baseURL string) *ScraperService {
 	return &ScraperService{
@@ -23,6 +35,44 @@ func NewScraperService(baseURL string) *ScraperService {
 		client: &http.Client{
 			Timeout: 60 * time.Second,
 		},
+		adapter:   defaultAdapter{},
+		userAgent: DefaultUserAgent,
+	}
+}
+
+// SetUserAgent sets the User-Agent the scraper service fetches pages with.
+// An empty userAgent restores DefaultUserAgent.
+func (s *ScraperService) SetUserAgent(userAgent string) {
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	s.userAgent = userAgent
+}
+
+// SetAdapter selects how scrape responses are decoded (see AdapterByName).
+// A nil adapter restores the default.
+func (s *ScraperService) SetAdapter(adapter ResponseAdapter) {
+	if adapter == nil {
+		adapter = defaultAdapter{}
+	}
+	s.adapter = adapter
+}
+
+// EnableCache caches successful scrape results per URL for ttl.
+// A ttl of zero or less disables caching.
+func (s *ScraperService) EnableCache(ttl time.Duration) {
+	if ttl <= 0 {
+		s.cache = nil
+		return
+	}
+	s.cache = newScrapeCache(ttl)
+}
+
+// ClearCache removes all cached scrape results
+func (s *ScraperService) ClearCache() {
+	if s.cache != nil {
+		s.cache.clear()
 	}
 }
 
@@ -83,7 +133,8 @@ func (s *ScraperService) CheckHealthWithProgress(ctx context.Context, onProgress
 	return nil
 }
 
-// Scrape scrapes a single URL (backward compatibility wrapper)
+// Scrape scrapes a single URL (backward compatibility wrapper). Like every
+// Scrape* method, it takes timeout in seconds; 0 leaves it to the service.
 func (s *ScraperService) Scrape(url string, timeout int) (*ScrapeResponse, error) {
 	return s.ScrapeWithContext(context.Background(), url, timeout)
 }
@@ -93,8 +144,136 @@ func (s *ScraperService) ScrapeWithContext(ctx context.Context, url string, time
 	return s.ScrapeWithProgress(ctx, url, timeout, nil)
 }
 
-// ScrapeWithProgress scrapes a single URL with context support and progress callbacks
+// ScrapeWithProgress scrapes a single URL with context support and progress callbacks.
+// timeout is in seconds and is sent to the service in milliseconds.
+// When caching is enabled, a recent successful result for the same URL is
+// returned without contacting the service (see BypassCache).
 func (s *ScraperService) ScrapeWithProgress(ctx context.Context, url string, timeout int, onProgress ProgressCallback) (*ScrapeResponse, error) {
+	if s.cache != nil && !cacheBypassed(ctx) {
+		if cached, ok := s.cache.get(url); ok {
+			if onProgress != nil {
+				onProgress(StageComplete, "Using cached scrape result")
+			}
+			return cached, nil
+		}
+	}
+
+	result, err := s.scrapeWithRetry(ctx, url, timeout, onProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache != nil {
+		s.cache.put(url, result)
+	}
+	return result, nil
+}
+
+// throttleRetries is how many more times a scrape is tried while the service
+// answers 429 Too Many Requests or 503 Service Unavailable
+const throttleRetries = 2
+
+// retryBaseDelay is the wait before the first retry when the service sends no
+// Retry-After; it doubles on each retry after that. Tests shorten it.
+var retryBaseDelay = time.Second
+
+// maxRetryWait caps the wait between retries, whatever Retry-After asks for
+const maxRetryWait = 10 * time.Second
+
+// MaxScrapeDuration returns the longest ScrapeWithProgress may take with a
+// timeout of timeoutSeconds: the first try and each throttled retry, plus
+// the waits between them
+func MaxScrapeDuration(timeoutSeconds int) time.Duration {
+	return time.Duration(throttleRetries+1)*time.Duration(timeoutSeconds)*time.Second + throttleRetries*maxRetryWait
+}
+
+// scrapeWithRetry scrapes url, trying again up to throttleRetries times while
+// the service is throttling or overloaded. A retry that couldn't start before
+// ctx's deadline isn't attempted; the last error is returned instead.
+func (s *ScraperService) scrapeWithRetry(ctx context.Context, url string, timeout int, onProgress ProgressCallback) (*ScrapeResponse, error) {
+	for attempt := 0; ; attempt++ {
+		result, err := s.scrape(ctx, url, timeout, onProgress)
+		var scraperErr *ScraperError
+		if err == nil || attempt >= throttleRetries || !errors.As(err, &scraperErr) || !scraperErr.throttled {
+			return result, err
+		}
+
+		delay := retryDelay(scraperErr.RetryAfter, attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err
+		}
+		if onProgress != nil {
+			onProgress(StageFetching, fmt.Sprintf("Scraper busy; retrying in %s...", delay))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, newTimeoutError(ctx.Err())
+			}
+			return nil, newCancelledError(ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retry number attempt+1: what
+// Retry-After asked for, or an exponential backoff without it, capped at
+// maxRetryWait
+func retryDelay(retryAfter time.Duration, attempt int) time.Duration {
+	delay := retryAfter
+	if delay <= 0 {
+		delay = retryBaseDelay << attempt
+	}
+	return min(delay, maxRetryWait)
+}
+
+// isThrottled reports whether a status means the service itself is rate
+// limiting or overloaded, rather than failing to scrape the page
+func isThrottled(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// markThrottled flags err for retrying if resp is a 429 or 503, recording its
+// Retry-After
+func markThrottled(err *ScraperError, resp *http.Response) *ScraperError {
+	if isThrottled(resp.StatusCode) {
+		err.throttled = true
+		err.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	return err
+}
+
+// parseRetryAfter reads a Retry-After header, either delay-seconds or an
+// HTTP date, as a duration from now. It returns 0 if the header is missing,
+// malformed, or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(0, time.Duration(seconds)*time.Second)
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(0, t.Sub(now))
+	}
+	return 0
+}
+
+// timeoutMillis converts a timeout in seconds to the milliseconds the scraper
+// service expects; 0 or less is left unset so the service uses its default
+func timeoutMillis(timeoutSeconds int) int {
+	if timeoutSeconds <= 0 {
+		return 0
+	}
+	return timeoutSeconds * 1000
+}
+
+// scrape sends the scrape request to the service
+func (s *ScraperService) scrape(ctx context.Context, url string, timeout int, onProgress ProgressCallback) (*ScrapeResponse, error) {
 	// Stage 1: Health check (optional, but good practice)
 	if onProgress != nil {
 		onProgress(StageHealthCheck, "Checking scraper service...")
@@ -110,14 +289,10 @@ func (s *ScraperService) ScrapeWithProgress(ctx context.Context, url string, tim
 	// The scraper service expects timeout in milliseconds, whereas our public
 	// API and configuration use seconds. Convert here to keep the external
 	// interface intuitive while matching the service contract.
-	timeoutMillis := 0
-	if timeout > 0 {
-		timeoutMillis = timeout * 1000
-	}
-
 	reqBody := ScrapeRequest{
-		URL:     url,
-		Timeout: timeoutMillis,
+		URL:       url,
+		Timeout:   timeoutMillis(timeout),
+		UserAgent: s.userAgent,
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -155,10 +330,11 @@ func (s *ScraperService) ScrapeWithProgress(ctx context.Context, url string, tim
 	}
 
 	// Parse response regardless of status code to get error categorization
-	var result ScrapeResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, newInvalidResponseError("failed to decode response", err)
+	parsed, err := s.adapter.Parse(body, url)
+	if err != nil {
+		return nil, markThrottled(s.unparsedResponseError(resp, body, err), resp)
 	}
+	result := *parsed
 
 	// If the response indicates failure, create a categorized error
 	if !result.Success || resp.StatusCode != http.StatusOK {
@@ -167,26 +343,17 @@ func (s *ScraperService) ScrapeWithProgress(ctx context.Context, url string, tim
 			errorMsg = "Failed to scrape URL"
 		}
 
-		// Use error type from response if available, otherwise infer from status code
+		// Use error type from response if available, otherwise infer from
+		// status code. A 429 or 503 is the service throttling or overloaded,
+		// which is worth retrying whatever the response says about the page.
 		var errorType ErrorType
-		if result.ErrorType != "" {
+		switch {
+		case isThrottled(resp.StatusCode):
+			errorType = errorTypeForStatus(resp.StatusCode)
+		case result.ErrorType != "":
 			errorType = MapErrorTypeFromString(result.ErrorType)
-		} else {
-			// Infer error type from HTTP status code
-			switch resp.StatusCode {
-			case http.StatusGatewayTimeout, http.StatusRequestTimeout:
-				errorType = ErrorTypeTimeout
-			case http.StatusServiceUnavailable, http.StatusBadGateway:
-				errorType = ErrorTypeServiceUnavailable
-			case http.StatusBadRequest:
-				errorType = ErrorTypeInvalidURL
-			case http.StatusTooManyRequests:
-				errorType = ErrorTypeRateLimit
-			case http.StatusForbidden:
-				errorType = ErrorTypeBlocked
-			default:
-				errorType = ErrorTypeExtraction
-			}
+		default:
+			errorType = errorTypeForStatus(resp.StatusCode)
 		}
 
 		// Create the appropriate error based on type
@@ -194,11 +361,16 @@ func (s *ScraperService) ScrapeWithProgress(ctx context.Context, url string, tim
 		scraperErr := NewScraperErrorFromType(errorType, errorMsg, cause)
 
 		// Set retryable flag from response if provided
-		if result.Retryable != nil {
+		if result.Retryable != nil && !isThrottled(resp.StatusCode) {
 			scraperErr.SetRetryable(*result.Retryable)
 		}
 
-		return nil, scraperErr
+		return nil, markThrottled(scraperErr, resp)
+	}
+
+	// Older scraper versions don't return site metadata; fall back to the host
+	if result.SiteName == "" {
+		result.SiteName = SiteNameFromURL(url)
 	}
 
 	// Stage 4: Complete
@@ -208,3 +380,68 @@ func (s *ScraperService) ScrapeWithProgress(ctx context.Context, url string, tim
 
 	return &result, nil
 }
+
+// errorTypeForStatus infers an error type from the HTTP status of a failed
+// scrape whose response doesn't say
+func errorTypeForStatus(status int) ErrorType {
+	switch status {
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return ErrorTypeTimeout
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return ErrorTypeServiceUnavailable
+	case http.StatusBadRequest:
+		return ErrorTypeInvalidURL
+	case http.StatusTooManyRequests:
+		return ErrorTypeRateLimit
+	case http.StatusForbidden:
+		return ErrorTypeBlocked
+	default:
+		return ErrorTypeExtraction
+	}
+}
+
+// maxErrorBodyLength bounds how much of an unparseable response body is
+// quoted in an error
+const maxErrorBodyLength = 200
+
+// unparsedResponseError describes a response the adapter couldn't decode
+// without dumping the body into the message. An HTML page (typically the
+// proxy's error page while the service is down) or a server error means the
+// service is unavailable; any other body is quoted, truncated.
+func (s *ScraperService) unparsedResponseError(resp *http.Response, body []byte, cause error) *ScraperError {
+	if isHTMLResponse(resp, body) {
+		return newServiceUnavailableError(fmt.Errorf("got an HTML page with status %d instead of a scrape result", resp.StatusCode))
+	}
+	if resp.StatusCode != http.StatusOK {
+		message := truncateBody(body)
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		statusErr := fmt.Errorf("status %d: %s", resp.StatusCode, message)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return newServiceUnavailableError(statusErr)
+		}
+		return NewScraperErrorFromType(errorTypeForStatus(resp.StatusCode), message, statusErr)
+	}
+	return newInvalidResponseError(fmt.Sprintf("failed to decode %s response: %q", s.adapter.Name(), truncateBody(body)), cause)
+}
+
+// isHTMLResponse reports whether a response is an HTML page, going by its
+// Content-Type or, failing that, how the body starts
+func isHTMLResponse(resp *http.Response, body []byte) bool {
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		return true
+	}
+	start := strings.ToLower(string(bytes.TrimSpace(body[:min(len(body), 64)])))
+	return strings.HasPrefix(start, "<!doctype html") || strings.HasPrefix(start, "<html")
+}
+
+// truncateBody renders a response body for an error message: whitespace is
+// collapsed and anything past maxErrorBodyLength characters is cut
+func truncateBody(body []byte) string {
+	text := strings.Join(strings.Fields(strings.ToValidUTF8(string(body), "")), " ")
+	if utf8.RuneCountInString(text) <= maxErrorBodyLength {
+		return text
+	}
+	return string([]rune(text)[:maxErrorBodyLength]) + "…"
+}
@@ -0,0 +1,433 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestService returns a ScraperService talking to a test server that
+// answers every request with handler
+func newTestService(t *testing.T, handler http.HandlerFunc) *ScraperService {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewScraperService(server.URL)
+}
+
+// respondJSON returns a handler answering with status and a JSON body
+func respondJSON(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+func TestScrapeSiteNameFallsBackToHost(t *testing.T) {
+	service := newTestService(t, respondJSON(http.StatusOK,
+		`{"success": true, "url": "https://www.example.com/a", "title": "A"}`))
+
+	result, err := service.ScrapeWithContext(context.Background(), "https://www.example.com/a", 0)
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if result.SiteName != "example.com" {
+		t.Errorf("SiteName = %q, want the host %q", result.SiteName, "example.com")
+	}
+}
+
+func TestScrapeKeepsReturnedSiteMetadata(t *testing.T) {
+	service := newTestService(t, respondJSON(http.StatusOK,
+		`{"success": true, "url": "https://example.com/a", "site_name": "Example News", "favicon": "https://example.com/icon.png"}`))
+
+	result, err := service.ScrapeWithContext(context.Background(), "https://example.com/a", 0)
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if result.SiteName != "Example News" {
+		t.Errorf("SiteName = %q, want %q", result.SiteName, "Example News")
+	}
+	if result.Favicon != "https://example.com/icon.png" {
+		t.Errorf("Favicon = %q, want %q", result.Favicon, "https://example.com/icon.png")
+	}
+}
+
+func TestScrapeDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"returned", `{"success": true, "url": "https://example.com/a", "description": "A short summary"}`, "A short summary"},
+		{"missing", `{"success": true, "url": "https://example.com/a"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t, respondJSON(http.StatusOK, tt.body))
+			result, err := service.ScrapeWithContext(context.Background(), "https://example.com/a", 0)
+			if err != nil {
+				t.Fatalf("Scrape: %v", err)
+			}
+			if result.Description != tt.want {
+				t.Errorf("Description = %q, want %q", result.Description, tt.want)
+			}
+		})
+	}
+}
+
+func TestScrapeErrorResponses(t *testing.T) {
+	nginx502 := `<html>
+<head><title>502 Bad Gateway</title></head>
+<body>
+<center><h1>502 Bad Gateway</h1></center>
+<hr><center>nginx/1.25.3</center>
+</body>
+</html>`
+
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantType    ErrorType
+		wantIn      string // in the error message
+	}{
+		{"HTML gateway page", http.StatusBadGateway, "text/html", nginx502, ErrorTypeServiceUnavailable, "HTML page with status 502"},
+		{"HTML without a content type", http.StatusBadGateway, "", "<!DOCTYPE html><html><body>down</body></html>", ErrorTypeServiceUnavailable, "HTML page"},
+		{"HTML with status 200", http.StatusOK, "text/html; charset=utf-8", "<html><body>login</body></html>", ErrorTypeServiceUnavailable, "HTML page with status 200"},
+		{"JSON error body", http.StatusForbidden, "application/json", `{"success": false, "error": "blocked by robots.txt", "errorType": "blocked"}`, ErrorTypeBlocked, "blocked by robots.txt"},
+		{"plain-text client error", http.StatusBadRequest, "text/plain", "url is required", ErrorTypeInvalidURL, "url is required"},
+		{"plain-text server error", http.StatusInternalServerError, "text/plain", "internal error", ErrorTypeServiceUnavailable, "status 500: internal error"},
+		{"empty error body", http.StatusNotFound, "text/plain", "", ErrorTypeExtraction, "Not Found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := service.ScrapeWithContext(context.Background(), "https://example.com/a", 0)
+			var scraperErr *ScraperError
+			if !errors.As(err, &scraperErr) {
+				t.Fatalf("err = %v, want a ScraperError", err)
+			}
+			if scraperErr.Type != tt.wantType {
+				t.Errorf("Type = %s, want %s", scraperErr.Type, tt.wantType)
+			}
+			if !strings.Contains(err.Error(), tt.wantIn) {
+				t.Errorf("error %q doesn't contain %q", err, tt.wantIn)
+			}
+			if strings.Contains(err.Error(), "<") {
+				t.Errorf("error %q quotes markup", err)
+			}
+		})
+	}
+}
+
+func TestScrapeErrorTruncatesBody(t *testing.T) {
+	body := strings.Repeat("x ", 1000)
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(body))
+	})
+
+	_, err := service.ScrapeWithContext(context.Background(), "https://example.com/a", 0)
+	if err == nil {
+		t.Fatal("err = nil, want an error")
+	}
+	if strings.Contains(err.Error(), strings.Repeat("x ", maxErrorBodyLength)) || !strings.Contains(err.Error(), "…") {
+		t.Errorf("error quotes the whole body: %d characters", len(err.Error()))
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"short", "bad request", "bad request"},
+		{"whitespace collapsed", "  bad\n\n\trequest  ", "bad request"},
+		{"invalid UTF-8 dropped", "bad \xff request", "bad request"},
+		{"at the limit", strings.Repeat("a", maxErrorBodyLength), strings.Repeat("a", maxErrorBodyLength)},
+		{"over the limit", strings.Repeat("a", maxErrorBodyLength+1), strings.Repeat("a", maxErrorBodyLength) + "…"},
+		{"cut by character, not byte", strings.Repeat("é", maxErrorBodyLength+5), strings.Repeat("é", maxErrorBodyLength) + "…"},
+	}
+	for _, tt := range tests {
+		if got := truncateBody([]byte(tt.body)); got != tt.want {
+			t.Errorf("%s: truncateBody = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeSendsTimeoutInMilliseconds(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    int
+	}{
+		{30, 30000},
+		{1, 1000},
+		{0, 0},
+		{-5, 0},
+	}
+	for _, tt := range tests {
+		var sent ScrapeRequest
+		service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/scrape" {
+				if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+					t.Errorf("decoding request: %v", err)
+				}
+			}
+			respondJSON(http.StatusOK, `{"success": true, "url": "https://example.com", "title": "Example"}`)(w, r)
+		})
+
+		if _, err := service.Scrape("https://example.com", tt.seconds); err != nil {
+			t.Fatalf("Scrape(%d): %v", tt.seconds, err)
+		}
+		if sent.Timeout != tt.want {
+			t.Errorf("Scrape(%d) sent timeout %d, want %d", tt.seconds, sent.Timeout, tt.want)
+		}
+	}
+}
+
+func TestScrapeSendsUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent *string // nil leaves the default
+		want      string
+	}{
+		{"default", nil, DefaultUserAgent},
+		{"configured", strPtr("link-mgmt-test/1.0"), "link-mgmt-test/1.0"},
+		{"trimmed", strPtr("  link-mgmt-test/1.0 "), "link-mgmt-test/1.0"},
+		{"empty restores the default", strPtr(""), DefaultUserAgent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]interface{}
+			service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/scrape" {
+					if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+						t.Errorf("decoding request: %v", err)
+					}
+				}
+				respondJSON(http.StatusOK, `{"success": true, "url": "https://example.com"}`)(w, r)
+			})
+			if tt.userAgent != nil {
+				service.SetUserAgent(*tt.userAgent)
+			}
+
+			if _, err := service.Scrape("https://example.com", 0); err != nil {
+				t.Fatalf("Scrape: %v", err)
+			}
+			if got := sent["user_agent"]; got != tt.want {
+				t.Errorf("request user_agent = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// shortenRetryDelay makes retries without a Retry-After wait only
+// milliseconds for the rest of the test
+func shortenRetryDelay(t *testing.T) {
+	t.Helper()
+
+	saved := retryBaseDelay
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = saved })
+}
+
+// throttleFirst returns a handler answering the first n scrapes with status
+// and a JSON error body claiming the failure isn't retryable, then succeeding.
+// It counts the scrapes it serves in hits.
+func throttleFirst(n int32, status int, hits *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= n {
+			respondJSON(status, `{"success": false, "error": "slow down", "errorType": "extraction", "retryable": false}`)(w, r)
+			return
+		}
+		respondJSON(http.StatusOK, `{"success": true, "url": "https://example.com/a", "title": "A"}`)(w, r)
+	}
+}
+
+func TestScrapeThrottledIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantType ErrorType
+	}{
+		{"too many requests", http.StatusTooManyRequests, ErrorTypeRateLimit},
+		{"service unavailable", http.StatusServiceUnavailable, ErrorTypeServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortenRetryDelay(t)
+			var hits atomic.Int32
+			service := newTestService(t, throttleFirst(100, tt.status, &hits))
+
+			_, err := service.ScrapeWithContext(context.Background(), "https://example.com/a", 0)
+			var scraperErr *ScraperError
+			if !errors.As(err, &scraperErr) {
+				t.Fatalf("err = %v, want a ScraperError", err)
+			}
+			if scraperErr.Type != tt.wantType {
+				t.Errorf("Type = %s, want %s, whatever the body says", scraperErr.Type, tt.wantType)
+			}
+			if !scraperErr.IsRetryable() {
+				t.Error("IsRetryable = false, want true despite the body's retryable: false")
+			}
+			if got := hits.Load(); got != 1+throttleRetries {
+				t.Errorf("service was asked %d times, want %d", got, 1+throttleRetries)
+			}
+		})
+	}
+}
+
+func TestScrapeRetriesThrottled(t *testing.T) {
+	shortenRetryDelay(t)
+	var hits atomic.Int32
+	service := newTestService(t, throttleFirst(2, http.StatusTooManyRequests, &hits))
+
+	var retries []string
+	result, err := service.ScrapeWithProgress(context.Background(), "https://example.com/a", 0, func(_ ScrapeStage, message string) {
+		if strings.Contains(message, "retrying") {
+			retries = append(retries, message)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if result.Title != "A" {
+		t.Errorf("Title = %q, want %q", result.Title, "A")
+	}
+	if got := hits.Load(); got != 3 {
+		t.Errorf("service was asked %d times, want 3", got)
+	}
+	if len(retries) != 2 {
+		t.Errorf("got %d retry progress messages, want 2: %v", len(retries), retries)
+	}
+}
+
+func TestScrapeDoesNotRetryOtherErrors(t *testing.T) {
+	shortenRetryDelay(t)
+	var hits atomic.Int32
+	service := newTestService(t, throttleFirst(1, http.StatusBadGateway, &hits))
+
+	if _, err := service.ScrapeWithContext(context.Background(), "https://example.com/a", 0); err == nil {
+		t.Fatal("err = nil, want the 502")
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("service was asked %d times, want 1", got)
+	}
+}
+
+func TestScrapeHonoursRetryAfter(t *testing.T) {
+	var hits atomic.Int32
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "5")
+		respondJSON(http.StatusTooManyRequests, `{"success": false, "error": "slow down"}`)(w, r)
+	})
+
+	// The 5s wait would pass the deadline, so the scrape isn't retried
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := service.ScrapeWithContext(ctx, "https://example.com/a", 0)
+	var scraperErr *ScraperError
+	if !errors.As(err, &scraperErr) || scraperErr.Type != ErrorTypeRateLimit {
+		t.Fatalf("err = %v, want a rate_limit ScraperError", err)
+	}
+	if scraperErr.RetryAfter != 5*time.Second {
+		t.Errorf("RetryAfter = %s, want 5s", scraperErr.RetryAfter)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("service was asked %d times, want 1", got)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("scrape took %s, want it to give up without waiting", elapsed)
+	}
+}
+
+func TestScrapeRetryStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		respondJSON(http.StatusServiceUnavailable, `{"success": false}`)(w, r)
+	})
+
+	_, err := service.ScrapeWithProgress(ctx, "https://example.com/a", 0, func(_ ScrapeStage, message string) {
+		if strings.Contains(message, "retrying") {
+			cancel()
+		}
+	})
+	var scraperErr *ScraperError
+	if !errors.As(err, &scraperErr) || scraperErr.Type != ErrorTypeCancelled {
+		t.Errorf("err = %v, want a cancelled ScraperError", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"0", 0},
+		{"3", 3 * time.Second},
+		{" 120 ", 2 * time.Minute},
+		{"-4", 0},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		retryAfter time.Duration
+		attempt    int
+		want       time.Duration
+	}{
+		{0, 0, time.Second},
+		{0, 1, 2 * time.Second},
+		{0, 5, maxRetryWait},
+		{3 * time.Second, 0, 3 * time.Second},
+		{3 * time.Second, 1, 3 * time.Second},
+		{time.Hour, 0, maxRetryWait},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.retryAfter, tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(%s, %d) = %s, want %s", tt.retryAfter, tt.attempt, got, tt.want)
+		}
+	}
+}
+
+// strPtr returns a pointer to s
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestMaxScrapeDuration(t *testing.T) {
+	// Three tries of 30s, and two waits of at most maxRetryWait between them
+	if got, want := MaxScrapeDuration(30), 90*time.Second+2*maxRetryWait; got != want {
+		t.Errorf("MaxScrapeDuration(30) = %s, want %s", got, want)
+	}
+}
@@ -1,6 +1,9 @@
 package scraper
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ErrorType categorizes different types of scraper errors
 type ErrorType string
@@ -24,8 +27,10 @@ type ScraperError struct {
 	Type         ErrorType
 	Message      string
 	Cause        error
+	RetryAfter   time.Duration // wait the service asked for (Retry-After); 0 if none
 	retryable    bool
 	retryableSet bool // tracks if retryable was explicitly set
+	throttled    bool // the service answered 429 or 503, so the scrape is retried
 }
 
 // Error implements the error interface
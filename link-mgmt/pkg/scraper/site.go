@@ -0,0 +1,17 @@
+package scraper
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SiteNameFromURL derives a display site name from a URL's host,
+// dropping any port and a leading "www.". Returns "" if the URL has no host.
+func SiteNameFromURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(u.Hostname())
+	return strings.TrimPrefix(host, "www.")
+}
@@ -0,0 +1,23 @@
+package scraper
+
+import "testing"
+
+func TestSiteNameFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/page", "example.com"},
+		{"https://www.example.com/page", "example.com"},
+		{"http://WWW.Example.COM:8080/x?y=1", "example.com"},
+		{"https://blog.example.co.uk", "blog.example.co.uk"},
+		{"  https://www.example.org  ", "example.org"},
+		{"not a url", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := SiteNameFromURL(tt.url); got != tt.want {
+			t.Errorf("SiteNameFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
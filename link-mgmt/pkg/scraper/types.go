@@ -3,8 +3,9 @@ package scraper
 // ScrapeRequest represents a request to scrape a URL.
 // NOTE: The Timeout field is expressed in milliseconds to match the scraper service API.
 type ScrapeRequest struct {
-	URL     string `json:"url"`
-	Timeout int    `json:"timeout,omitempty"` // milliseconds
+	URL       string `json:"url"`
+	Timeout   int    `json:"timeout,omitempty"`    // milliseconds
+	UserAgent string `json:"user_agent,omitempty"` // sent to the scraped site
 }
 
 // ScrapeResponse represents the response from a scrape operation
@@ -13,6 +14,10 @@ type ScrapeResponse struct {
 	URL         string `json:"url"`
 	Title       string `json:"title,omitempty"`
 	Text        string `json:"text,omitempty"`
+	Description string `json:"description,omitempty"` // Meta description, if the page declares one
+	Favicon     string `json:"favicon,omitempty"`     // Absolute favicon URL, if the page declares one
+	SiteName    string `json:"site_name,omitempty"`   // Site name; derived from the URL host if not returned
+	ImageURL    string `json:"image_url,omitempty"`   // Absolute preview image URL (og:image), if the page declares one
 	ExtractedAt string `json:"extracted_at,omitempty"`
 	Error       string `json:"error,omitempty"`
 	ErrorType   string `json:"error_type,omitempty"` // Categorized error type from scraper service
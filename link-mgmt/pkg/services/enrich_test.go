@@ -0,0 +1,349 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/scraper"
+
+	"github.com/google/uuid"
+)
+
+func TestContentHash(t *testing.T) {
+	base := scraper.ScrapeResponse{Title: "Title", Description: "About it", Text: "Some body text"}
+	hash := contentHash(&base)
+
+	same := []struct {
+		name   string
+		result scraper.ScrapeResponse
+	}{
+		{"identical", base},
+		{"whitespace differs", scraper.ScrapeResponse{Title: "  Title\n", Description: "About\t it", Text: "Some  body\n\ntext"}},
+		{"unhashed fields differ", scraper.ScrapeResponse{Title: "Title", Description: "About it", Text: "Some body text", URL: "https://example.com/other"}},
+	}
+	for _, tt := range same {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contentHash(&tt.result); got != hash {
+				t.Errorf("contentHash = %s, want %s", got, hash)
+			}
+		})
+	}
+
+	changed := []struct {
+		name   string
+		result scraper.ScrapeResponse
+	}{
+		{"title", scraper.ScrapeResponse{Title: "Other", Description: "About it", Text: "Some body text"}},
+		{"text", scraper.ScrapeResponse{Title: "Title", Description: "About it", Text: "Some other text"}},
+		{"content shifted between fields", scraper.ScrapeResponse{Title: "Title About", Description: "it", Text: "Some body text"}},
+		{"image added", scraper.ScrapeResponse{Title: "Title", Description: "About it", Text: "Some body text", ImageURL: "https://example.com/a.png"}},
+	}
+	for _, tt := range changed {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contentHash(&tt.result); got == hash {
+				t.Errorf("contentHash = %s, want it to differ", got)
+			}
+		})
+	}
+}
+
+func TestEnrichLinkSkipsUnchangedContent(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	title := "First Title"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "url": "https://example.com", "title": title})
+	}))
+	t.Cleanup(server.Close)
+	service := NewLinkService(database, scraper.NewScraperService(server.URL))
+
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/page", nil)
+	opts := ScrapeOptions{Enabled: true, TimeoutSeconds: 5}
+
+	first, changed, err := service.EnrichLink(ctx, link.ID, user.ID, opts)
+	if err != nil {
+		t.Fatalf("first EnrichLink: %v", err)
+	}
+	if !changed || deref(first.Title) != "First Title" {
+		t.Fatalf("first EnrichLink: changed = %v, title = %s; want true, First Title", changed, deref(first.Title))
+	}
+
+	// Identical content leaves the link, and its updated_at, alone
+	again, changed, err := service.EnrichLink(ctx, link.ID, user.ID, opts)
+	if err != nil {
+		t.Fatalf("repeat EnrichLink: %v", err)
+	}
+	if changed {
+		t.Error("re-scraping identical content reported a change")
+	}
+	if !again.UpdatedAt.Equal(first.UpdatedAt) {
+		t.Errorf("updated_at = %v, want it kept at %v", again.UpdatedAt, first.UpdatedAt)
+	}
+	if again.LastScrapedAt == nil || !again.LastScrapedAt.After(*first.LastScrapedAt) {
+		t.Errorf("last_scraped_at = %v, want it advanced past %v", again.LastScrapedAt, first.LastScrapedAt)
+	}
+
+	// Changed content is written
+	mu.Lock()
+	title = "Second Title"
+	mu.Unlock()
+	updated, changed, err := service.EnrichLink(ctx, link.ID, user.ID, opts)
+	if err != nil {
+		t.Fatalf("EnrichLink after change: %v", err)
+	}
+	if !changed || deref(updated.Title) != "Second Title" {
+		t.Errorf("EnrichLink after change: changed = %v, title = %s; want true, Second Title", changed, deref(updated.Title))
+	}
+	if !updated.UpdatedAt.After(first.UpdatedAt) {
+		t.Errorf("updated_at = %v, want it advanced past %v", updated.UpdatedAt, first.UpdatedAt)
+	}
+}
+
+func TestScrapeContextShutdown(t *testing.T) {
+	service := NewLinkService(nil, nil)
+	lifecycle, shutdown := context.WithCancel(context.Background())
+	service.SetLifecycle(lifecycle)
+
+	// A request ending on its own is not a shutdown
+	requestCtx, cancelRequest := context.WithCancel(context.Background())
+	scrapeCtx, cancel := service.scrapeContext(requestCtx)
+	cancelRequest()
+	<-scrapeCtx.Done()
+	if shutdownError(scrapeCtx) {
+		t.Error("cancelled request reported as shutdown")
+	}
+	cancel()
+
+	// Ending the lifecycle aborts scrapes in flight
+	scrapeCtx, cancel = service.scrapeContext(context.Background())
+	defer cancel()
+	shutdown()
+	select {
+	case <-scrapeCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("scrape context not cancelled by shutdown")
+	}
+	if !shutdownError(scrapeCtx) {
+		t.Errorf("cause = %v, want %v", context.Cause(scrapeCtx), ErrShuttingDown)
+	}
+}
+
+// newHangingScraper returns a scraper whose requests block until the client
+// gives up, and a channel that receives once per request started
+func newHangingScraper(t *testing.T) (*scraper.ScraperService, <-chan struct{}) {
+	t.Helper()
+
+	started := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return scraper.NewScraperService(server.URL), started
+}
+
+// shutdownMidScrape runs scrape in the background, ends the service's
+// lifecycle once the scraper has the request, and waits for scrape to return
+func shutdownMidScrape(t *testing.T, service *LinkService, started <-chan struct{}, scrape func()) {
+	t.Helper()
+
+	lifecycle, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+	service.SetLifecycle(lifecycle)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scrape()
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scraper never received the request")
+	}
+	shutdown()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scrape still running after shutdown")
+	}
+}
+
+func TestEnrichLinkAbortedByShutdown(t *testing.T) {
+	database := dbtest.New(t)
+	scraperService, started := newHangingScraper(t)
+	service := NewLinkService(database, scraperService)
+
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/slow", nil)
+
+	var err error
+	shutdownMidScrape(t, service, started, func() {
+		_, _, err = service.EnrichLink(context.Background(), link.ID, user.ID, ScrapeOptions{Enabled: true, TimeoutSeconds: 30})
+	})
+	if !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("EnrichLink error = %v, want %v", err, ErrShuttingDown)
+	}
+}
+
+func TestCreateLinkWithScrapingAbortedByShutdown(t *testing.T) {
+	database := dbtest.New(t)
+	scraperService, started := newHangingScraper(t)
+	service := NewLinkService(database, scraperService)
+	user := dbtest.CreateUser(t, database)
+
+	var link *models.Link
+	var err error
+	shutdownMidScrape(t, service, started, func() {
+		link, err = service.CreateLinkWithScraping(context.Background(), user.ID,
+			models.LinkCreate{URL: "https://example.com/slow"}, ScrapeOptions{Enabled: true, TimeoutSeconds: 30})
+	})
+	if err != nil {
+		t.Fatalf("CreateLinkWithScraping: %v", err)
+	}
+	if link == nil {
+		t.Fatal("CreateLinkWithScraping returned no link")
+	}
+	if link.LastScrapedAt != nil {
+		t.Errorf("last_scraped_at = %v, want unset", link.LastScrapedAt)
+	}
+
+	// The link was saved, unscraped, so the client can enrich it later
+	saved, err := database.GetLinkByID(context.Background(), link.ID, user.ID)
+	if err != nil {
+		t.Fatalf("GetLinkByID: %v", err)
+	}
+	if saved.URL != "https://example.com/slow" {
+		t.Errorf("saved URL = %s, want https://example.com/slow", saved.URL)
+	}
+}
+
+func TestAcquireScrape(t *testing.T) {
+	service := NewLinkService(nil, nil)
+	service.SetMaxConcurrentScrapes(2)
+
+	first, err := service.acquireScrape()
+	if err != nil {
+		t.Fatalf("first slot: %v", err)
+	}
+	if _, err := service.acquireScrape(); err != nil {
+		t.Fatalf("second slot: %v", err)
+	}
+	if _, err := service.acquireScrape(); !errors.Is(err, ErrScrapeBusy) {
+		t.Fatalf("third scrape: err = %v, want %v", err, ErrScrapeBusy)
+	}
+	first()
+	if _, err := service.acquireScrape(); err != nil {
+		t.Errorf("after a release: %v", err)
+	}
+
+	service.SetMaxConcurrentScrapes(0)
+	for i := range 100 {
+		if _, err := service.acquireScrape(); err != nil {
+			t.Fatalf("unlimited, scrape %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestCreateLinkWithScrapingBusy(t *testing.T) {
+	// No database: a busy scraper must be refused before the link is created
+	service := NewLinkService(nil, nil)
+	service.SetMaxConcurrentScrapes(1)
+	release, err := service.acquireScrape()
+	if err != nil {
+		t.Fatalf("acquireScrape: %v", err)
+	}
+	defer release()
+
+	_, err = service.CreateLinkWithScraping(context.Background(), uuid.New(), models.LinkCreate{URL: "https://example.com"}, ScrapeOptions{Enabled: true})
+	if !errors.Is(err, ErrScrapeBusy) {
+		t.Errorf("err = %v, want %v", err, ErrScrapeBusy)
+	}
+}
+
+func TestEnrichLinkConcurrencyLimit(t *testing.T) {
+	database := dbtest.New(t)
+	scraperService, started := newHangingScraper(t)
+	service := NewLinkService(database, scraperService)
+	const limit = 2
+	service.SetMaxConcurrentScrapes(limit)
+
+	user := dbtest.CreateUser(t, database)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/slow", nil)
+	options := ScrapeOptions{Enabled: true, TimeoutSeconds: 30}
+
+	// Fill every slot with a scrape the scraper holds open
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for range limit {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			service.EnrichLink(ctx, link.ID, user.ID, options)
+		}()
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("scraper never received the request")
+		}
+	}
+
+	if _, _, err := service.EnrichLink(context.Background(), link.ID, user.ID, options); !errors.Is(err, ErrScrapeBusy) {
+		t.Errorf("scrape %d: err = %v, want %v", limit+1, err, ErrScrapeBusy)
+	}
+
+	cancel()
+	wg.Wait()
+	if _, err := service.acquireScrape(); err != nil {
+		t.Errorf("slots not released after the scrapes ended: %v", err)
+	}
+}
+
+func TestEnrichLinkKeepsNotes(t *testing.T) {
+	database := dbtest.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true, "url": "https://example.com/page",
+			"title": "Scraped", "description": "Scraped description", "text": "Scraped text",
+		})
+	}))
+	t.Cleanup(server.Close)
+	service := NewLinkService(database, scraper.NewScraperService(server.URL))
+
+	user := dbtest.CreateUser(t, database)
+	notes := "My own notes"
+	link, err := database.CreateLink(ctx, user.ID, models.LinkCreate{URL: "https://example.com/page", Notes: &notes})
+	if err != nil {
+		t.Fatalf("CreateLink: %v", err)
+	}
+
+	// Even a scrape that overwrites every scraped field leaves notes alone
+	enriched, _, err := service.EnrichLink(ctx, link.ID, user.ID, ScrapeOptions{Enabled: true, TimeoutSeconds: 5, OnlyFillEmpty: false})
+	if err != nil {
+		t.Fatalf("EnrichLink: %v", err)
+	}
+	if deref(enriched.Title) != "Scraped" {
+		t.Fatalf("title = %s, want the scraped one", deref(enriched.Title))
+	}
+	if deref(enriched.Notes) != notes {
+		t.Errorf("notes = %s, want %q kept", deref(enriched.Notes), notes)
+	}
+}
@@ -2,8 +2,18 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
 	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
 
 	"link-mgmt/pkg/db"
 	"link-mgmt/pkg/models"
@@ -12,23 +22,186 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrShuttingDown is returned when an in-flight scrape is cancelled because
+// the server is shutting down
+var ErrShuttingDown = errors.New("server shutting down")
+
+// ErrScrapeBusy is returned when the maximum number of scrapes are already
+// running (see SetMaxConcurrentScrapes)
+var ErrScrapeBusy = errors.New("too many scrapes in progress, try again shortly")
+
+// ErrTransferTarget is returned when a transfer's target API key doesn't
+// prove ownership of a writable account
+var ErrTransferTarget = errors.New("target API key is invalid, expired, or read-only")
+
+// ValidationError reports a request field with an invalid value. The API
+// answers it with 400 Bad Request.
+type ValidationError struct {
+	Field   string // JSON name of the field, e.g. "title"
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// verifyTimeout bounds the reachability check made by VerifyURL
+const verifyTimeout = 5 * time.Second
+
+// errDisallowedAddress is returned when VerifyURL would connect to an address
+// on the server's own host or networks
+var errDisallowedAddress = errors.New("address is private, loopback, or link-local")
+
+// UnreachableError reports a URL that failed the reachability check. The API
+// answers it with 422 Unprocessable Entity.
+type UnreachableError struct {
+	StatusCode int   // status the URL answered with; 0 if it didn't answer
+	Err        error // why the request failed, when StatusCode is 0
+}
+
+func (e *UnreachableError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("URL is unreachable: %v", e.Err)
+	}
+	return fmt.Sprintf("URL is unreachable: it returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *UnreachableError) Unwrap() error {
+	return e.Err
+}
+
 // LinkService handles business logic for link operations
 type LinkService struct {
-	db      *db.DB
-	scraper *scraper.ScraperService
+	db        *db.DB
+	scraper   *scraper.ScraperService
+	lifecycle context.Context // cancelled at server shutdown; nil means never
+	maxText   int             // longest text accepted, in characters
+	scrapes   chan struct{}   // one slot per running scrape; nil means unlimited
+	verifier  *http.Client    // makes the requests for VerifyURL
 }
 
 // NewLinkService creates a new link service
 func NewLinkService(db *db.DB, scraperService *scraper.ScraperService) *LinkService {
 	return &LinkService{
-		db:      db,
-		scraper: scraperService,
+		db:       db,
+		scraper:  scraperService,
+		maxText:  models.DefaultMaxTextLength,
+		verifier: newVerifyClient(false),
+	}
+}
+
+// SetMaxTextLength sets the longest text, in characters, a link may be
+// created or updated with. Values below 1 keep the default.
+func (s *LinkService) SetMaxTextLength(n int) {
+	if n > 0 {
+		s.maxText = n
+	}
+}
+
+// SetLifecycle ties scrapes to ctx: once it is cancelled, in-flight scrapes
+// are aborted instead of holding up shutdown. Enriching then returns
+// ErrShuttingDown; creating returns the saved link without scraped content.
+func (s *LinkService) SetLifecycle(ctx context.Context) {
+	s.lifecycle = ctx
+}
+
+// SetMaxConcurrentScrapes limits how many scrapes run at once, so a burst of
+// enrich requests can't overwhelm the scraper service. Scrapes beyond the
+// limit fail fast with ErrScrapeBusy. n < 1 removes the limit.
+func (s *LinkService) SetMaxConcurrentScrapes(n int) {
+	if n < 1 {
+		s.scrapes = nil
+		return
+	}
+	s.scrapes = make(chan struct{}, n)
+}
+
+// acquireScrape claims a scrape slot and returns the func that releases it,
+// or ErrScrapeBusy if every slot is taken
+func (s *LinkService) acquireScrape() (func(), error) {
+	if s.scrapes == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.scrapes <- struct{}{}:
+		return func() { <-s.scrapes }, nil
+	default:
+		return nil, ErrScrapeBusy
+	}
+}
+
+// scrapeContext derives the context for a scrape from the request context,
+// additionally cancelled with cause ErrShuttingDown when the lifecycle ends
+func (s *LinkService) scrapeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	if s.lifecycle == nil {
+		return ctx, func() { cancel(nil) }
+	}
+	stop := context.AfterFunc(s.lifecycle, func() { cancel(ErrShuttingDown) })
+	return ctx, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// shutdownError reports whether the scrape under scrapeCtx was cut short by shutdown
+func shutdownError(scrapeCtx context.Context) bool {
+	return errors.Is(context.Cause(scrapeCtx), ErrShuttingDown)
+}
+
+// validateLinkCreate checks that a new link has a URL and that no field is
+// longer than its limit
+func (s *LinkService) validateLinkCreate(linkCreate models.LinkCreate) error {
+	if strings.TrimSpace(linkCreate.URL) == "" {
+		return &ValidationError{Field: "url", Message: "URL is required"}
+	}
+	return s.checkLengths(&linkCreate.URL, linkCreate.Title, linkCreate.Description, linkCreate.Text, linkCreate.Notes)
+}
+
+// checkLengths rejects the first field longer than its limit. Nil fields
+// aren't being set and are skipped.
+func (s *LinkService) checkLengths(url, title, description, text, notes *string) error {
+	fields := []struct {
+		name  string
+		value *string
+		max   int
+	}{
+		{"url", url, models.MaxURLLength},
+		{"title", title, models.MaxTitleLength},
+		{"description", description, models.MaxDescriptionLength},
+		{"text", text, s.maxText},
+		{"notes", notes, models.MaxNotesLength},
+	}
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+		if n := utf8.RuneCountInString(*field.value); n > field.max {
+			return &ValidationError{
+				Field:   field.name,
+				Message: fmt.Sprintf("%s must be at most %d characters (got %d)", field.name, field.max, n),
+			}
+		}
+	}
+	return nil
+}
+
+// clipRunes shortens s to at most max characters
+func clipRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
 	}
+	return string([]rune(s)[:max])
 }
 
-// ListLinks retrieves all links for a user
-func (s *LinkService) ListLinks(ctx context.Context, userID uuid.UUID) ([]models.Link, error) {
-	return s.db.GetLinksByUserID(ctx, userID)
+// ListLinks retrieves all links for a user matching the filter, ordered by opts
+func (s *LinkService) ListLinks(ctx context.Context, userID uuid.UUID, filter models.LinkFilter, opts models.ListOptions) ([]models.Link, error) {
+	return s.db.GetLinksByUserID(ctx, userID, filter, opts)
+}
+
+// CountLinks counts the user's links matching the filter
+func (s *LinkService) CountLinks(ctx context.Context, userID uuid.UUID, filter models.LinkFilter) (int64, error) {
+	return s.db.CountLinksByUserID(ctx, userID, filter)
 }
 
 // GetLink retrieves a single link by ID
@@ -36,18 +209,151 @@ func (s *LinkService) GetLink(ctx context.Context, linkID, userID uuid.UUID) (*m
 	return s.db.GetLinkByID(ctx, linkID, userID)
 }
 
+// GetSimilarLinks finds the user's other links sharing terms with a link
+func (s *LinkService) GetSimilarLinks(ctx context.Context, linkID, userID uuid.UUID, limit int) ([]models.Link, error) {
+	return s.db.GetSimilarLinks(ctx, linkID, userID, limit)
+}
+
 // CreateLink creates a new link
 func (s *LinkService) CreateLink(ctx context.Context, userID uuid.UUID, linkCreate models.LinkCreate) (*models.Link, error) {
-	// Validation could go here
-	if strings.TrimSpace(linkCreate.URL) == "" {
-		return nil, fmt.Errorf("URL is required")
+	if err := s.validateLinkCreate(linkCreate); err != nil {
+		return nil, err
 	}
 
 	return s.db.CreateLink(ctx, userID, linkCreate)
 }
 
+// CreateLinkIdempotent creates a link under an idempotency key. A repeated
+// key returns the link the first request created, with replayed set; a
+// deleted link is not created again (see db.CreateLinkIdempotent).
+func (s *LinkService) CreateLinkIdempotent(ctx context.Context, userID uuid.UUID, key string, linkCreate models.LinkCreate) (*models.Link, bool, error) {
+	if err := s.validateLinkCreate(linkCreate); err != nil {
+		return nil, false, err
+	}
+
+	return s.db.CreateLinkIdempotent(ctx, userID, key, linkCreate)
+}
+
+// VerifyURL checks that rawURL is reachable before it is saved, to catch
+// typos: a HEAD request must answer with a status below 400. Servers that
+// don't allow HEAD (405 or 501) are retried with GET. Failures are returned
+// as *UnreachableError.
+func (s *LinkService) VerifyURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &ValidationError{Field: "url", Message: "URL must be an absolute http or https URL"}
+	}
+
+	status, err := s.probeURL(ctx, http.MethodHead, parsed.String())
+	if status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented {
+		status, err = s.probeURL(ctx, http.MethodGet, parsed.String())
+	}
+	if err != nil {
+		return &UnreachableError{Err: err}
+	}
+	if status >= http.StatusBadRequest {
+		return &UnreachableError{StatusCode: status}
+	}
+	return nil
+}
+
+// probeURL requests rawURL with method and returns the response status,
+// without reading the body
+func (s *LinkService) probeURL(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", scraper.DefaultUserAgent)
+
+	resp, err := s.verifier.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// newVerifyClient returns the client VerifyURL makes its requests with.
+// Unless allowPrivate is set, it refuses at dial time to connect to private,
+// loopback, link-local, and unspecified addresses, so a URL can't be used to
+// probe the server's own networks, whether directly, through a redirect, or
+// through a name resolving there. Proxies are not used, since they would make
+// the connection on the server's behalf.
+func newVerifyClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: verifyTimeout}
+	if !allowPrivate {
+		dialer.Control = refusePrivateAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   verifyTimeout,
+		Transport: transport,
+	}
+}
+
+// refusePrivateAddress is a net.Dialer Control function that fails with
+// errDisallowedAddress for addresses VerifyURL may not connect to. It runs
+// after name resolution, on the address actually being dialed.
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errDisallowedAddress, address)
+	}
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", errDisallowedAddress, ip)
+	}
+	return nil
+}
+
+// CreateLinks creates many links at once. Invalid items and URLs the user
+// already has are reported per item rather than failing the whole batch.
+func (s *LinkService) CreateLinks(ctx context.Context, userID uuid.UUID, linkCreates []models.LinkCreate) ([]models.LinkBatchResult, error) {
+	results := make([]models.LinkBatchResult, len(linkCreates))
+	var valid []models.LinkCreate
+	var validIndex []int
+	for i, linkCreate := range linkCreates {
+		results[i].Index = i
+		if err := s.validateLinkCreate(linkCreate); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		valid = append(valid, linkCreate)
+		validIndex = append(validIndex, i)
+	}
+
+	if len(valid) == 0 {
+		return results, nil
+	}
+
+	created, err := s.db.CreateLinks(ctx, userID, valid)
+	if err != nil {
+		return nil, err
+	}
+	for j, link := range created {
+		i := validIndex[j]
+		if link == nil {
+			results[i].Error = db.ErrDuplicateLink.Error()
+			continue
+		}
+		results[i].Link = link
+	}
+
+	return results, nil
+}
+
 // UpdateLink updates an existing link
 func (s *LinkService) UpdateLink(ctx context.Context, linkID, userID uuid.UUID, update models.LinkUpdate) (*models.Link, error) {
+	if err := s.checkLengths(update.URL, update.Title, update.Description, update.Text, update.Notes); err != nil {
+		return nil, err
+	}
 	return s.db.UpdateLink(ctx, linkID, userID, update)
 }
 
@@ -56,6 +362,73 @@ func (s *LinkService) DeleteLink(ctx context.Context, linkID, userID uuid.UUID)
 	return s.db.DeleteLink(ctx, linkID, userID)
 }
 
+// ToggleFavorite flips the favorite flag on a link
+func (s *LinkService) ToggleFavorite(ctx context.Context, linkID, userID uuid.UUID) (*models.Link, error) {
+	return s.db.ToggleFavorite(ctx, linkID, userID)
+}
+
+// RecordVisit counts an opening of a link and returns its new visit count
+func (s *LinkService) RecordVisit(ctx context.Context, linkID, userID uuid.UUID) (int, error) {
+	return s.db.RecordVisit(ctx, linkID, userID)
+}
+
+// SetLinkRead marks a link read or unread; a nil read toggles it
+func (s *LinkService) SetLinkRead(ctx context.Context, linkID, userID uuid.UUID, read *bool) (*models.Link, error) {
+	return s.db.SetLinkRead(ctx, linkID, userID, read)
+}
+
+// DeleteLinks deletes multiple links and returns how many were removed
+func (s *LinkService) DeleteLinks(ctx context.Context, linkIDs []uuid.UUID, userID uuid.UUID) (int64, error) {
+	if len(linkIDs) == 0 {
+		return 0, fmt.Errorf("at least one link ID is required")
+	}
+	return s.db.DeleteLinksByIDs(ctx, linkIDs, userID)
+}
+
+// ReorderLinks sets the user's manual link ordering: the given links come
+// first, in order, followed by the rest
+func (s *LinkService) ReorderLinks(ctx context.Context, linkIDs []uuid.UUID, userID uuid.UUID) error {
+	if len(linkIDs) == 0 {
+		return fmt.Errorf("at least one link ID is required")
+	}
+	seen := make(map[uuid.UUID]bool, len(linkIDs))
+	for _, id := range linkIDs {
+		if seen[id] {
+			return fmt.Errorf("duplicate link ID: %s", id)
+		}
+		seen[id] = true
+	}
+	return s.db.ReorderLinks(ctx, userID, linkIDs)
+}
+
+// TransferLinks moves the given links from fromUserID to the account that
+// targetAPIKey belongs to, returning how many moved. Knowing the target key
+// is the proof of owning that account, so it must be a current, writable key.
+func (s *LinkService) TransferLinks(ctx context.Context, linkIDs []uuid.UUID, fromUserID uuid.UUID, targetAPIKey string) (int64, error) {
+	if len(linkIDs) == 0 {
+		return 0, &ValidationError{Field: "ids", Message: "at least one link ID is required"}
+	}
+	if strings.TrimSpace(targetAPIKey) == "" {
+		return 0, &ValidationError{Field: "target_api_key", Message: "target API key is required"}
+	}
+
+	target, err := s.db.LookupUserByAPIKey(ctx, strings.TrimSpace(targetAPIKey))
+	if errors.Is(err, db.ErrUserNotFound) || errors.Is(err, db.ErrAPIKeyExpired) {
+		return 0, ErrTransferTarget
+	}
+	if err != nil {
+		return 0, err
+	}
+	if target.IsReadOnly {
+		return 0, ErrTransferTarget
+	}
+	if target.ID == fromUserID {
+		return 0, &ValidationError{Field: "target_api_key", Message: "target API key belongs to the same account"}
+	}
+
+	return s.db.TransferLinks(ctx, linkIDs, fromUserID, target.ID)
+}
+
 // CreateLinkWithScraping creates a link and enriches it with scraped content
 // This is the key method that moves orchestration from CLI to API
 func (s *LinkService) CreateLinkWithScraping(
@@ -64,6 +437,16 @@ func (s *LinkService) CreateLinkWithScraping(
 	linkCreate models.LinkCreate,
 	scrapeOptions ScrapeOptions,
 ) (*models.Link, error) {
+	// Claim a scrape slot up front, so a busy scraper doesn't leave the link
+	// created but never enriched
+	if scrapeOptions.Enabled {
+		release, err := s.acquireScrape()
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
 	// Step 1: Create the link first (even if scraping fails, we have the link)
 	link, err := s.CreateLink(ctx, userID, linkCreate)
 	if err != nil {
@@ -75,43 +458,18 @@ func (s *LinkService) CreateLinkWithScraping(
 		return link, nil
 	}
 
-	scrapeResult, err := s.scraper.ScrapeWithContext(ctx, linkCreate.URL, scrapeOptions.TimeoutSeconds)
+	scrapeCtx, cancel := s.scrapeContext(ctx)
+	defer cancel()
+	scrapeResult, err := s.scraper.ScrapeWithContext(scrapeCtx, linkCreate.URL, scrapeOptions.TimeoutSeconds)
 	if err != nil {
-		// Log error but don't fail - return link without enrichment
-		// In production, you might want to queue this for retry
-		return link, nil // or return error if you want to fail fast
+		// The link is saved either way, so return it without enrichment, as
+		// for a failed scrape; that includes a scrape cut short by shutdown,
+		// which the client can retry with EnrichLink
+		return link, nil
 	}
 
 	// Step 3: Merge scraped content (only fill empty fields if OnlyFillEmpty is true)
-	update := models.LinkUpdate{}
-	changed := false
-
-	if scrapeOptions.OnlyFillEmpty {
-		// Only update fields that are currently empty
-		if (link.Title == nil || strings.TrimSpace(*link.Title) == "") && scrapeResult.Title != "" {
-			title := scrapeResult.Title
-			update.Title = &title
-			changed = true
-		}
-
-		if (link.Text == nil || strings.TrimSpace(*link.Text) == "") && scrapeResult.Text != "" {
-			text := scrapeResult.Text
-			update.Text = &text
-			changed = true
-		}
-	} else {
-		// Overwrite with scraped content
-		if scrapeResult.Title != "" {
-			title := scrapeResult.Title
-			update.Title = &title
-			changed = true
-		}
-		if scrapeResult.Text != "" {
-			text := scrapeResult.Text
-			update.Text = &text
-			changed = true
-		}
-	}
+	update, changed := s.mergeScrapeResult(link, scrapeResult, scrapeOptions.OnlyFillEmpty)
 
 	// Step 4: Update link with enriched content
 	if changed {
@@ -120,63 +478,123 @@ func (s *LinkService) CreateLinkWithScraping(
 			// Log error but return original link
 			return link, nil
 		}
-		return updated, nil
+		link = updated
 	}
 
-	return link, nil
+	// Step 5: Record the successful scrape
+	scraped, err := s.db.MarkLinkScraped(ctx, link.ID, userID, contentHash(scrapeResult))
+	if err != nil {
+		return link, nil
+	}
+	return scraped, nil
 }
 
-// EnrichLink enriches an existing link with scraped content
+// EnrichLink enriches an existing link with scraped content. The returned bool
+// is false when the link was left unchanged (see EnrichLinkWithProgress).
 func (s *LinkService) EnrichLink(
 	ctx context.Context,
 	linkID, userID uuid.UUID,
 	scrapeOptions ScrapeOptions,
-) (*models.Link, error) {
+) (*models.Link, bool, error) {
+	return s.EnrichLinkWithProgress(ctx, linkID, userID, scrapeOptions, nil)
+}
+
+// EnrichLinkWithProgress enriches an existing link, reporting scrape stages to onProgress (may be nil).
+// If the scraped content hashes the same as the last scrape, the link is not
+// rewritten (so updated_at is kept) and the returned bool is false.
+func (s *LinkService) EnrichLinkWithProgress(
+	ctx context.Context,
+	linkID, userID uuid.UUID,
+	scrapeOptions ScrapeOptions,
+	onProgress scraper.ProgressCallback,
+) (*models.Link, bool, error) {
 	// Get existing link
 	link, err := s.GetLink(ctx, linkID, userID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
+	release, err := s.acquireScrape()
+	if err != nil {
+		return nil, false, err
+	}
+	defer release()
+
 	// Scrape the URL
-	scrapeResult, err := s.scraper.ScrapeWithContext(ctx, link.URL, scrapeOptions.TimeoutSeconds)
+	scrapeCtx, cancel := s.scrapeContext(ctx)
+	defer cancel()
+	scrapeResult, err := s.scraper.ScrapeWithProgress(scrapeCtx, link.URL, scrapeOptions.TimeoutSeconds, onProgress)
+	if err != nil {
+		if shutdownError(scrapeCtx) {
+			return nil, false, ErrShuttingDown
+		}
+		return nil, false, fmt.Errorf("failed to scrape URL: %w", err)
+	}
+
+	// Merge scraped content, unless it is identical to the last scrape
+	hash := contentHash(scrapeResult)
+	changed := false
+	if link.ContentHash == nil || *link.ContentHash != hash {
+		var update models.LinkUpdate
+		update, changed = s.mergeScrapeResult(link, scrapeResult, scrapeOptions.OnlyFillEmpty)
+		if changed {
+			if _, err := s.UpdateLink(ctx, linkID, userID, update); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	// Record the scrape even when nothing changed, so the link is no longer stale
+	scraped, err := s.db.MarkLinkScraped(ctx, linkID, userID, hash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to scrape URL: %w", err)
+		return nil, false, err
+	}
+	return scraped, changed, nil
+}
+
+// contentHash fingerprints scraped content. Whitespace is normalized so
+// insignificant formatting differences between scrapes don't count as changes.
+func contentHash(result *scraper.ScrapeResponse) string {
+	h := sha256.New()
+	for _, field := range []string{result.Title, result.Description, result.Text, result.Favicon, result.SiteName} {
+		h.Write([]byte(strings.Join(strings.Fields(field), " ")))
+		h.Write([]byte{0}) // separator, so content can't shift between fields
+	}
+	// Hashed only when present, so links scraped before images were
+	// recorded don't all read as changed on their next scrape
+	if result.ImageURL != "" {
+		h.Write([]byte(result.ImageURL))
 	}
+	return hex.EncodeToString(h.Sum(nil))
+}
 
-	// Merge scraped content
+// mergeScrapeResult builds an update from scraped content. When onlyFillEmpty is
+// true, fields the link already has are left untouched; otherwise any non-empty
+// scraped value overwrites the stored one. Scraped values longer than the field
+// limits are clipped to fit. Returns false if nothing would change.
+func (s *LinkService) mergeScrapeResult(link *models.Link, result *scraper.ScrapeResponse, onlyFillEmpty bool) (models.LinkUpdate, bool) {
 	update := models.LinkUpdate{}
 	changed := false
 
-	if scrapeOptions.OnlyFillEmpty {
-		if (link.Title == nil || strings.TrimSpace(*link.Title) == "") && scrapeResult.Title != "" {
-			title := scrapeResult.Title
-			update.Title = &title
-			changed = true
-		}
-		if (link.Text == nil || strings.TrimSpace(*link.Text) == "") && scrapeResult.Text != "" {
-			text := scrapeResult.Text
-			update.Text = &text
-			changed = true
-		}
-	} else {
-		if scrapeResult.Title != "" {
-			title := scrapeResult.Title
-			update.Title = &title
-			changed = true
+	merge := func(current *string, scraped string) *string {
+		if scraped == "" {
+			return nil
 		}
-		if scrapeResult.Text != "" {
-			text := scrapeResult.Text
-			update.Text = &text
-			changed = true
+		if onlyFillEmpty && current != nil && strings.TrimSpace(*current) != "" {
+			return nil
 		}
+		changed = true
+		return &scraped
 	}
 
-	if !changed {
-		return link, nil
-	}
+	update.Title = merge(link.Title, clipRunes(result.Title, models.MaxTitleLength))
+	update.Text = merge(link.Text, clipRunes(result.Text, s.maxText))
+	update.Description = merge(link.Description, clipRunes(result.Description, models.MaxDescriptionLength))
+	update.Favicon = merge(link.Favicon, result.Favicon)
+	update.SiteName = merge(link.SiteName, result.SiteName)
+	update.ImageURL = merge(link.ImageURL, result.ImageURL)
 
-	return s.UpdateLink(ctx, linkID, userID, update)
+	return update, changed
 }
 
 // ScrapeOptions configures scraping behavior
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"link-mgmt/pkg/db"
+	"link-mgmt/pkg/db/dbtest"
+	"link-mgmt/pkg/models"
+	"link-mgmt/pkg/scraper"
+
+	"github.com/google/uuid"
+)
+
+// strPtr returns a pointer to s
+func strPtr(s string) *string {
+	return &s
+}
+
+// deref returns *s, or "<nil>" for a nil pointer
+func deref(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
+
+func TestMergeScrapeResultDescription(t *testing.T) {
+	service := NewLinkService(nil, nil)
+
+	tests := []struct {
+		name          string
+		current       *string
+		scraped       string
+		onlyFillEmpty bool
+		want          *string // nil leaves the description unchanged
+	}{
+		{"fills a missing description", nil, "Scraped summary", true, strPtr("Scraped summary")},
+		{"fills a blank description", strPtr("  "), "Scraped summary", true, strPtr("Scraped summary")},
+		{"keeps an existing description", strPtr("Mine"), "Scraped summary", true, nil},
+		{"overwrites when asked", strPtr("Mine"), "Scraped summary", false, strPtr("Scraped summary")},
+		{"no scraped description leaves it empty", nil, "", true, nil},
+		{"no scraped description keeps the existing one on overwrite", strPtr("Mine"), "", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link := &models.Link{URL: "https://example.com", Description: tt.current}
+			update, changed := service.mergeScrapeResult(link, &scraper.ScrapeResponse{Description: tt.scraped}, tt.onlyFillEmpty)
+			if deref(update.Description) != deref(tt.want) {
+				t.Errorf("Description = %s, want %s", deref(update.Description), deref(tt.want))
+			}
+			if changed != (tt.want != nil) {
+				t.Errorf("changed = %v, want %v", changed, tt.want != nil)
+			}
+		})
+	}
+}
+
+func TestMergeScrapeResultImageURL(t *testing.T) {
+	service := NewLinkService(nil, nil)
+
+	tests := []struct {
+		name          string
+		current       *string
+		scraped       string
+		onlyFillEmpty bool
+		want          *string // nil leaves the image URL unchanged
+	}{
+		{"fills a missing image", nil, "https://example.com/og.webp", true, strPtr("https://example.com/og.webp")},
+		{"fills a blank image", strPtr(""), "https://example.com/og.webp", true, strPtr("https://example.com/og.webp")},
+		{"keeps an existing image", strPtr("https://example.com/old.png"), "https://example.com/og.webp", true, nil},
+		{"overwrites when asked", strPtr("https://example.com/old.png"), "https://example.com/og.webp", false, strPtr("https://example.com/og.webp")},
+		{"no og:image derives nothing", nil, "", true, nil},
+		{"no og:image keeps the existing one on overwrite", strPtr("https://example.com/old.png"), "", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link := &models.Link{URL: "https://example.com", ImageURL: tt.current}
+			update, changed := service.mergeScrapeResult(link, &scraper.ScrapeResponse{ImageURL: tt.scraped}, tt.onlyFillEmpty)
+			if deref(update.ImageURL) != deref(tt.want) {
+				t.Errorf("ImageURL = %s, want %s", deref(update.ImageURL), deref(tt.want))
+			}
+			if changed != (tt.want != nil) {
+				t.Errorf("changed = %v, want %v", changed, tt.want != nil)
+			}
+		})
+	}
+}
+
+func TestMergeScrapeResultClipsDescription(t *testing.T) {
+	service := NewLinkService(nil, nil)
+	long := strings.Repeat("é", models.MaxDescriptionLength+10)
+
+	update, _ := service.mergeScrapeResult(&models.Link{}, &scraper.ScrapeResponse{Description: long}, true)
+	if update.Description == nil {
+		t.Fatal("Description not filled")
+	}
+	if n := utf8.RuneCountInString(*update.Description); n != models.MaxDescriptionLength {
+		t.Errorf("Description has %d characters, want it clipped to %d", n, models.MaxDescriptionLength)
+	}
+}
+
+func TestMergeScrapeResultFillsOnlyEmptyFields(t *testing.T) {
+	service := NewLinkService(nil, nil)
+	link := &models.Link{Title: strPtr("My title"), Text: strPtr("")}
+	result := &scraper.ScrapeResponse{Title: "Scraped title", Text: "Scraped text", Description: "Scraped summary", SiteName: "Example"}
+
+	update, changed := service.mergeScrapeResult(link, result, true)
+	if !changed {
+		t.Fatal("changed = false, want true")
+	}
+	if update.Title != nil {
+		t.Errorf("Title = %q, want the user's title kept", *update.Title)
+	}
+	if deref(update.Text) != "Scraped text" || deref(update.Description) != "Scraped summary" || deref(update.SiteName) != "Example" {
+		t.Errorf("update = text %s, description %s, site %s; want the empty fields filled",
+			deref(update.Text), deref(update.Description), deref(update.SiteName))
+	}
+
+	if _, changed := service.mergeScrapeResult(link, &scraper.ScrapeResponse{}, true); changed {
+		t.Error("an empty scrape changed the link")
+	}
+}
+
+func TestReorderLinksValidation(t *testing.T) {
+	service := NewLinkService(nil, nil)
+	id := uuid.New()
+
+	tests := []struct {
+		name string
+		ids  []uuid.UUID
+		want string
+	}{
+		{"no IDs", nil, "at least one link ID is required"},
+		{"duplicate ID", []uuid.UUID{id, uuid.New(), id}, "duplicate link ID: " + id.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ReorderLinks(context.Background(), tt.ids, uuid.New())
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("ReorderLinks: err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLengthsBoundaries(t *testing.T) {
+	service := NewLinkService(nil, nil)
+	service.SetMaxTextLength(500)
+
+	tests := []struct {
+		field string
+		max   int
+		set   func(value string) error
+	}{
+		{"url", models.MaxURLLength, func(v string) error { return service.checkLengths(&v, nil, nil, nil, nil) }},
+		{"title", models.MaxTitleLength, func(v string) error { return service.checkLengths(nil, &v, nil, nil, nil) }},
+		{"description", models.MaxDescriptionLength, func(v string) error { return service.checkLengths(nil, nil, &v, nil, nil) }},
+		{"text", 500, func(v string) error { return service.checkLengths(nil, nil, nil, &v, nil) }},
+		{"notes", models.MaxNotesLength, func(v string) error { return service.checkLengths(nil, nil, nil, nil, &v) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if err := tt.set(strings.Repeat("a", tt.max)); err != nil {
+				t.Errorf("%d characters: %v, want it accepted", tt.max, err)
+			}
+			// Limits count characters, not bytes
+			if err := tt.set(strings.Repeat("é", tt.max)); err != nil {
+				t.Errorf("%d two-byte characters: %v, want it accepted", tt.max, err)
+			}
+
+			err := tt.set(strings.Repeat("a", tt.max+1))
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("%d characters: err = %v, want a ValidationError", tt.max+1, err)
+			}
+			want := fmt.Sprintf("%s must be at most %d characters (got %d)", tt.field, tt.max, tt.max+1)
+			if validationErr.Field != tt.field || validationErr.Message != want {
+				t.Errorf("%d characters: field %q, message %q; want %q, %q", tt.max+1, validationErr.Field, validationErr.Message, tt.field, want)
+			}
+		})
+	}
+}
+
+func TestSetMaxTextLength(t *testing.T) {
+	service := NewLinkService(nil, nil)
+	text := strings.Repeat("a", models.DefaultMaxTextLength+1)
+
+	for _, n := range []int{0, -1} {
+		service.SetMaxTextLength(n)
+		if err := service.checkLengths(nil, nil, nil, &text, nil); err == nil {
+			t.Errorf("SetMaxTextLength(%d) changed the default limit", n)
+		}
+	}
+	service.SetMaxTextLength(models.DefaultMaxTextLength + 1)
+	if err := service.checkLengths(nil, nil, nil, &text, nil); err != nil {
+		t.Errorf("raised limit: %v", err)
+	}
+}
+
+func TestCreateAndUpdateRejectLongFields(t *testing.T) {
+	// Validation happens before the database is touched, so none is needed
+	service := NewLinkService(nil, nil)
+	ctx := context.Background()
+	long := strings.Repeat("t", models.MaxTitleLength+1)
+
+	var validationErr *ValidationError
+	if _, err := service.CreateLink(ctx, uuid.New(), models.LinkCreate{URL: "https://example.com", Title: &long}); !errors.As(err, &validationErr) || validationErr.Field != "title" {
+		t.Errorf("CreateLink: err = %v, want a title ValidationError", err)
+	}
+	if _, err := service.CreateLink(ctx, uuid.New(), models.LinkCreate{URL: "   "}); !errors.As(err, &validationErr) || validationErr.Field != "url" {
+		t.Errorf("CreateLink without a URL: err = %v, want a url ValidationError", err)
+	}
+	if _, err := service.UpdateLink(ctx, uuid.New(), uuid.New(), models.LinkUpdate{Title: &long}); !errors.As(err, &validationErr) || validationErr.Field != "title" {
+		t.Errorf("UpdateLink: err = %v, want a title ValidationError", err)
+	}
+}
+
+func TestTransferLinksTarget(t *testing.T) {
+	database := dbtest.New(t)
+	service := NewLinkService(database, nil)
+	ctx := context.Background()
+
+	user := dbtest.CreateUser(t, database)
+	target := dbtest.CreateUser(t, database)
+	readOnlyKey := dbtest.CreateReadOnlyKey(t, database, target.ID)
+	expired := dbtest.CreateUser(t, database)
+	dbtest.Exec(t, database, `UPDATE users SET expires_at = NOW() - interval '1 second' WHERE id = $1`, expired.ID)
+	link := dbtest.CreateLink(t, database, user.ID, "https://example.com/moving", nil)
+	ids := []uuid.UUID{link.ID}
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"a key nobody holds", dbtest.NewAPIKey(t)},
+		{"a read-only key", readOnlyKey},
+		{"an expired key", expired.APIKey},
+	}
+	for _, tt := range tests {
+		if _, err := service.TransferLinks(ctx, ids, user.ID, tt.key); !errors.Is(err, ErrTransferTarget) {
+			t.Errorf("%s: err = %v, want ErrTransferTarget", tt.name, err)
+		}
+	}
+
+	var validationErr *ValidationError
+	if _, err := service.TransferLinks(ctx, ids, user.ID, user.APIKey); !errors.As(err, &validationErr) {
+		t.Errorf("the same account: err = %v, want a ValidationError", err)
+	}
+	if _, err := database.GetLinkByID(ctx, link.ID, user.ID); err != nil {
+		t.Fatalf("after rejected transfers: link no longer the sender's: %v", err)
+	}
+
+	moved, err := service.TransferLinks(ctx, ids, user.ID, " "+target.APIKey+" ")
+	if err != nil || moved != 1 {
+		t.Fatalf("TransferLinks = %d, %v; want 1, nil", moved, err)
+	}
+	// Presenting the target key isn't a use of it
+	owner, err := database.LookupUserByAPIKey(ctx, target.APIKey)
+	if err != nil {
+		t.Fatalf("LookupUserByAPIKey: %v", err)
+	}
+	if owner.LastUsedAt != nil {
+		t.Errorf("target key recorded as used at %v", owner.LastUsedAt)
+	}
+}
+
+func TestTransferLinksDatabaseError(t *testing.T) {
+	database := dbtest.New(t)
+	service := NewLinkService(database, nil)
+	user := dbtest.CreateUser(t, database)
+	database.Close()
+
+	_, err := service.TransferLinks(context.Background(), []uuid.UUID{uuid.New()}, user.ID, dbtest.NewAPIKey(t))
+	if err == nil || errors.Is(err, ErrTransferTarget) || errors.Is(err, db.ErrUserNotFound) {
+		t.Errorf("with the database closed: err = %v, want the database error", err)
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// newVerifyingService returns a link service whose VerifyURL may reach the
+// loopback test servers
+func newVerifyingService() *LinkService {
+	service := NewLinkService(nil, nil)
+	service.verifier = newVerifyClient(true)
+	return service
+}
+
+// stubSite starts a server answering each method with the given status
+// (200 for the rest) and returns its URL and the methods it was sent
+func stubSite(t *testing.T, statuses map[string]int) (string, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		if status, ok := statuses[r.Method]; ok {
+			w.WriteHeader(status)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(methods)
+	}
+}
+
+func TestVerifyURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    map[string]int
+		wantStatus  int // 0 for reachable
+		wantMethods []string
+	}{
+		{"reachable", nil, 0, []string{"HEAD"}},
+		{"no content", map[string]int{"HEAD": http.StatusNoContent}, 0, []string{"HEAD"}},
+		{"not found", map[string]int{"HEAD": http.StatusNotFound}, http.StatusNotFound, []string{"HEAD"}},
+		{"server error", map[string]int{"HEAD": http.StatusBadGateway}, http.StatusBadGateway, []string{"HEAD"}},
+		{"HEAD not allowed falls back to GET", map[string]int{"HEAD": http.StatusMethodNotAllowed}, 0, []string{"HEAD", "GET"}},
+		{"HEAD not implemented falls back to GET", map[string]int{"HEAD": http.StatusNotImplemented}, 0, []string{"HEAD", "GET"}},
+		{"GET fallback can fail too", map[string]int{"HEAD": http.StatusMethodNotAllowed, "GET": http.StatusNotFound}, http.StatusNotFound, []string{"HEAD", "GET"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, methods := stubSite(t, tt.statuses)
+
+			err := newVerifyingService().VerifyURL(context.Background(), url+"/page")
+			var unreachable *UnreachableError
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Errorf("VerifyURL: %v, want reachable", err)
+			case tt.wantStatus != 0 && !errors.As(err, &unreachable):
+				t.Errorf("VerifyURL error = %v, want *UnreachableError", err)
+			case tt.wantStatus != 0 && unreachable.StatusCode != tt.wantStatus:
+				t.Errorf("StatusCode = %d, want %d", unreachable.StatusCode, tt.wantStatus)
+			}
+			if got := methods(); !slices.Equal(got, tt.wantMethods) {
+				t.Errorf("requests = %v, want %v", got, tt.wantMethods)
+			}
+		})
+	}
+}
+
+func TestVerifyURLNoAnswer(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	err := newVerifyingService().VerifyURL(context.Background(), url)
+	var unreachable *UnreachableError
+	if !errors.As(err, &unreachable) || unreachable.StatusCode != 0 || unreachable.Err == nil {
+		t.Errorf("VerifyURL error = %v, want *UnreachableError without a status", err)
+	}
+}
+
+func TestVerifyURLRejectsNonHTTP(t *testing.T) {
+	for _, url := range []string{"ftp://example.com/file", "example.com", "/relative", "https://"} {
+		err := newVerifyingService().VerifyURL(context.Background(), url)
+		var validation *ValidationError
+		if !errors.As(err, &validation) || validation.Field != "url" {
+			t.Errorf("VerifyURL(%q) error = %v, want a url ValidationError", url, err)
+		}
+	}
+}
+
+func TestVerifyURLRefusesPrivateAddresses(t *testing.T) {
+	url, methods := stubSite(t, nil)
+
+	err := NewLinkService(nil, nil).VerifyURL(context.Background(), url)
+	var unreachable *UnreachableError
+	if !errors.As(err, &unreachable) || !errors.Is(err, errDisallowedAddress) {
+		t.Errorf("VerifyURL(%s) error = %v, want it refused as a private address", url, err)
+	}
+	if got := methods(); len(got) != 0 {
+		t.Errorf("loopback server received %v, want no requests", got)
+	}
+}
+
+func TestRefusePrivateAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		refused bool
+	}{
+		{"127.0.0.1:80", true},
+		{"127.8.9.10:8080", true},
+		{"[::1]:443", true},
+		{"10.1.2.3:80", true},
+		{"172.16.0.1:80", true},
+		{"192.168.1.1:80", true},
+		{"169.254.169.254:80", true},
+		{"[fe80::1]:80", true},
+		{"[fd00::1]:80", true},
+		{"0.0.0.0:80", true},
+		{"[::]:80", true},
+		{"[::ffff:127.0.0.1]:80", true},
+		{"[::ffff:10.0.0.1]:80", true},
+		{"93.184.216.34:80", false},
+		{"172.32.0.1:443", false},
+		{"[2606:4700:4700::1111]:443", false},
+	}
+	for _, tt := range tests {
+		err := refusePrivateAddress("tcp", tt.address, nil)
+		if got := errors.Is(err, errDisallowedAddress); got != tt.refused {
+			t.Errorf("refusePrivateAddress(%s) = %v, want refused %v", tt.address, err, tt.refused)
+		}
+	}
+}
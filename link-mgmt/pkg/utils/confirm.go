@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IsConfirmed reports whether a y/N prompt answer accepts: "y" or "yes" in
+// any case. Anything else, including an empty answer, means No.
+func IsConfirmed(answer string) bool {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// NeedsCountConfirmation reports whether deleting count items at once asks
+// for the count to be typed rather than y/N: only when count is above
+// threshold. A negative threshold never asks.
+func NeedsCountConfirmation(count, threshold int) bool {
+	return threshold >= 0 && count > threshold
+}
+
+// IsCountConfirmed reports whether a typed-count prompt answer accepts: the
+// exact count, ignoring surrounding whitespace. Anything else means No.
+func IsCountConfirmed(answer string, count int) bool {
+	return strings.TrimSpace(answer) == strconv.Itoa(count)
+}
@@ -0,0 +1,71 @@
+package utils
+
+import "testing"
+
+func TestIsConfirmed(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"y", true},
+		{"Y", true},
+		{"yes", true},
+		{"YES", true},
+		{"Yes", true},
+		{"  y \n", true},
+		{"", false},
+		{"\n", false},
+		{"   ", false},
+		{"n", false},
+		{"no", false},
+		{"yep", false},
+		{"ye", false},
+		{"y y", false},
+	}
+	for _, tt := range tests {
+		if got := IsConfirmed(tt.answer); got != tt.want {
+			t.Errorf("IsConfirmed(%q) = %v, want %v", tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestNeedsCountConfirmation(t *testing.T) {
+	tests := []struct {
+		count, threshold int
+		want             bool
+	}{
+		{1, 5, false},
+		{5, 5, false},
+		{6, 5, true},
+		{100, 5, true},
+		{1, 0, true},
+		{0, 0, false},
+		{100, -1, false},
+	}
+	for _, tt := range tests {
+		if got := NeedsCountConfirmation(tt.count, tt.threshold); got != tt.want {
+			t.Errorf("NeedsCountConfirmation(%d, %d) = %v, want %v", tt.count, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestIsCountConfirmed(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"12", true},
+		{" 12\n", true},
+		{"11", false},
+		{"012", false},
+		{"y", false},
+		{"yes", false},
+		{"", false},
+		{"12 links", false},
+	}
+	for _, tt := range tests {
+		if got := IsCountConfirmed(tt.answer, 12); got != tt.want {
+			t.Errorf("IsCountConfirmed(%q, 12) = %v, want %v", tt.answer, got, tt.want)
+		}
+	}
+}
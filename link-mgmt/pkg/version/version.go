@@ -0,0 +1,43 @@
+// Package version holds build metadata for the CLI and API, injected at build
+// time with -ldflags, e.g.
+//
+//	go build -ldflags "-X link-mgmt/pkg/version.Version=v1.2.0" ./cmd/cli
+package version
+
+import "fmt"
+
+// Set via -ldflags -X; unset values fall back to "dev"
+var (
+	Version   = "dev"
+	Commit    = "dev"
+	BuildDate = "dev"
+)
+
+// Info is the build metadata as reported by --version and GET /api/v1/version
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+}
+
+// Get returns the current build metadata
+func Get() Info {
+	return Info{
+		Version:   orDev(Version),
+		Commit:    orDev(Commit),
+		BuildDate: orDev(BuildDate),
+	}
+}
+
+// String formats the build metadata on one line
+func (i Info) String() string {
+	return fmt.Sprintf("%s (commit %s, built %s)", i.Version, i.Commit, i.BuildDate)
+}
+
+// orDev treats an explicitly empty -X value the same as an unset one
+func orDev(s string) string {
+	if s == "" {
+		return "dev"
+	}
+	return s
+}
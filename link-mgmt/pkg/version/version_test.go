@@ -0,0 +1,18 @@
+package version
+
+import "testing"
+
+func TestGet(t *testing.T) {
+	previous := []string{Version, Commit, BuildDate}
+	t.Cleanup(func() { Version, Commit, BuildDate = previous[0], previous[1], previous[2] })
+
+	Version, Commit, BuildDate = "v1.2.0", "", "2024-03-01"
+	got := Get()
+	want := Info{Version: "v1.2.0", Commit: "dev", BuildDate: "2024-03-01"}
+	if got != want {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+	if s := got.String(); s != "v1.2.0 (commit dev, built 2024-03-01)" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -24,7 +24,11 @@ export class BrowserManager {
     }
   }
 
-  async extractFromUrl(url: string, timeout: number = 10000): Promise<string> {
+  async extractFromUrl(
+    url: string,
+    timeout: number = 10000,
+    userAgent?: string
+  ): Promise<string> {
     if (!this.context) {
       const error = createScrapeError(
         ScrapeErrorType.BROWSER_ERROR,
@@ -50,6 +54,10 @@ export class BrowserManager {
 
     const page = await this.context.newPage();
     try {
+      if (userAgent) {
+        // Some sites refuse the headless browser's default user agent
+        await page.setExtraHTTPHeaders({ "User-Agent": userAgent });
+      }
       await page.goto(url, { waitUntil: "networkidle", timeout });
       const content = await page.content();
       return content;
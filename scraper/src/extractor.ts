@@ -8,12 +8,59 @@ export function cleanupText(text: string): string {
   return text.replace(/^[\n\r]+|[\n\r]+$/g, "").trim();
 }
 
+/**
+ * Finds the page's favicon as an absolute URL, falling back to /favicon.ico
+ */
+export function extractFavicon(dom: JSDOM, url: string): string {
+  const icon = dom.window.document.querySelector(
+    'link[rel~="icon"], link[rel="shortcut icon"], link[rel="apple-touch-icon"]'
+  );
+  const href = icon?.getAttribute("href");
+  try {
+    return new URL(href || "/favicon.ico", url).toString();
+  } catch {
+    return "";
+  }
+}
+
+/**
+ * Reads the page's meta description (standard, Open Graph, or Twitter card)
+ */
+export function extractDescription(dom: JSDOM): string {
+  const meta = dom.window.document.querySelector(
+    'meta[name="description"], meta[property="og:description"], meta[name="twitter:description"]'
+  );
+  return cleanupText(meta?.getAttribute("content") || "");
+}
+
+/**
+ * Reads the page's preview image (Open Graph or Twitter card) as an absolute URL
+ */
+export function extractImageURL(dom: JSDOM, url: string): string {
+  const meta = dom.window.document.querySelector(
+    'meta[property="og:image"], meta[property="og:image:url"], meta[name="twitter:image"]'
+  );
+  const content = meta?.getAttribute("content")?.trim();
+  if (!content) {
+    return "";
+  }
+  try {
+    return new URL(content, url).toString();
+  } catch {
+    return "";
+  }
+}
+
 export async function extractMainContent(
   html: string,
   url: string
 ): Promise<ExtractedContent | null> {
   try {
     const dom = new JSDOM(html, { url });
+    // Read metadata before Readability, which mutates the document
+    const favicon = extractFavicon(dom, url);
+    const description = extractDescription(dom);
+    const imageURL = extractImageURL(dom, url);
     const reader = new Readability(dom.window.document);
     const article = reader.parse();
 
@@ -28,6 +75,10 @@ export async function extractMainContent(
     return {
       title: article.title || "",
       text: cleanupText(article.textContent || ""),
+      description: description || undefined,
+      favicon,
+      site_name: article.siteName || undefined,
+      image_url: imageURL || undefined,
     };
   } catch (error) {
     // Categorize extraction errors
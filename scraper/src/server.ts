@@ -121,7 +121,11 @@ async function handleScrape(request: Request): Promise<Response> {
     );
   }
 
-  const { url, timeout = 10000 } = body as { url: string; timeout?: number };
+  const {
+    url,
+    timeout = 10000,
+    user_agent: userAgent,
+  } = body as { url: string; timeout?: number; user_agent?: string };
 
   if (!url || typeof url !== "string") {
     logger.warn("Invalid scrape request: URL is not a string", { url });
@@ -168,7 +172,7 @@ async function handleScrape(request: Request): Promise<Response> {
 
   try {
     const scrapeStartTime = Date.now();
-    const html = await manager.extractFromUrl(url, timeout);
+    const html = await manager.extractFromUrl(url, timeout, userAgent);
     const extractionStartTime = Date.now();
     const extracted = await extractMainContent(html, url);
     const extractionDuration = Date.now() - extractionStartTime;
@@ -232,6 +236,10 @@ async function handleScrape(request: Request): Promise<Response> {
         url,
         title: extracted.title || "",
         text: extracted.text || "",
+        description: extracted.description || undefined,
+        favicon: extracted.favicon || undefined,
+        site_name: extracted.site_name || undefined,
+        image_url: extracted.image_url || undefined,
         extracted_at: new Date().toISOString(),
       },
       200
@@ -276,9 +284,14 @@ async function handleBatchScrape(request: Request): Promise<Response> {
     return sendJSON({ error: "urls must be an array" }, 400);
   }
 
-  const { urls, timeout = 10000 } = body as {
+  const {
+    urls,
+    timeout = 10000,
+    user_agent: userAgent,
+  } = body as {
     urls: string[];
     timeout?: number;
+    user_agent?: string;
   };
 
   if (!initialized || !manager) {
@@ -300,7 +313,7 @@ async function handleBatchScrape(request: Request): Promise<Response> {
   for (const url of urls) {
     try {
       const urlStartTime = Date.now();
-      const html = await manager.extractFromUrl(url, timeout);
+      const html = await manager.extractFromUrl(url, timeout, userAgent);
       const extracted = await extractMainContent(html, url);
       const urlDuration = Date.now() - urlStartTime;
 
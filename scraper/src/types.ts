@@ -13,6 +13,10 @@ export interface ExtractionResult {
 export interface ExtractedContent {
   title: string;
   text: string;
+  description?: string;
+  favicon?: string;
+  site_name?: string;
+  image_url?: string;
 }
 
 export interface ScrapeResponse {
@@ -20,6 +24,10 @@ export interface ScrapeResponse {
   url: string;
   title?: string;
   text?: string;
+  description?: string;
+  favicon?: string;
+  site_name?: string;
+  image_url?: string;
   extracted_at?: string;
   error?: string;
   error_type?: ScrapeErrorType;